@@ -0,0 +1,414 @@
+package api
+
+import (
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/recurrence"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"github.com/seppo0010/boardgamesorganizer/webhooks"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrUnauthorized = errors.New("Missing or invalid token")
+var ErrNotFound = errors.New("Not found")
+var ErrMethodNotAllowed = errors.New("Method not allowed")
+var ErrInvalidBody = errors.New("Invalid request body")
+
+//go:embed openapi.yaml
+var openAPI []byte
+
+type Server struct {
+	Meetings         *meetings.Factory
+	Users            users.Factory
+	Tokens           map[string]string
+	Webhooks         webhooks.Store
+	OnMeetingCreated func(groupID string)
+	OnRSVP           func(groupID string)
+}
+
+type Group struct {
+	ID         string `json:"id"`
+	Source     int    `json:"source"`
+	ExternalID string `json:"external_id"`
+}
+
+type Meeting struct {
+	ID         string     `json:"id"`
+	Time       time.Time  `json:"time"`
+	Location   string     `json:"location"`
+	Capacity   int        `json:"capacity"`
+	Recurrence string     `json:"recurrence,omitempty"`
+	End        *time.Time `json:"end,omitempty"`
+	// RSVPDeadline defaults to the start of the meeting
+	RSVPDeadline *time.Time `json:"rsvp_deadline,omitempty"`
+	MaxGuests    int        `json:"max_guests,omitempty"`
+}
+
+type Attendee struct {
+	UserID      string `json:"user_id"`
+	DisplayName string `json:"display_name"`
+	Amount      int    `json:"amount"`
+	Maybe       bool   `json:"maybe,omitempty"`
+}
+
+type RSVP struct {
+	Amount int  `json:"amount"`
+	Maybe  bool `json:"maybe,omitempty"`
+}
+
+type Webhook struct {
+	ID     string           `json:"id"`
+	URL    string           `json:"url"`
+	Events []webhooks.Event `json:"events"`
+	Secret string           `json:"secret,omitempty"`
+}
+
+type WebhookDelivery struct {
+	ID         string         `json:"id"`
+	Event      webhooks.Event `json:"event"`
+	Payload    string         `json:"payload"`
+	Attempt    int            `json:"attempt"`
+	StatusCode int            `json:"status_code"`
+	Error      string         `json:"error"`
+	Time       time.Time      `json:"time"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		log.Printf("failed to write response: %#v", err)
+	}
+}
+
+func meetingResponse(meeting *meetings.Meeting) *Meeting {
+	response := &Meeting{ID: meeting.ID, Time: meeting.Time, Location: meeting.Location, Capacity: meeting.Capacity, Recurrence: meeting.Recurrence, MaxGuests: meeting.MaxGuests}
+	if meeting.Duration > 0 {
+		end := meeting.End()
+		response.End = &end
+	}
+	if meeting.RSVPCutoff > 0 {
+		deadline := meeting.RSVPDeadline()
+		response.RSVPDeadline = &deadline
+	}
+	return response
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch err {
+	case ErrUnauthorized:
+		status = http.StatusUnauthorized
+	case ErrNotFound, meetings.NoActiveMeeting, users.GroupNotFound, webhooks.SubscriptionNotFound:
+		status = http.StatusNotFound
+	case ErrMethodNotAllowed:
+		status = http.StatusMethodNotAllowed
+	case ErrInvalidBody, meetings.MeetingIsInThePast, meetings.TooManyGuests, recurrence.ErrInvalidRule:
+		status = http.StatusBadRequest
+	case meetings.MeetingAlreadyActive, meetings.MeetingIsFull, meetings.MeetingsOverlap, meetings.RSVPClosed, meetings.SignUpNotOpen:
+		status = http.StatusConflict
+	default:
+		log.Printf("api error: %#v", err)
+		err = meetings.UnexpectedError
+	}
+	writeJSON(w, status, &errorResponse{Error: err.Error()})
+}
+
+func (s *Server) authorized(r *http.Request, groupID string) bool {
+	token, found := s.Tokens[groupID]
+	if !found || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) == 1
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(path) == 1 && path[0] == "openapi.yaml" {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(openAPI)
+		return
+	}
+	if len(path) < 2 || path[0] != "groups" {
+		writeError(w, ErrNotFound)
+		return
+	}
+	groupID := path[1]
+	if !s.authorized(r, groupID) {
+		writeError(w, ErrUnauthorized)
+		return
+	}
+	switch {
+	case len(path) == 2:
+		s.group(w, r, groupID)
+	case len(path) == 3 && path[2] == "meeting":
+		s.meeting(w, r, groupID)
+	case len(path) == 4 && path[2] == "meeting" && path[3] == "attendees":
+		s.attendees(w, r, groupID)
+	case len(path) == 5 && path[2] == "meeting" && path[3] == "attendees":
+		s.rsvp(w, r, groupID, path[4])
+	case s.Webhooks != nil && len(path) == 3 && path[2] == "webhooks":
+		s.webhooks(w, r, groupID)
+	case s.Webhooks != nil && len(path) == 4 && path[2] == "webhooks":
+		s.webhook(w, r, groupID, path[3])
+	case s.Webhooks != nil && len(path) == 5 && path[2] == "webhooks" && path[4] == "deliveries":
+		s.webhookDeliveries(w, r, groupID, path[3])
+	default:
+		writeError(w, ErrNotFound)
+	}
+}
+
+func (s *Server) group(w http.ResponseWriter, r *http.Request, groupID string) {
+	if r.Method != http.MethodGet {
+		writeError(w, ErrMethodNotAllowed)
+		return
+	}
+	group, err := s.Users.GetExternalGroup(groupID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, &Group{ID: groupID, Source: int(group.Source), ExternalID: group.ID})
+}
+
+func (s *Server) meeting(w http.ResponseWriter, r *http.Request, groupID string) {
+	switch r.Method {
+	case http.MethodGet:
+		meeting, err := s.Meetings.GetMeeting(groupID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, meetingResponse(meeting))
+	case http.MethodPost:
+		body := &Meeting{}
+		err := json.NewDecoder(r.Body).Decode(body)
+		if err != nil || body.Time.IsZero() || body.Location == "" || body.Capacity < 0 || (body.End != nil && !body.End.After(body.Time)) || (body.RSVPDeadline != nil && body.RSVPDeadline.After(body.Time)) || body.MaxGuests < meetings.NoGuests {
+			writeError(w, ErrInvalidBody)
+			return
+		}
+		meeting := &meetings.Meeting{
+			Time:      body.Time.UTC(),
+			Location:  body.Location,
+			Capacity:  body.Capacity,
+			MaxGuests: body.MaxGuests,
+		}
+		if body.End != nil {
+			meeting.Duration = body.End.Sub(body.Time)
+		}
+		if body.RSVPDeadline != nil {
+			meeting.RSVPCutoff = body.Time.Sub(*body.RSVPDeadline)
+		}
+		if body.Recurrence != "" {
+			rule, err := recurrence.Parse(body.Recurrence)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			meeting.Recurrence = rule.String()
+			body.Recurrence = meeting.Recurrence
+		}
+		err = s.Meetings.CreateMeeting(groupID, meeting)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		body.ID = meeting.ID
+		if s.OnMeetingCreated != nil {
+			s.OnMeetingCreated(groupID)
+		}
+		writeJSON(w, http.StatusCreated, body)
+	case http.MethodDelete:
+		err := s.Meetings.CancelMeeting(groupID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, ErrMethodNotAllowed)
+	}
+}
+
+func (s *Server) attendees(w http.ResponseWriter, r *http.Request, groupID string) {
+	if r.Method != http.MethodGet {
+		writeError(w, ErrMethodNotAllowed)
+		return
+	}
+	if _, err := s.Meetings.GetMeeting(groupID); err != nil {
+		writeError(w, err)
+		return
+	}
+	attendees, err := s.Meetings.GetMeetingAttendees(groupID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	userIDs := make([]string, len(attendees))
+	for i, attendee := range attendees {
+		userIDs[i] = attendee.UserID
+	}
+	usersMap, err := s.Users.GetUsers(userIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	retval := make([]*Attendee, 0, len(attendees))
+	for _, attendee := range attendees {
+		if attendee.Amount <= 0 {
+			continue
+		}
+		displayName := ""
+		if user, found := usersMap[attendee.UserID]; found {
+			displayName = user.DisplayName
+		}
+		retval = append(retval, &Attendee{UserID: attendee.UserID, DisplayName: displayName, Amount: attendee.Amount, Maybe: attendee.Maybe})
+	}
+	writeJSON(w, http.StatusOK, retval)
+}
+
+func (s *Server) rsvp(w http.ResponseWriter, r *http.Request, groupID string, userID string) {
+	if r.Method != http.MethodPut {
+		writeError(w, ErrMethodNotAllowed)
+		return
+	}
+	body := &RSVP{}
+	err := json.NewDecoder(r.Body).Decode(body)
+	if err != nil || body.Amount < 0 {
+		writeError(w, ErrInvalidBody)
+		return
+	}
+	if _, err := s.Users.GetExternalUser(userID); err != nil {
+		if err == users.UserNotFound {
+			writeError(w, ErrNotFound)
+			return
+		}
+		writeError(w, err)
+		return
+	}
+	err = s.Meetings.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: userID, Amount: body.Amount, Maybe: body.Maybe})
+	if err != nil && err != meetings.UserAlreadyAttendsMeeting && err != meetings.UserDoesNotAttendMeeting {
+		writeError(w, err)
+		return
+	}
+	if err == nil && s.OnRSVP != nil {
+		s.OnRSVP(groupID)
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+func validWebhook(body *Webhook) bool {
+	u, err := url.Parse(body.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+	if len(body.Events) == 0 {
+		return false
+	}
+	for _, event := range body.Events {
+		if !webhooks.IsEvent(event) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) webhooks(w http.ResponseWriter, r *http.Request, groupID string) {
+	switch r.Method {
+	case http.MethodGet:
+		subscriptions, err := s.Webhooks.GetSubscriptions(groupID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		retval := make([]*Webhook, len(subscriptions))
+		for i, subscription := range subscriptions {
+			retval[i] = &Webhook{ID: subscription.ID, URL: subscription.URL, Events: subscription.Events}
+		}
+		writeJSON(w, http.StatusOK, retval)
+	case http.MethodPost:
+		body := &Webhook{}
+		err := json.NewDecoder(r.Body).Decode(body)
+		if err != nil || !validWebhook(body) {
+			writeError(w, ErrInvalidBody)
+			return
+		}
+		secret, err := webhooks.NewSecret()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		subscription := &webhooks.Subscription{GroupID: groupID, URL: body.URL, Secret: secret, Events: body.Events}
+		err = s.Webhooks.CreateSubscription(subscription)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, &Webhook{ID: subscription.ID, URL: subscription.URL, Events: subscription.Events, Secret: secret})
+	default:
+		writeError(w, ErrMethodNotAllowed)
+	}
+}
+
+func (s *Server) webhook(w http.ResponseWriter, r *http.Request, groupID string, subscriptionID string) {
+	if r.Method != http.MethodDelete {
+		writeError(w, ErrMethodNotAllowed)
+		return
+	}
+	err := s.Webhooks.DeleteSubscription(groupID, subscriptionID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) webhookDeliveries(w http.ResponseWriter, r *http.Request, groupID string, subscriptionID string) {
+	if r.Method != http.MethodGet {
+		writeError(w, ErrMethodNotAllowed)
+		return
+	}
+	subscriptions, err := s.Webhooks.GetSubscriptions(groupID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	found := false
+	for _, subscription := range subscriptions {
+		found = found || subscription.ID == subscriptionID
+	}
+	if !found {
+		writeError(w, webhooks.SubscriptionNotFound)
+		return
+	}
+	deliveries, err := s.Webhooks.GetDeliveries(subscriptionID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	retval := make([]*WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		retval[i] = &WebhookDelivery{
+			ID:         delivery.ID,
+			Event:      delivery.Event,
+			Payload:    delivery.Payload,
+			Attempt:    delivery.Attempt,
+			StatusCode: delivery.StatusCode,
+			Error:      delivery.Error,
+			Time:       delivery.Time,
+		}
+	}
+	writeJSON(w, http.StatusOK, retval)
+}
@@ -0,0 +1,321 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	ftime "github.com/seppo0010/boardgamesorganizer/time"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"github.com/seppo0010/boardgamesorganizer/webhooks"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const token = "s3cr3t"
+
+type testServer struct {
+	*httptest.Server
+	groupID string
+	uf      *users.Memory
+	mf      *meetings.Factory
+	wf      *webhooks.Memory
+}
+
+func newTestServer(t *testing.T) *testServer {
+	uf := users.NewMemory()
+	mf := meetings.NewMemory()
+	mf.SetTimeFactory(&ftime.Fake{CurrentNow: time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC)})
+	groupID, err := uf.GetOrCreateGroup(&users.ExternalGroup{ID: "-100", Source: users.SourceTelegram})
+	if err != nil {
+		t.Fatalf("cannot create group: %#v", err)
+	}
+	wf := webhooks.NewMemory()
+	s := &Server{Meetings: mf, Users: uf, Tokens: map[string]string{groupID: token}, Webhooks: wf}
+	return &testServer{Server: httptest.NewServer(s), groupID: groupID, uf: uf, mf: mf, wf: wf}
+}
+
+func (s *testServer) do(t *testing.T, method, path, authorization string, body interface{}, v interface{}) int {
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("cannot marshal body: %#v", err)
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req, err := http.NewRequest(method, s.URL+path, reader)
+	if err != nil {
+		t.Fatalf("cannot create request: %#v", err)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", "Bearer "+authorization)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("cannot do request: %#v", err)
+	}
+	defer res.Body.Close()
+	if v != nil {
+		if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+			t.Fatalf("cannot decode response: %#v", err)
+		}
+	}
+	return res.StatusCode
+}
+
+func TestUnauthorized(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestServer(t)
+	defer s.Close()
+
+	assert.Equal(s.do(t, "GET", "/groups/"+s.groupID, "", nil, nil), http.StatusUnauthorized)
+	assert.Equal(s.do(t, "GET", "/groups/"+s.groupID, "wrong", nil, nil), http.StatusUnauthorized)
+	assert.Equal(s.do(t, "GET", "/groups/123", token, nil, nil), http.StatusUnauthorized)
+}
+
+func TestGetGroup(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestServer(t)
+	defer s.Close()
+
+	group := &Group{}
+	assert.Equal(s.do(t, "GET", "/groups/"+s.groupID, token, nil, group), http.StatusOK)
+	assert.Equal(group, &Group{ID: s.groupID, Source: users.SourceTelegram, ExternalID: "-100"})
+}
+
+func TestCreateGetDeleteMeeting(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestServer(t)
+	defer s.Close()
+	path := "/groups/" + s.groupID + "/meeting"
+	created := []string{}
+	s.Config.Handler.(*Server).OnMeetingCreated = func(groupID string) {
+		created = append(created, groupID)
+	}
+	cancelled := []string{}
+	s.mf.OnCancel = func(groupID string, meeting *meetings.Meeting) {
+		cancelled = append(cancelled, meeting.Location)
+	}
+
+	assert.Equal(s.do(t, "GET", path, token, nil, nil), http.StatusNotFound)
+
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home", Capacity: 4}
+	assert.Equal(s.do(t, "POST", path, token, m, m), http.StatusCreated)
+	assert.NotEqual(m.ID, "")
+	assert.Equal(s.do(t, "POST", path, token, m, nil), http.StatusConflict)
+	assert.Equal(created, []string{s.groupID})
+
+	m2 := &Meeting{}
+	assert.Equal(s.do(t, "GET", path, token, nil, m2), http.StatusOK)
+	assert.Equal(m, m2)
+
+	assert.Equal(s.do(t, "DELETE", path, token, nil, nil), http.StatusNoContent)
+	assert.Equal(s.do(t, "GET", path, token, nil, nil), http.StatusNotFound)
+	assert.Equal(cancelled, []string{"Home"})
+
+	all, err := s.mf.GetMeetings(s.groupID)
+	assert.NoError(err)
+	assert.Equal(len(all), 1)
+	assert.True(all[0].Cancelled)
+}
+
+func TestCreateMeetingInvalid(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestServer(t)
+	defer s.Close()
+	path := "/groups/" + s.groupID + "/meeting"
+
+	assert.Equal(s.do(t, "POST", path, token, &Meeting{Location: "Home"}, nil), http.StatusBadRequest)
+	past := &Meeting{Time: time.Date(2019, 4, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}
+	assert.Equal(s.do(t, "POST", path, token, past, nil), http.StatusBadRequest)
+	daily := &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home", Recurrence: "FREQ=DAILY"}
+	assert.Equal(s.do(t, "POST", path, token, daily, nil), http.StatusBadRequest)
+	end := time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC)
+	endsBefore := &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home", End: &end}
+	assert.Equal(s.do(t, "POST", path, token, endsBefore, nil), http.StatusBadRequest)
+}
+
+func TestCreateMeetingWithEnd(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestServer(t)
+	defer s.Close()
+	path := "/groups/" + s.groupID + "/meeting"
+
+	end := time.Date(2019, 5, 2, 23, 0, 0, 0, time.UTC)
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home", End: &end}
+	assert.Equal(s.do(t, "POST", path, token, m, m), http.StatusCreated)
+
+	m2 := &Meeting{}
+	assert.Equal(s.do(t, "GET", path, token, nil, m2), http.StatusOK)
+	assert.True(m2.End.Equal(end))
+
+	active, err := s.mf.GetMeeting(s.groupID)
+	assert.NoError(err)
+	assert.Equal(active.Duration, 3*time.Hour)
+}
+
+func TestRSVPDeadline(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestServer(t)
+	defer s.Close()
+	path := "/groups/" + s.groupID + "/meeting"
+
+	deadline := time.Date(2019, 5, 3, 20, 0, 0, 0, time.UTC)
+	late := &Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home", RSVPDeadline: &deadline}
+	assert.Equal(s.do(t, "POST", path, token, late, nil), http.StatusBadRequest)
+
+	deadline = time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home", RSVPDeadline: &deadline}
+	assert.Equal(s.do(t, "POST", path, token, m, nil), http.StatusCreated)
+	m2 := &Meeting{}
+	assert.Equal(s.do(t, "GET", path, token, nil, m2), http.StatusOK)
+	assert.True(m2.RSVPDeadline.Equal(deadline))
+
+	aliceID, err := s.uf.GetOrCreateUser(&users.ExternalUser{ID: "1", Source: users.SourceTelegram, DisplayName: "alice"})
+	assert.NoError(err)
+	assert.Equal(s.do(t, "PUT", path+"/attendees/"+aliceID, token, &RSVP{Amount: 1}, nil), http.StatusConflict)
+}
+
+func TestCreateRecurringMeeting(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestServer(t)
+	defer s.Close()
+	path := "/groups/" + s.groupID + "/meeting"
+
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home", Recurrence: "rrule:freq=weekly;interval=2"}
+	assert.Equal(s.do(t, "POST", path, token, m, m), http.StatusCreated)
+	assert.Equal(m.Recurrence, "FREQ=WEEKLY;INTERVAL=2")
+
+	m2 := &Meeting{}
+	assert.Equal(s.do(t, "GET", path, token, nil, m2), http.StatusOK)
+	assert.Equal(m, m2)
+}
+
+func TestRSVPAndAttendees(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestServer(t)
+	defer s.Close()
+	path := "/groups/" + s.groupID + "/meeting"
+	rsvps := []string{}
+	s.Config.Handler.(*Server).OnRSVP = func(groupID string) {
+		rsvps = append(rsvps, groupID)
+	}
+	aliceID, err := s.uf.GetOrCreateUser(&users.ExternalUser{ID: "1", Source: users.SourceTelegram, DisplayName: "alice"})
+	assert.NoError(err)
+	bobID, err := s.uf.GetOrCreateUser(&users.ExternalUser{ID: "2", Source: users.SourceTelegram, DisplayName: "bob"})
+	assert.NoError(err)
+
+	assert.Equal(s.do(t, "PUT", path+"/attendees/"+aliceID, token, &RSVP{Amount: 1}, nil), http.StatusNotFound)
+
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home", Capacity: 3}
+	assert.Equal(s.do(t, "POST", path, token, m, nil), http.StatusCreated)
+
+	assert.Equal(s.do(t, "PUT", path+"/attendees/"+aliceID, token, &RSVP{Amount: 2}, nil), http.StatusOK)
+	assert.Equal(s.do(t, "PUT", path+"/attendees/"+aliceID, token, &RSVP{Amount: 2}, nil), http.StatusOK)
+	assert.Equal(s.do(t, "PUT", path+"/attendees/"+bobID, token, &RSVP{Amount: 2}, nil), http.StatusConflict)
+	assert.Equal(s.do(t, "PUT", path+"/attendees/"+bobID, token, &RSVP{Amount: 1}, nil), http.StatusOK)
+	assert.Equal(s.do(t, "PUT", path+"/attendees/999", token, &RSVP{Amount: 1}, nil), http.StatusNotFound)
+	assert.Equal(rsvps, []string{s.groupID, s.groupID})
+
+	attendees := []*Attendee{}
+	assert.Equal(s.do(t, "GET", path+"/attendees", token, nil, &attendees), http.StatusOK)
+	assert.Equal(attendees, []*Attendee{
+		&Attendee{UserID: aliceID, DisplayName: "alice", Amount: 2},
+		&Attendee{UserID: bobID, DisplayName: "bob", Amount: 1},
+	})
+
+	assert.Equal(s.do(t, "PUT", path+"/attendees/"+aliceID, token, &RSVP{Amount: 0}, nil), http.StatusOK)
+	attendees = []*Attendee{}
+	assert.Equal(s.do(t, "GET", path+"/attendees", token, nil, &attendees), http.StatusOK)
+	assert.Equal(attendees, []*Attendee{&Attendee{UserID: bobID, DisplayName: "bob", Amount: 1}})
+}
+
+func TestOpenAPI(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestServer(t)
+	defer s.Close()
+
+	res, err := http.Get(s.URL + "/openapi.yaml")
+	assert.NoError(err)
+	defer res.Body.Close()
+	assert.Equal(res.StatusCode, http.StatusOK)
+	assert.Equal(res.Header.Get("Content-Type"), "application/yaml")
+}
+
+func TestWebhooks(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestServer(t)
+	defer s.Close()
+	path := "/groups/" + s.groupID + "/webhooks"
+
+	assert.Equal(s.do(t, "POST", path, token, &Webhook{URL: "ftp://example.com", Events: []webhooks.Event{webhooks.EventMeetingCreated}}, nil), http.StatusBadRequest)
+	assert.Equal(s.do(t, "POST", path, token, &Webhook{URL: "http://example.com/hook"}, nil), http.StatusBadRequest)
+	assert.Equal(s.do(t, "POST", path, token, &Webhook{URL: "http://example.com/hook", Events: []webhooks.Event{"meeting.exploded"}}, nil), http.StatusBadRequest)
+
+	created := &Webhook{}
+	assert.Equal(s.do(t, "POST", path, token, &Webhook{URL: "http://example.com/hook", Events: []webhooks.Event{webhooks.EventMeetingCreated}}, created), http.StatusCreated)
+	assert.NotEqual(created.ID, "")
+	assert.NotEqual(created.Secret, "")
+
+	list := []*Webhook{}
+	assert.Equal(s.do(t, "GET", path, token, nil, &list), http.StatusOK)
+	assert.Equal(list, []*Webhook{&Webhook{ID: created.ID, URL: "http://example.com/hook", Events: []webhooks.Event{webhooks.EventMeetingCreated}}})
+
+	delivery := &webhooks.Delivery{ID: "abc", SubscriptionID: created.ID, Event: webhooks.EventMeetingCreated, Payload: "{}", Attempt: 1, StatusCode: 200, Time: time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC)}
+	assert.NoError(s.wf.LogDelivery(delivery))
+	deliveries := []*WebhookDelivery{}
+	assert.Equal(s.do(t, "GET", path+"/"+created.ID+"/deliveries", token, nil, &deliveries), http.StatusOK)
+	assert.Equal(deliveries, []*WebhookDelivery{&WebhookDelivery{ID: "abc", Event: webhooks.EventMeetingCreated, Payload: "{}", Attempt: 1, StatusCode: 200, Time: delivery.Time}})
+	assert.Equal(s.do(t, "GET", path+"/999/deliveries", token, nil, nil), http.StatusNotFound)
+
+	assert.Equal(s.do(t, "DELETE", path+"/"+created.ID, token, nil, nil), http.StatusNoContent)
+	assert.Equal(s.do(t, "DELETE", path+"/"+created.ID, token, nil, nil), http.StatusNotFound)
+}
+
+func TestMaxGuests(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestServer(t)
+	defer s.Close()
+	path := "/groups/" + s.groupID + "/meeting"
+	aliceID, err := s.uf.GetOrCreateUser(&users.ExternalUser{ID: "1", Source: users.SourceTelegram, DisplayName: "alice"})
+	assert.NoError(err)
+
+	assert.Equal(s.do(t, "POST", path, token, &Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home", MaxGuests: -2}, nil), http.StatusBadRequest)
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home", MaxGuests: 1}
+	assert.Equal(s.do(t, "POST", path, token, m, m), http.StatusCreated)
+	m2 := &Meeting{}
+	assert.Equal(s.do(t, "GET", path, token, nil, m2), http.StatusOK)
+	assert.Equal(m2.MaxGuests, 1)
+
+	assert.Equal(s.do(t, "PUT", path+"/attendees/"+aliceID, token, &RSVP{Amount: 3}, nil), http.StatusBadRequest)
+	assert.Equal(s.do(t, "PUT", path+"/attendees/"+aliceID, token, &RSVP{Amount: 2}, nil), http.StatusOK)
+}
+
+func TestMaybeRSVP(t *testing.T) {
+	assert := assert.New(t)
+	s := newTestServer(t)
+	defer s.Close()
+	path := "/groups/" + s.groupID + "/meeting"
+	aliceID, err := s.uf.GetOrCreateUser(&users.ExternalUser{ID: "1", Source: users.SourceTelegram, DisplayName: "alice"})
+	assert.NoError(err)
+	bobID, err := s.uf.GetOrCreateUser(&users.ExternalUser{ID: "2", Source: users.SourceTelegram, DisplayName: "bob"})
+	assert.NoError(err)
+
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home", Capacity: 1}
+	assert.Equal(s.do(t, "POST", path, token, m, nil), http.StatusCreated)
+	assert.Equal(s.do(t, "PUT", path+"/attendees/"+aliceID, token, &RSVP{Amount: 1}, nil), http.StatusOK)
+	assert.Equal(s.do(t, "PUT", path+"/attendees/"+bobID, token, &RSVP{Amount: 1}, nil), http.StatusConflict)
+	assert.Equal(s.do(t, "PUT", path+"/attendees/"+bobID, token, &RSVP{Amount: 1, Maybe: true}, nil), http.StatusOK)
+
+	attendees := []*Attendee{}
+	assert.Equal(s.do(t, "GET", path+"/attendees", token, nil, &attendees), http.StatusOK)
+	assert.Equal(attendees, []*Attendee{
+		&Attendee{UserID: aliceID, DisplayName: "alice", Amount: 1},
+		&Attendee{UserID: bobID, DisplayName: "bob", Amount: 1, Maybe: true},
+	})
+}
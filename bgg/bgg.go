@@ -0,0 +1,135 @@
+package bgg
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"github.com/seppo0010/boardgamesorganizer/games"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidExport = errors.New("Not a BoardGameGeek collection export")
+
+type xmlValue struct {
+	Value string `xml:"value,attr"`
+}
+
+type xmlItem struct {
+	ObjectID      int      `xml:"objectid,attr"`
+	Name          string   `xml:"name"`
+	YearPublished int      `xml:"yearpublished"`
+	Status        *xmlFlag `xml:"status"`
+	Stats         struct {
+		MinPlayers    int      `xml:"minplayers,attr"`
+		MaxPlayers    int      `xml:"maxplayers,attr"`
+		PlayingTime   int      `xml:"playingtime,attr"`
+		AverageWeight xmlValue `xml:"rating>averageweight"`
+	} `xml:"stats"`
+}
+
+type xmlFlag struct {
+	Own string `xml:"own,attr"`
+}
+
+type xmlItems struct {
+	XMLName xml.Name   `xml:"items"`
+	Items   []*xmlItem `xml:"item"`
+}
+
+// Parse reads a BoardGameGeek collection export, either the XML from the
+// collection API or the CSV from the website. Only owned games are returned
+// when the export says which ones are.
+func Parse(r io.Reader) ([]*games.Game, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if bytes.HasPrefix(data, []byte("<")) {
+		return ParseXML(bytes.NewReader(data))
+	}
+	return ParseCSV(bytes.NewReader(data))
+}
+
+func ParseXML(r io.Reader) ([]*games.Game, error) {
+	items := &xmlItems{}
+	if err := xml.NewDecoder(r).Decode(items); err != nil {
+		return nil, ErrInvalidExport
+	}
+	list := []*games.Game{}
+	for _, item := range items.Items {
+		if item.Status != nil && item.Status.Own != "1" {
+			continue
+		}
+		weight, _ := strconv.ParseFloat(item.Stats.AverageWeight.Value, 64)
+		list = append(list, &games.Game{
+			BGGID:      item.ObjectID,
+			Title:      strings.TrimSpace(item.Name),
+			MinPlayers: item.Stats.MinPlayers,
+			MaxPlayers: item.Stats.MaxPlayers,
+			Playtime:   time.Duration(item.Stats.PlayingTime) * time.Minute,
+			Weight:     weight,
+			Year:       item.YearPublished,
+		})
+	}
+	return list, nil
+}
+
+// ParseCSV reads the website export, which has a header with the column
+// names. A plain list with a title or name column works too.
+func ParseCSV(r io.Reader) ([]*games.Game, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil || len(records) == 0 {
+		return nil, ErrInvalidExport
+	}
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	field := func(record []string, names ...string) string {
+		for _, name := range names {
+			if i, found := columns[name]; found && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+		}
+		return ""
+	}
+	number := func(record []string, names ...string) int {
+		n, _ := strconv.Atoi(field(record, names...))
+		return n
+	}
+	_, hasObjectName := columns["objectname"]
+	_, hasName := columns["name"]
+	_, hasTitle := columns["title"]
+	if !hasObjectName && !hasName && !hasTitle {
+		return nil, ErrInvalidExport
+	}
+	list := []*games.Game{}
+	for _, record := range records[1:] {
+		if own := field(record, "own"); own != "" && own != "1" {
+			continue
+		}
+		title := field(record, "objectname", "name", "title")
+		if title == "" {
+			continue
+		}
+		weight, _ := strconv.ParseFloat(field(record, "avgweight", "weight"), 64)
+		list = append(list, &games.Game{
+			BGGID:      number(record, "objectid", "bggid"),
+			Title:      title,
+			MinPlayers: number(record, "minplayers"),
+			MaxPlayers: number(record, "maxplayers"),
+			Playtime:   time.Duration(number(record, "playingtime", "playtime")) * time.Minute,
+			Weight:     weight,
+			Year:       number(record, "yearpublished", "year"),
+		})
+	}
+	return list, nil
+}
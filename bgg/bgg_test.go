@@ -0,0 +1,69 @@
+package bgg
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/games"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+const collectionXML = `<?xml version="1.0" encoding="utf-8" standalone="yes"?>
+<items totalitems="3" termsofuse="https://boardgamegeek.com/xmlapi/termsofuse">
+	<item objecttype="thing" objectid="13" subtype="boardgame" collid="1">
+		<name sortindex="5">CATAN</name>
+		<yearpublished>1995</yearpublished>
+		<stats minplayers="3" maxplayers="4" minplaytime="60" maxplaytime="120" playingtime="120" numowned="1">
+			<rating value="N/A">
+				<average value="7.1"/>
+				<averageweight value="2.3"/>
+			</rating>
+		</stats>
+		<status own="1" prevowned="0" fortrade="0" want="0"/>
+	</item>
+	<item objecttype="thing" objectid="266192" subtype="boardgame" collid="2">
+		<name sortindex="1">Wingspan</name>
+		<status own="0" prevowned="0" fortrade="0" want="1"/>
+	</item>
+	<item objecttype="thing" objectid="230802" subtype="boardgame" collid="3">
+		<name sortindex="1">Azul</name>
+	</item>
+</items>
+`
+
+const collectionCSV = "\xef\xbb\xbfobjectname,objectid,rating,own,avgweight,minplayers,maxplayers,playingtime,yearpublished\n" +
+	"CATAN,13,8,1,2.3,3,4,120,1995\n" +
+	"Wingspan,266192,,0,2.4,1,5,70,2019\n" +
+	"\"Azul, Summer Pavilion\",287954,,1,,2,4,45,2019\n"
+
+func TestParseXML(t *testing.T) {
+	assert := assert.New(t)
+	list, err := Parse(strings.NewReader(collectionXML))
+	assert.NoError(err)
+	assert.Equal(list, []*games.Game{
+		&games.Game{BGGID: 13, Title: "CATAN", MinPlayers: 3, MaxPlayers: 4, Playtime: 2 * time.Hour, Weight: 2.3, Year: 1995},
+		&games.Game{BGGID: 230802, Title: "Azul"},
+	})
+}
+
+func TestParseCSV(t *testing.T) {
+	assert := assert.New(t)
+	list, err := Parse(strings.NewReader(collectionCSV))
+	assert.NoError(err)
+	assert.Equal(list, []*games.Game{
+		&games.Game{BGGID: 13, Title: "CATAN", MinPlayers: 3, MaxPlayers: 4, Playtime: 2 * time.Hour, Weight: 2.3, Year: 1995},
+		&games.Game{BGGID: 287954, Title: "Azul, Summer Pavilion", MinPlayers: 2, MaxPlayers: 4, Playtime: 45 * time.Minute, Year: 2019},
+	})
+
+	list, err = Parse(strings.NewReader("title\nCatan\n\nAzul\n"))
+	assert.NoError(err)
+	assert.Equal(list, []*games.Game{&games.Game{Title: "Catan"}, &games.Game{Title: "Azul"}})
+}
+
+func TestParseInvalid(t *testing.T) {
+	assert := assert.New(t)
+	for _, input := range []string{"", "<message>Your request has been accepted</message>", "foo,bar\n1,2\n"} {
+		_, err := Parse(strings.NewReader(input))
+		assert.Equal(err, ErrInvalidExport, input)
+	}
+}
@@ -0,0 +1,125 @@
+package calendar
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"io"
+	"strings"
+	"time"
+)
+
+const productID = "-//seppo0010//boardgamesorganizer//EN"
+const calendarName = "Board games"
+const eventSummary = "Board games at %s"
+const dateTimeFormat = "20060102T150405Z"
+
+type Attendee struct {
+	UserID      string
+	DisplayName string
+	Amount      int
+	Maybe       bool
+}
+
+type Event struct {
+	GroupID   string
+	Meeting   *meetings.Meeting
+	Attendees []*Attendee
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`)
+var paramEscaper = strings.NewReplacer(`"`, `'`, "\r", "", "\n", " ")
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func escapeParam(s string) string {
+	return `"` + paramEscaper.Replace(s) + `"`
+}
+
+// fold splits content lines longer than 75 octets as required by RFC 5545,
+// never breaking a multi-byte character.
+func fold(line string) string {
+	buf := &bytes.Buffer{}
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			buf.WriteString("\r\n ")
+			width = 1
+		}
+		buf.WriteRune(r)
+		width += size
+	}
+	buf.WriteString("\r\n")
+	return buf.String()
+}
+
+func UID(groupID string, meetingID string) string {
+	return fmt.Sprintf("meeting-%s-%s@boardgamesorganizer", groupID, meetingID)
+}
+
+func attendeeName(attendee *Attendee) string {
+	name := attendee.DisplayName
+	if attendee.Amount > 1 {
+		name = fmt.Sprintf("%s (+%d)", attendee.DisplayName, attendee.Amount-1)
+	}
+	if attendee.Maybe {
+		name += " (maybe)"
+	}
+	return name
+}
+
+// Write renders events as an iCalendar feed generated at now.
+func Write(w io.Writer, events []*Event, now time.Time) error {
+	buf := &bytes.Buffer{}
+	line := func(format string, args ...interface{}) {
+		buf.WriteString(fold(fmt.Sprintf(format, args...)))
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:%s", productID)
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:%s", calendarName)
+	for _, event := range events {
+		meeting := event.Meeting
+		line("BEGIN:VEVENT")
+		line("UID:%s", UID(event.GroupID, meeting.ID))
+		line("DTSTAMP:%s", formatTime(now))
+		line("DTSTART:%s", formatTime(meeting.Time))
+		if meeting.Duration > 0 {
+			line("DTEND:%s", formatTime(meeting.End()))
+		}
+		line("SUMMARY:%s", escapeText(fmt.Sprintf(eventSummary, meeting.Location)))
+		line("LOCATION:%s", escapeText(meeting.Location))
+		if meeting.Cancelled {
+			line("STATUS:CANCELLED")
+		} else {
+			line("STATUS:CONFIRMED")
+		}
+		names := make([]string, 0, len(event.Attendees))
+		for _, attendee := range event.Attendees {
+			if attendee.Amount <= 0 {
+				continue
+			}
+			names = append(names, attendeeName(attendee))
+			partstat := "ACCEPTED"
+			if attendee.Maybe {
+				partstat = "TENTATIVE"
+			}
+			line("ATTENDEE;CN=%s;PARTSTAT=%s:urn:x-boardgamesorganizer:user:%s", escapeParam(attendeeName(attendee)), partstat, attendee.UserID)
+		}
+		if len(names) > 0 {
+			line("DESCRIPTION:%s", escapeText("Attendees:\n"+strings.Join(names, "\n")))
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat)
+}
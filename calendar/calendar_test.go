@@ -0,0 +1,116 @@
+package calendar
+
+import (
+	"bytes"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	ftime "github.com/seppo0010/boardgamesorganizer/time"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	assert := assert.New(t)
+	buf := &bytes.Buffer{}
+	err := Write(buf, []*Event{
+		&Event{
+			GroupID: "3",
+			Meeting: &meetings.Meeting{ID: "7", Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home; 2nd floor, door B", Duration: 3 * time.Hour},
+			Attendees: []*Attendee{
+				&Attendee{UserID: "1", DisplayName: "alice", Amount: 2},
+				&Attendee{UserID: "2", DisplayName: "bob", Amount: 0},
+				&Attendee{UserID: "3", DisplayName: "carol", Amount: 1, Maybe: true},
+			},
+		},
+		&Event{
+			GroupID: "3",
+			Meeting: &meetings.Meeting{ID: "8", Time: time.Date(2019, 5, 9, 20, 0, 0, 0, time.UTC), Location: "Bar", Closed: true, Cancelled: true},
+		},
+	}, time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC))
+	assert.NoError(err)
+	assert.Equal(buf.String(), strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//seppo0010//boardgamesorganizer//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:Board games",
+		"BEGIN:VEVENT",
+		"UID:meeting-3-7@boardgamesorganizer",
+		"DTSTAMP:20190501T170307Z",
+		"DTSTART:20190502T200307Z",
+		"DTEND:20190502T230307Z",
+		`SUMMARY:Board games at Home\; 2nd floor\, door B`,
+		`LOCATION:Home\; 2nd floor\, door B`,
+		"STATUS:CONFIRMED",
+		`ATTENDEE;CN="alice (+1)";PARTSTAT=ACCEPTED:urn:x-boardgamesorganizer:user:1`,
+		`ATTENDEE;CN="carol (maybe)";PARTSTAT=TENTATIVE:urn:x-boardgamesorganizer:us`,
+		` er:3`,
+		`DESCRIPTION:Attendees:\nalice (+1)\ncarol (maybe)`,
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:meeting-3-8@boardgamesorganizer",
+		"DTSTAMP:20190501T170307Z",
+		"DTSTART:20190509T200000Z",
+		"SUMMARY:Board games at Bar",
+		"LOCATION:Bar",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n"))
+}
+
+func TestFold(t *testing.T) {
+	assert := assert.New(t)
+	line := "LOCATION:" + strings.Repeat("ñ", 40)
+	folded := fold(line)
+	for _, l := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		assert.True(len(l) <= 75)
+	}
+	assert.Equal(strings.Replace(strings.TrimSuffix(folded, "\r\n"), "\r\n ", "", -1), line)
+}
+
+func TestFeed(t *testing.T) {
+	assert := assert.New(t)
+	mf := meetings.NewMemory()
+	tf := &ftime.Fake{CurrentNow: time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC)}
+	mf.SetTimeFactory(tf)
+	uf := users.NewMemory()
+	feed := &Feed{BaseURL: "http://example.com/", Secret: []byte("secret"), Meetings: mf, Users: uf}
+	feed.SetTimeFactory(tf)
+	groupID, err := uf.GetOrCreateGroup(&users.ExternalGroup{ID: "-100", Source: users.SourceTelegram})
+	assert.NoError(err)
+	userID, err := uf.GetOrCreateUser(&users.ExternalUser{ID: "1", Source: users.SourceTelegram, DisplayName: "alice"})
+	assert.NoError(err)
+
+	m := &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}
+	assert.NoError(mf.CreateMeeting(groupID, m))
+	assert.NoError(mf.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: userID, Amount: 1}))
+	tf.CurrentNow = time.Date(2019, 5, 3, 20, 3, 7, 0, time.UTC)
+	m2 := &meetings.Meeting{Time: time.Date(2019, 5, 4, 20, 3, 7, 0, time.UTC), Location: "Bar"}
+	assert.NoError(mf.CreateMeeting(groupID, m2))
+	assert.NoError(mf.CancelMeeting(groupID))
+
+	link := feed.URL(groupID)
+	assert.True(strings.HasPrefix(link, "http://example.com/calendar/"+groupID+".ics?signature="))
+
+	w := httptest.NewRecorder()
+	feed.ServeHTTP(w, httptest.NewRequest("GET", strings.TrimPrefix(link, "http://example.com"), nil))
+	assert.Equal(w.Code, http.StatusOK)
+	assert.Equal(w.Header().Get("Content-Type"), "text/calendar; charset=utf-8")
+	body := w.Body.String()
+	assert.Contains(body, "UID:"+UID(groupID, m.ID)+"\r\n")
+	assert.Contains(body, "UID:"+UID(groupID, m2.ID)+"\r\n")
+	assert.Contains(body, `ATTENDEE;CN="alice";PARTSTAT=ACCEPTED:urn:x-boardgamesorganizer:user:`+userID+"\r\n")
+	assert.Contains(body, "STATUS:CANCELLED\r\n")
+	assert.Contains(body, "DTSTAMP:20190503T200307Z\r\n")
+	assert.NotContains(body, "DTSTAMP:20190502T200307Z")
+
+	w = httptest.NewRecorder()
+	feed.ServeHTTP(w, httptest.NewRequest("GET", "/calendar/"+groupID+".ics?signature=abc", nil))
+	assert.Equal(w.Code, http.StatusNotFound)
+}
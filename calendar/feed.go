@@ -0,0 +1,109 @@
+package calendar
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	ftime "github.com/seppo0010/boardgamesorganizer/time"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const feedPrefix = "/calendar/"
+const feedSuffix = ".ics"
+
+type Feed struct {
+	BaseURL  string
+	Secret   []byte
+	Meetings *meetings.Factory
+	Users    users.Factory
+
+	timeFactory ftime.Factory
+}
+
+func (f *Feed) SetTimeFactory(tf ftime.Factory) {
+	f.timeFactory = tf
+}
+
+func (f *Feed) now() time.Time {
+	if f.timeFactory == nil {
+		return time.Now()
+	}
+	return f.timeFactory.Now()
+}
+
+func (f *Feed) sign(groupID string) string {
+	mac := hmac.New(sha256.New, f.Secret)
+	mac.Write([]byte(groupID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (f *Feed) URL(groupID string) string {
+	return fmt.Sprintf("%s%s%s%s?signature=%s", strings.TrimRight(f.BaseURL, "/"), feedPrefix, url.PathEscape(groupID), feedSuffix, f.sign(groupID))
+}
+
+func (f *Feed) Events(groupID string) ([]*Event, error) {
+	groupMeetings, err := f.Meetings.GetMeetings(groupID)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*Event, len(groupMeetings))
+	meetingAttendees := make([][]*meetings.Attendee, len(groupMeetings))
+	userIDs := []string{}
+	for i, meeting := range groupMeetings {
+		meetingAttendees[i], err = f.Meetings.GetMeetingAttendeesByID(meeting.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, attendee := range meetingAttendees[i] {
+			userIDs = append(userIDs, attendee.UserID)
+		}
+	}
+	usersMap, err := f.Users.GetUsers(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i, meeting := range groupMeetings {
+		events[i] = &Event{GroupID: groupID, Meeting: meeting, Attendees: make([]*Attendee, 0, len(meetingAttendees[i]))}
+		for _, attendee := range meetingAttendees[i] {
+			if user, found := usersMap[attendee.UserID]; found {
+				events[i].Attendees = append(events[i].Attendees, &Attendee{
+					UserID:      attendee.UserID,
+					DisplayName: user.DisplayName,
+					Amount:      attendee.Amount,
+					Maybe:       attendee.Maybe,
+				})
+			}
+		}
+	}
+	return events, nil
+}
+
+func (f *Feed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, feedPrefix) || !strings.HasSuffix(r.URL.Path, feedSuffix) {
+		http.NotFound(w, r)
+		return
+	}
+	groupID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, feedPrefix), feedSuffix)
+	if !hmac.Equal([]byte(f.sign(groupID)), []byte(r.URL.Query().Get("signature"))) {
+		http.NotFound(w, r)
+		return
+	}
+	events, err := f.Events(groupID)
+	if err != nil {
+		log.Printf("failed to get calendar events: %#v", err)
+		http.Error(w, meetings.UnexpectedError.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	err = Write(w, events, f.now())
+	if err != nil {
+		log.Printf("failed to write calendar: %#v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package dates
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidDate = errors.New("Could not understand the date")
+var ErrMissingTime = errors.New("Missing the time of day")
+var ErrNonexistentTime = errors.New("That time does not exist because of a daylight saving time change")
+
+var accents = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n")
+var separatedSuffix = regexp.MustCompile(`(\d)\s+(am|pm|hs|h)\b`)
+var isoDate = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})$`)
+var dayMonth = regexp.MustCompile(`^(\d{1,2})[/-](\d{1,2})(?:[/-](\d{2}|\d{4}))?$`)
+var clockTime = regexp.MustCompile(`^(\d{1,2}):(\d{2})(?::(\d{2}))?(am|pm|hs|h)?$`)
+var shortTime = regexp.MustCompile(`^(\d{1,2})(?:\.(\d{2}))?(am|pm|hs|h)$`)
+var bareHour = regexp.MustCompile(`^\d{1,2}$`)
+
+var fillers = map[string]bool{
+	"on": true, "this": true, "the": true, "el": true, "este": true, "esta": true, "de": true, "del": true,
+}
+
+// bare hours like "a las 21" or "at 9" are only accepted after these
+var hourPrefixes = map[string]bool{"at": true, "a": true, "las": true, "la": true}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday, "domingo": time.Sunday, "dom": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday, "lunes": time.Monday, "lun": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday, "martes": time.Tuesday, "mar": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday, "miercoles": time.Wednesday, "mie": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "jueves": time.Thursday, "jue": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday, "viernes": time.Friday, "vie": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday, "sabado": time.Saturday, "sab": time.Saturday,
+}
+
+var units = map[string]int{
+	"day": 1, "days": 1, "dia": 1, "dias": 1,
+	"week": 7, "weeks": 7, "semana": 7, "semanas": 7,
+}
+
+func Weekday(name string) (time.Weekday, bool) {
+	weekday, found := weekdays[accents.Replace(strings.ToLower(name))]
+	return weekday, found
+}
+
+type parsed struct {
+	date    bool
+	year    int
+	month   time.Month
+	day     int
+	weekday *time.Weekday
+	next    bool
+	clock   bool
+	hour    int
+	minute  int
+	second  int
+}
+
+func tokenize(input string) []string {
+	input = accents.Replace(strings.ToLower(input))
+	input = separatedSuffix.ReplaceAllString(input, "$1$2")
+	return strings.Fields(strings.Replace(input, ",", " ", -1))
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func (p *parsed) setDate(t time.Time) error {
+	if p.date || p.weekday != nil {
+		return ErrInvalidDate
+	}
+	p.date = true
+	p.year, p.month, p.day = t.Date()
+	return nil
+}
+
+func (p *parsed) setClock(hour, minute, second int, suffix string) error {
+	if p.clock || minute > 59 || second > 59 {
+		return ErrInvalidDate
+	}
+	switch suffix {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return ErrInvalidDate
+		}
+		hour = hour % 12
+		if suffix == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return ErrInvalidDate
+		}
+	}
+	p.clock = true
+	p.hour, p.minute, p.second = hour, minute, second
+	return nil
+}
+
+// Parse reads a date and time of day such as "friday 20:00", "tomorrow 8pm",
+// "next sat 15hs", "15/06 21:00", "en 3 dias a las 21" or
+// "2019-03-05 20:01:00", resolving relative dates against now. Weekdays mean
+// their next occurrence, which may be today if the time has not passed yet
+// unless preceded by "next". Day and month are always read day first.
+func Parse(input string, now time.Time, location *time.Location) (time.Time, error) {
+	now = now.In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	p := &parsed{}
+	tokens := tokenize(input)
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		following := func(words ...string) bool {
+			if i+len(words) >= len(tokens) {
+				return false
+			}
+			for j, word := range words {
+				if tokens[i+1+j] != word {
+					return false
+				}
+			}
+			return true
+		}
+		var err error
+		if weekday, found := weekdays[token]; found {
+			if p.date || p.weekday != nil {
+				return time.Time{}, ErrInvalidDate
+			}
+			p.weekday = &weekday
+			continue
+		}
+		if m := isoDate.FindStringSubmatch(token); m != nil {
+			if p.date || p.weekday != nil {
+				return time.Time{}, ErrInvalidDate
+			}
+			p.date = true
+			p.year, p.month, p.day = atoi(m[1]), time.Month(atoi(m[2])), atoi(m[3])
+			continue
+		}
+		if m := dayMonth.FindStringSubmatch(token); m != nil {
+			if p.date || p.weekday != nil {
+				return time.Time{}, ErrInvalidDate
+			}
+			p.date = true
+			p.day, p.month = atoi(m[1]), time.Month(atoi(m[2]))
+			switch len(m[3]) {
+			case 0:
+				p.year = now.Year()
+				if time.Date(p.year, p.month, p.day, 0, 0, 0, 0, location).Before(today) {
+					p.year++
+				}
+			case 2:
+				p.year = 2000 + atoi(m[3])
+			default:
+				p.year = atoi(m[3])
+			}
+			continue
+		}
+		if m := clockTime.FindStringSubmatch(token); m != nil {
+			err = p.setClock(atoi(m[1]), atoi(m[2]), atoi(m[3]), m[4])
+		} else if m := shortTime.FindStringSubmatch(token); m != nil {
+			err = p.setClock(atoi(m[1]), atoi(m[2]), 0, m[3])
+		} else if bareHour.MatchString(token) && i > 0 && hourPrefixes[tokens[i-1]] {
+			err = p.setClock(atoi(token), 0, 0, "")
+		} else if hourPrefixes[token] || fillers[token] {
+			continue
+		} else if token == "next" || token == "proximo" || token == "proxima" {
+			p.next = true
+		} else if token == "que" && following("viene") {
+			p.next = true
+			i++
+		} else if token == "today" || token == "hoy" {
+			err = p.setDate(today)
+		} else if token == "tomorrow" || token == "manana" {
+			err = p.setDate(today.AddDate(0, 0, 1))
+		} else if token == "pasado" && following("manana") {
+			err = p.setDate(today.AddDate(0, 0, 2))
+			i++
+		} else if token == "day" && following("after", "tomorrow") {
+			err = p.setDate(today.AddDate(0, 0, 2))
+			i += 2
+		} else if (token == "in" || token == "en") && i+2 < len(tokens) && bareHour.MatchString(tokens[i+1]) && units[tokens[i+2]] > 0 {
+			err = p.setDate(today.AddDate(0, 0, atoi(tokens[i+1])*units[tokens[i+2]]))
+			i += 2
+		} else {
+			return time.Time{}, ErrInvalidDate
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	if !p.clock {
+		return time.Time{}, ErrMissingTime
+	}
+	if p.next && p.weekday == nil {
+		return time.Time{}, ErrInvalidDate
+	}
+	if p.weekday != nil {
+		days := (int(*p.weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 && (p.next || p.at(today, location).Before(now)) {
+			days = 7
+		}
+		p.year, p.month, p.day = today.AddDate(0, 0, days).Date()
+	} else if !p.date {
+		day := today
+		if p.at(today, location).Before(now) {
+			day = today.AddDate(0, 0, 1)
+		}
+		p.year, p.month, p.day = day.Date()
+	}
+	t := time.Date(p.year, p.month, p.day, p.hour, p.minute, p.second, 0, location)
+	if year, month, day := t.Date(); year != p.year || month != p.month || day != p.day {
+		return time.Time{}, ErrInvalidDate
+	}
+	if t.Hour() != p.hour || t.Minute() != p.minute {
+		return time.Time{}, ErrNonexistentTime
+	}
+	return t, nil
+}
+
+func (p *parsed) at(day time.Time, location *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), p.hour, p.minute, p.second, 0, location)
+}
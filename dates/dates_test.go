@@ -0,0 +1,94 @@
+package dates
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	assert.NoError(err)
+	// a Wednesday afternoon
+	now := time.Date(2019, 6, 12, 17, 30, 0, 0, madrid)
+
+	for input, expected := range map[string]time.Time{
+		"2019-03-05 20:01:00":      time.Date(2019, 3, 5, 20, 1, 0, 0, madrid),
+		"friday 20:00":             time.Date(2019, 6, 14, 20, 0, 0, 0, madrid),
+		"Friday, 8pm":              time.Date(2019, 6, 14, 20, 0, 0, 0, madrid),
+		"tomorrow 8pm":             time.Date(2019, 6, 13, 20, 0, 0, 0, madrid),
+		"tomorrow at 8:30 pm":      time.Date(2019, 6, 13, 20, 30, 0, 0, madrid),
+		"today 12am":               time.Date(2019, 6, 12, 0, 0, 0, 0, madrid),
+		"next sat 15hs":            time.Date(2019, 6, 15, 15, 0, 0, 0, madrid),
+		"15/06 21:00":              time.Date(2019, 6, 15, 21, 0, 0, 0, madrid),
+		"15/06/2020 21:00":         time.Date(2020, 6, 15, 21, 0, 0, 0, madrid),
+		"01/02 21:00":              time.Date(2020, 2, 1, 21, 0, 0, 0, madrid),
+		"in 3 days 20:00":          time.Date(2019, 6, 15, 20, 0, 0, 0, madrid),
+		"in 2 weeks 20:00":         time.Date(2019, 6, 26, 20, 0, 0, 0, madrid),
+		"day after tomorrow 9am":   time.Date(2019, 6, 14, 9, 0, 0, 0, madrid),
+		"wednesday 20:00":          time.Date(2019, 6, 12, 20, 0, 0, 0, madrid),
+		"wednesday 10:00":          time.Date(2019, 6, 19, 10, 0, 0, 0, madrid),
+		"next wednesday 20:00":     time.Date(2019, 6, 19, 20, 0, 0, 0, madrid),
+		"21:00":                    time.Date(2019, 6, 12, 21, 0, 0, 0, madrid),
+		"at 9":                     time.Date(2019, 6, 13, 9, 0, 0, 0, madrid),
+		"el viernes a las 21":      time.Date(2019, 6, 14, 21, 0, 0, 0, madrid),
+		"viernes que viene 21hs":   time.Date(2019, 6, 14, 21, 0, 0, 0, madrid),
+		"mañana 20hs":              time.Date(2019, 6, 13, 20, 0, 0, 0, madrid),
+		"pasado mañana 20 hs":      time.Date(2019, 6, 14, 20, 0, 0, 0, madrid),
+		"próximo sábado 15.30hs":   time.Date(2019, 6, 15, 15, 30, 0, 0, madrid),
+		"hoy a las 21":             time.Date(2019, 6, 12, 21, 0, 0, 0, madrid),
+		"en 2 días a las 20":       time.Date(2019, 6, 14, 20, 0, 0, 0, madrid),
+		"miércoles 20:00":          time.Date(2019, 6, 12, 20, 0, 0, 0, madrid),
+		"el jueves de la semana":   {},
+		"tomorrow":                 {},
+		"friday tomorrow 20:00":    {},
+		"20:00 21:00":              {},
+		"31/02 20:00":              {},
+		"tomorrow 25:00":           {},
+		"tomorrow 13pm":            {},
+		"next 20:00":               {},
+		"someday 20:00":            {},
+		"Home;2019-03-05 20:00:00": {},
+	} {
+		parsed, err := Parse(input, now, madrid)
+		if expected.IsZero() {
+			assert.Error(err, input)
+			continue
+		}
+		if assert.NoError(err, input) {
+			assert.True(parsed.Equal(expected), "%s: got %s, expected %s", input, parsed, expected)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	assert := assert.New(t)
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	assert.NoError(err)
+	now := time.Date(2019, 6, 12, 17, 30, 0, 0, madrid)
+
+	_, err = Parse("tomorrow", now, madrid)
+	assert.Equal(err, ErrMissingTime)
+	_, err = Parse("someday 20:00", now, madrid)
+	assert.Equal(err, ErrInvalidDate)
+	_, err = Parse("2019-03-31 02:30", now, madrid)
+	assert.Equal(err, ErrNonexistentTime)
+}
+
+func TestParseTimezones(t *testing.T) {
+	assert := assert.New(t)
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	assert.NoError(err)
+	buenosAires, err := time.LoadLocation("America/Argentina/Buenos_Aires")
+	assert.NoError(err)
+	// already Thursday in Madrid, still Wednesday in Buenos Aires
+	now := time.Date(2019, 6, 13, 0, 30, 0, 0, time.UTC)
+
+	parsed, err := Parse("tomorrow 20:00", now, madrid)
+	assert.NoError(err)
+	assert.True(parsed.Equal(time.Date(2019, 6, 14, 18, 0, 0, 0, time.UTC)))
+	parsed, err = Parse("tomorrow 20:00", now, buenosAires)
+	assert.NoError(err)
+	assert.True(parsed.Equal(time.Date(2019, 6, 13, 23, 0, 0, 0, time.UTC)))
+}
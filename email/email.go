@@ -0,0 +1,148 @@
+package email
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"log"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidAddress = errors.New("Invalid email address")
+var ErrInvalidSignature = errors.New("Invalid RSVP link")
+var ErrStaleLink = errors.New("RSVP link belongs to a meeting that is no longer active")
+
+// SendError lists the addresses an announcement could not be sent to
+type SendError struct {
+	Addresses []string
+}
+
+func (e *SendError) Error() string {
+	return fmt.Sprintf("Could not send the announcement to %s", strings.Join(e.Addresses, ", "))
+}
+
+const announcementSubject = "Next event!"
+const announcementText = "Meeting created for %s at %s!"
+const announcementDateFormat = "Monday 02 Jan 2006 15:04"
+const goingLabel = "Going"
+const goingPlusOneLabel = "Going+1"
+const notGoingLabel = "Not going"
+
+type Config struct {
+	SMTPAddr string
+	Username string
+	Password string
+	From     string
+	BaseURL  string
+	Secret   []byte
+	Location *time.Location
+}
+
+type Mailer struct {
+	config *Config
+	uf     users.Factory
+	auth   smtp.Auth
+}
+
+func NewMailer(config *Config, uf users.Factory) *Mailer {
+	var auth smtp.Auth
+	if config.Username != "" {
+		host := config.SMTPAddr
+		if i := strings.LastIndex(host, ":"); i >= 0 {
+			host = host[:i]
+		}
+		auth = smtp.PlainAuth("", config.Username, config.Password, host)
+	}
+	return &Mailer{config: config, uf: uf, auth: auth}
+}
+
+func sign(secret []byte, groupID, userID string, amount int, meetingTime time.Time) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%s:%s:%d:%d", groupID, userID, amount, meetingTime.Unix())
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verify(secret []byte, groupID, userID string, amount int, meetingTime time.Time, signature string) bool {
+	expected := sign(secret, groupID, userID, amount, meetingTime)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+func (m *Mailer) rsvpLink(groupID, userID string, amount int, meetingTime time.Time) string {
+	values := url.Values{}
+	values.Set("group", groupID)
+	values.Set("user", userID)
+	values.Set("amount", strconv.Itoa(amount))
+	values.Set("time", strconv.FormatInt(meetingTime.Unix(), 10))
+	values.Set("signature", sign(m.config.Secret, groupID, userID, amount, meetingTime))
+	return fmt.Sprintf("%s/rsvp?%s", strings.TrimRight(m.config.BaseURL, "/"), values.Encode())
+}
+
+func (m *Mailer) message(to, groupID, userID string, meeting *meetings.Meeting, location *time.Location) []byte {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "From: %s\r\n", m.config.From)
+	fmt.Fprintf(buf, "To: %s\r\n", to)
+	fmt.Fprintf(buf, "Subject: %s\r\n", announcementSubject)
+	fmt.Fprintf(buf, "Content-Type: text/plain; charset=UTF-8\r\n")
+	fmt.Fprintf(buf, "\r\n")
+	fmt.Fprintf(buf, announcementText+"\r\n\r\n", meeting.Time.In(location).Format(announcementDateFormat), meeting.Location)
+	fmt.Fprintf(buf, "%s: %s\r\n", goingLabel, m.rsvpLink(groupID, userID, 1, meeting.Time))
+	if meeting.MaxGuests != meetings.NoGuests {
+		fmt.Fprintf(buf, "%s: %s\r\n", goingPlusOneLabel, m.rsvpLink(groupID, userID, 2, meeting.Time))
+	}
+	fmt.Fprintf(buf, "%s: %s\r\n", notGoingLabel, m.rsvpLink(groupID, userID, 0, meeting.Time))
+	return buf.Bytes()
+}
+
+// displayName is how a member known only by their address is shown to the
+// group, so the address itself is not.
+func displayName(address string) string {
+	return address[:strings.Index(address, "@")]
+}
+
+// Announce emails the meeting to every address, and returns a SendError
+// listing those it could not be sent to.
+func (m *Mailer) Announce(groupID string, meeting *meetings.Meeting, addresses []string) error {
+	for _, address := range addresses {
+		address = strings.TrimSpace(address)
+		if address == "" || strings.ContainsAny(address, "\r\n") || !strings.Contains(address, "@") {
+			return ErrInvalidAddress
+		}
+	}
+	location := m.config.Location
+	if location == nil {
+		location = time.UTC
+	}
+	if group, err := m.uf.GetExternalGroup(groupID); err == nil {
+		location = group.Location(location)
+	}
+	failed := []string{}
+	for _, address := range addresses {
+		address = strings.TrimSpace(address)
+		userID, err := m.uf.GetOrCreateUser(&users.ExternalUser{
+			Source:      users.SourceEmail,
+			ID:          address,
+			DisplayName: displayName(address),
+		})
+		if err != nil {
+			return err
+		}
+		err = smtp.SendMail(m.config.SMTPAddr, m.auth, m.config.From, []string{address}, m.message(address, groupID, userID, meeting, location))
+		if err != nil {
+			log.Printf("failed to send announcement to %s: %#v", address, err)
+			failed = append(failed, address)
+		}
+	}
+	if len(failed) > 0 {
+		return &SendError{Addresses: failed}
+	}
+	return nil
+}
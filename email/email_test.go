@@ -0,0 +1,296 @@
+package email
+
+import (
+	"bufio"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	ftime "github.com/seppo0010/boardgamesorganizer/time"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeSMTP struct {
+	listener net.Listener
+	messages chan string
+	// reject holds the recipients it refuses, as given in RCPT TO
+	reject map[string]bool
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("cannot listen: %#v", err)
+	}
+	s := &fakeSMTP{listener: listener, messages: make(chan string, 10)}
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTP) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeSMTP) Close() {
+	s.listener.Close()
+}
+
+func (s *fakeSMTP) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTP) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	reply := func(line string) {
+		w.WriteString(line + "\r\n")
+		w.Flush()
+	}
+	reply("220 localhost fake smtp")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "RCPT TO:") && s.reject[strings.TrimSpace(line[len("RCPT TO:"):])]:
+			reply("550 no such user")
+		case strings.HasPrefix(command, "DATA"):
+			reply("354 go ahead")
+			data := ""
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data += dataLine
+			}
+			s.messages <- data
+			reply("250 queued")
+		case strings.HasPrefix(command, "QUIT"):
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+var linkRegexp = regexp.MustCompile(`(Going|Going\+1|Not going): (\S+)`)
+
+func links(message string) map[string]string {
+	retval := map[string]string{}
+	for _, match := range linkRegexp.FindAllStringSubmatch(message, -1) {
+		retval[match[1]] = match[2]
+	}
+	return retval
+}
+
+func setup(t *testing.T) (*fakeSMTP, *Mailer, *meetings.Factory, *users.Memory) {
+	server := newFakeSMTP(t)
+	uf := users.NewMemory()
+	mf := meetings.NewMemory()
+	mf.SetTimeFactory(&ftime.Fake{CurrentNow: time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC)})
+	mailer := NewMailer(&Config{
+		SMTPAddr: server.Addr(),
+		From:     "bot@example.com",
+		BaseURL:  "http://example.com/",
+		Secret:   []byte("secret"),
+	}, uf)
+	return server, mailer, mf, uf
+}
+
+func TestAnnounce(t *testing.T) {
+	assert := assert.New(t)
+	server, mailer, mf, uf := setup(t)
+	defer server.Close()
+	groupID := "ashf"
+	meeting := &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}
+	assert.NoError(mf.CreateMeeting(groupID, meeting))
+
+	err := mailer.Announce(groupID, meeting, []string{"alice@example.com", "bob@example.com"})
+	assert.NoError(err)
+
+	for _, address := range []string{"alice@example.com", "bob@example.com"} {
+		message := <-server.messages
+		assert.Contains(message, "To: "+address)
+		assert.Contains(message, "Meeting created for Thursday 02 May 2019 20:03 at Home!")
+		assert.Len(links(message), 3)
+	}
+
+	userID, err := uf.GetOrCreateUser(&users.ExternalUser{Source: users.SourceEmail, ID: "alice@example.com"})
+	assert.NoError(err)
+	user, err := uf.GetExternalUser(userID)
+	assert.NoError(err)
+	assert.Equal(user.DisplayName, "alice")
+	assert.Equal(user.ID, "alice@example.com")
+}
+
+func TestAnnounceSendFailure(t *testing.T) {
+	assert := assert.New(t)
+	server, mailer, mf, _ := setup(t)
+	defer server.Close()
+	groupID := "ashf"
+	meeting := &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}
+	assert.NoError(mf.CreateMeeting(groupID, meeting))
+
+	server.reject = map[string]bool{"<bob@example.com>": true}
+	err := mailer.Announce(groupID, meeting, []string{"alice@example.com", "bob@example.com", "carol@example.com"})
+	assert.Equal(err, &SendError{Addresses: []string{"bob@example.com"}})
+	assert.Contains(<-server.messages, "To: alice@example.com")
+	assert.Contains(<-server.messages, "To: carol@example.com")
+}
+
+func TestAnnounceInvalidAddress(t *testing.T) {
+	assert := assert.New(t)
+	server, mailer, _, _ := setup(t)
+	defer server.Close()
+	meeting := &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}
+
+	err := mailer.Announce("ashf", meeting, []string{"alice@example.com", "bob\r\nBcc: x@example.com"})
+	assert.Equal(err, ErrInvalidAddress)
+}
+
+func TestRSVPLinks(t *testing.T) {
+	assert := assert.New(t)
+	server, mailer, mf, _ := setup(t)
+	defer server.Close()
+	groupID := "ashf"
+	meeting := &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}
+	assert.NoError(mf.CreateMeeting(groupID, meeting))
+	assert.NoError(mailer.Announce(groupID, meeting, []string{"alice@example.com"}))
+	messageLinks := links(<-server.messages)
+
+	rsvps := []string{}
+	handler := &Handler{Secret: []byte("secret"), Meetings: mf, OnRSVP: func(groupID string) {
+		rsvps = append(rsvps, groupID)
+	}}
+	request := func(method string, link string) *httptest.ResponseRecorder {
+		u, err := url.Parse(link)
+		assert.NoError(err)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, u.RequestURI(), nil))
+		return w
+	}
+	post := func(link string) *httptest.ResponseRecorder {
+		return request("POST", link)
+	}
+
+	// opening the link only asks for confirmation
+	w := request("GET", messageLinks["Going+1"])
+	assert.Equal(w.Code, http.StatusOK)
+	assert.Contains(w.Body.String(), "Going to board games at Home with 1 guest(s)?")
+	assert.Contains(w.Body.String(), `<form method="post">`)
+	attendees, err := mf.GetMeetingAttendees(groupID)
+	assert.NoError(err)
+	assert.Len(attendees, 0)
+	assert.Equal(len(rsvps), 0)
+
+	w = post(messageLinks["Going+1"])
+	assert.Equal(w.Code, http.StatusOK)
+	attendees, err = mf.GetMeetingAttendees(groupID)
+	assert.NoError(err)
+	assert.Len(attendees, 1)
+	assert.Equal(attendees[0].Amount, 2)
+
+	w = post(messageLinks["Going+1"])
+	assert.Equal(w.Code, http.StatusOK)
+
+	w = post(messageLinks["Not going"])
+	assert.Equal(w.Code, http.StatusOK)
+	attendees, err = mf.GetMeetingAttendees(groupID)
+	assert.NoError(err)
+	for _, attendee := range attendees {
+		assert.Equal(attendee.Amount, 0)
+	}
+	assert.Equal(rsvps, []string{groupID, groupID})
+
+	w = post(strings.Replace(messageLinks["Going"], "amount=1", "amount=5", 1))
+	assert.Equal(w.Code, http.StatusForbidden)
+	w = request("GET", strings.Replace(messageLinks["Going"], "amount=1", "amount=5", 1))
+	assert.Equal(w.Code, http.StatusForbidden)
+	w = request("PUT", messageLinks["Going"])
+	assert.Equal(w.Code, http.StatusMethodNotAllowed)
+}
+
+func TestRSVPStaleLink(t *testing.T) {
+	assert := assert.New(t)
+	server, mailer, mf, _ := setup(t)
+	defer server.Close()
+	groupID := "ashf"
+	meeting := &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}
+	assert.NoError(mf.CreateMeeting(groupID, meeting))
+	assert.NoError(mailer.Announce(groupID, meeting, []string{"alice@example.com"}))
+	messageLinks := links(<-server.messages)
+
+	assert.NoError(mf.DeleteMeeting(groupID))
+	assert.NoError(mf.CreateMeeting(groupID, &meetings.Meeting{Time: time.Date(2019, 5, 9, 20, 3, 7, 0, time.UTC)}))
+
+	u, err := url.Parse(messageLinks["Going"])
+	assert.NoError(err)
+	w := httptest.NewRecorder()
+	(&Handler{Secret: []byte("secret"), Meetings: mf}).ServeHTTP(w, httptest.NewRequest("POST", u.RequestURI(), nil))
+	assert.Equal(w.Code, http.StatusGone)
+}
+
+func TestRSVPClosedLink(t *testing.T) {
+	assert := assert.New(t)
+	server, mailer, mf, _ := setup(t)
+	defer server.Close()
+	groupID := "ashf"
+	meeting := &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home", RSVPCutoff: 30 * time.Hour}
+	assert.NoError(mf.CreateMeeting(groupID, meeting))
+	assert.NoError(mailer.Announce(groupID, meeting, []string{"alice@example.com"}))
+	messageLinks := links(<-server.messages)
+
+	u, err := url.Parse(messageLinks["Going"])
+	assert.NoError(err)
+	w := httptest.NewRecorder()
+	(&Handler{Secret: []byte("secret"), Meetings: mf}).ServeHTTP(w, httptest.NewRequest("POST", u.RequestURI(), nil))
+	assert.Equal(w.Code, http.StatusConflict)
+	assert.Contains(w.Body.String(), meetings.RSVPClosed.Error())
+}
+
+func TestRSVPNoGuests(t *testing.T) {
+	assert := assert.New(t)
+	server, mailer, mf, _ := setup(t)
+	defer server.Close()
+	groupID := "ashf"
+	meeting := &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}
+	assert.NoError(mf.CreateMeeting(groupID, meeting))
+	assert.NoError(mailer.Announce(groupID, meeting, []string{"alice@example.com"}))
+	messageLinks := links(<-server.messages)
+
+	assert.NoError(mf.SetMeetingMaxGuests(groupID, meetings.NoGuests))
+	u, err := url.Parse(messageLinks["Going+1"])
+	assert.NoError(err)
+	w := httptest.NewRecorder()
+	(&Handler{Secret: []byte("secret"), Meetings: mf}).ServeHTTP(w, httptest.NewRequest("POST", u.RequestURI(), nil))
+	assert.Equal(w.Code, http.StatusConflict)
+	assert.Contains(w.Body.String(), meetings.TooManyGuests.Error())
+
+	meeting.MaxGuests = meetings.NoGuests
+	assert.NoError(mailer.Announce(groupID, meeting, []string{"alice@example.com"}))
+	messageLinks = links(<-server.messages)
+	assert.Contains(messageLinks, "Going")
+	assert.NotContains(messageLinks, "Going+1")
+}
@@ -0,0 +1,113 @@
+package email
+
+import (
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const goingResponse = "OK, going!"
+const notGoingResponse = "OK, not going :("
+const goingConfirmation = "Going to board games at %s?"
+const goingWithGuestsConfirmation = "Going to board games at %s with %d guest(s)?"
+const notGoingConfirmation = "Not going to board games at %s?"
+
+// confirmationPage asks to confirm the RSVP, since mail scanners and link
+// prefetchers open every link in a message. Only posting it RSVPs.
+var confirmationPage = template.Must(template.New("confirmation").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>RSVP</title></head>
+<body>
+<form method="post">
+<p>{{.}}</p>
+<button type="submit">Confirm</button>
+</form>
+</body>
+</html>
+`))
+
+type Handler struct {
+	Secret   []byte
+	Meetings *meetings.Factory
+	OnRSVP   func(groupID string)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	groupID := query.Get("group")
+	userID := query.Get("user")
+	amount, err := strconv.Atoi(query.Get("amount"))
+	if err != nil || amount < 0 {
+		http.Error(w, ErrInvalidSignature.Error(), http.StatusBadRequest)
+		return
+	}
+	unix, err := strconv.ParseInt(query.Get("time"), 10, 64)
+	if err != nil {
+		http.Error(w, ErrInvalidSignature.Error(), http.StatusBadRequest)
+		return
+	}
+	meetingTime := time.Unix(unix, 0)
+	if !verify(h.Secret, groupID, userID, amount, meetingTime, query.Get("signature")) {
+		http.Error(w, ErrInvalidSignature.Error(), http.StatusForbidden)
+		return
+	}
+
+	meeting, err := h.Meetings.GetMeeting(groupID)
+	if err != nil {
+		if err == meetings.NoActiveMeeting {
+			http.Error(w, err.Error(), http.StatusGone)
+			return
+		}
+		http.Error(w, meetings.UnexpectedError.Error(), http.StatusInternalServerError)
+		return
+	}
+	if meeting.Time.Unix() != unix {
+		http.Error(w, ErrStaleLink.Error(), http.StatusGone)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		question := fmt.Sprintf(notGoingConfirmation, meeting.Location)
+		if amount > 1 {
+			question = fmt.Sprintf(goingWithGuestsConfirmation, meeting.Location, amount-1)
+		} else if amount > 0 {
+			question = fmt.Sprintf(goingConfirmation, meeting.Location)
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		err = confirmationPage.Execute(w, question)
+		if err != nil {
+			log.Printf("failed to write rsvp confirmation: %#v", err)
+		}
+		return
+	}
+
+	err = h.Meetings.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: userID, Amount: amount})
+	if err != nil && err != meetings.UserAlreadyAttendsMeeting && err != meetings.UserDoesNotAttendMeeting {
+		if err == meetings.MeetingIsFull || err == meetings.SignUpNotOpen || err == meetings.RSVPClosed || err == meetings.TooManyGuests {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		log.Printf("failed to rsvp from email: %#v", err)
+		http.Error(w, meetings.UnexpectedError.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err == nil && h.OnRSVP != nil {
+		h.OnRSVP(groupID)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if amount > 0 {
+		fmt.Fprintln(w, goingResponse)
+	} else {
+		fmt.Fprintln(w, notGoingResponse)
+	}
+}
@@ -0,0 +1,108 @@
+package games
+
+import (
+	"errors"
+	"time"
+)
+
+var GameNotFound = errors.New("Game not found")
+var GameAlreadyAdded = errors.New("User already has that game")
+var NominationNotFound = errors.New("Game nomination not found")
+var NominationAlreadyExists = errors.New("That game is already nominated")
+var InvalidPlay = errors.New("A play needs a game and at least one player")
+var PlayNotFound = errors.New("Play not found")
+
+// Game is a copy of a board game owned by a member of a group.
+type Game struct {
+	ID      string
+	GroupID string
+	// OwnerID is a users user ID
+	OwnerID    string
+	Title      string
+	MinPlayers int
+	MaxPlayers int
+	// Playtime is zero when unknown
+	Playtime time.Duration
+	// BGGID is the BoardGameGeek ID, zero when unknown
+	BGGID int
+	// Weight is the BoardGameGeek complexity from 1 to 5, zero when unknown
+	Weight float64
+	Year   int
+}
+
+// Nomination is a game proposed to be played at a meeting, either from the
+// group's library or as free text.
+type Nomination struct {
+	ID        string
+	MeetingID string
+	// GameID is empty for free text nominations
+	GameID      string
+	Title       string
+	NominatedBy string
+	// Votes holds the user IDs of the voters
+	Votes []string
+}
+
+func (n *Nomination) HasVote(userID string) bool {
+	for _, vote := range n.Votes {
+		if vote == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Play is a game played at a closed meeting.
+type Play struct {
+	ID        string
+	GroupID   string
+	MeetingID string
+	// Time is when the meeting took place
+	Time time.Time
+	// GameID is empty for games not in the group's library
+	GameID  string
+	Title   string
+	Players []*Player
+	// Scored reports whether the players' scores were kept
+	Scored bool
+	// Cooperative plays are won or lost by all the players together
+	Cooperative bool
+	Won         bool
+	LoggedBy    string
+}
+
+type Player struct {
+	// UserID is a users user ID
+	UserID string
+	Score  int
+	// Winner is not set on cooperative plays, see Play.Won
+	Winner bool
+}
+
+// Winners returns the user IDs of the players who won the play.
+func (p *Play) Winners() []string {
+	winners := []string{}
+	for _, player := range p.Players {
+		if player.Winner || (p.Cooperative && p.Won) {
+			winners = append(winners, player.UserID)
+		}
+	}
+	return winners
+}
+
+type Store interface {
+	AddGame(game *Game) error
+	GetGames(groupID string) ([]*Game, error)
+	// FindGames returns the games of the group whose title contains the
+	// given one, ignoring case.
+	FindGames(groupID string, title string) ([]*Game, error)
+	Nominate(nomination *Nomination) error
+	// GetNominations returns the meeting's nominations in the order they
+	// were made.
+	GetNominations(meetingID string) ([]*Nomination, error)
+	SetVote(nominationID string, userID string, vote bool) error
+	LogPlay(play *Play) error
+	// GetPlays returns the group's plays, the most recent first.
+	GetPlays(groupID string) ([]*Play, error)
+	DeletePlay(playID string) error
+}
@@ -0,0 +1,155 @@
+package games
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func testGames(t *testing.T, s Store) {
+	assert := assert.New(t)
+
+	games, err := s.GetGames("1")
+	assert.NoError(err)
+	assert.Equal(len(games), 0)
+
+	catan := &Game{GroupID: "1", OwnerID: "10", Title: "Catan", MinPlayers: 3, MaxPlayers: 4, Playtime: 90 * time.Minute}
+	assert.NoError(s.AddGame(catan))
+	assert.NotEqual(catan.ID, "")
+	assert.Equal(s.AddGame(&Game{GroupID: "1", OwnerID: "10", Title: "catan"}), GameAlreadyAdded)
+	azul := &Game{GroupID: "1", OwnerID: "11", Title: "Azul", MinPlayers: 2, MaxPlayers: 4}
+	assert.NoError(s.AddGame(azul))
+	otherCatan := &Game{GroupID: "1", OwnerID: "11", Title: "Catan", MinPlayers: 3, MaxPlayers: 4}
+	assert.NoError(s.AddGame(otherCatan))
+	assert.NoError(s.AddGame(&Game{GroupID: "2", OwnerID: "10", Title: "Catan"}))
+
+	games, err = s.GetGames("1")
+	assert.NoError(err)
+	assert.Equal(games, []*Game{azul, catan, otherCatan})
+
+	games, err = s.FindGames("1", "CAT")
+	assert.NoError(err)
+	assert.Equal(games, []*Game{catan, otherCatan})
+
+	games, err = s.FindGames("1", "Wingspan")
+	assert.NoError(err)
+	assert.Equal(len(games), 0)
+}
+
+func testImport(t *testing.T, s Store) {
+	assert := assert.New(t)
+
+	assert.NoError(s.AddGame(&Game{GroupID: "1", OwnerID: "10", Title: "Catan", BGGID: 13}))
+	assert.NoError(s.AddGame(&Game{GroupID: "1", OwnerID: "11", Title: "Azul"}))
+
+	summary, err := Import(s, "1", "11", []*Game{
+		&Game{Title: "The Settlers of Catan", BGGID: 13, MinPlayers: 3, MaxPlayers: 4, Weight: 2.3, Year: 1995},
+		&Game{Title: "azul", BGGID: 230802},
+		&Game{Title: "Wingspan", BGGID: 266192},
+	})
+	assert.NoError(err)
+	assert.Equal(len(summary.Added), 2)
+	assert.Equal(len(summary.AlreadyOwned), 1)
+	assert.Equal(summary.AlreadyOwned[0].Title, "azul")
+	assert.Equal(len(summary.Shared), 1)
+	assert.Equal(summary.Shared[0].Title, "Catan")
+
+	games, err := s.FindGames("1", "catan")
+	assert.NoError(err)
+	assert.Equal(len(games), 2)
+	for _, game := range games {
+		assert.Equal(game.BGGID, 13)
+	}
+	assert.Equal(games[1].OwnerID, "11")
+	assert.Equal(games[1].Weight, 2.3)
+	assert.Equal(games[1].Year, 1995)
+
+	summary, err = Import(s, "1", "11", []*Game{&Game{Title: "Wingspan (2nd edition)", BGGID: 266192}})
+	assert.NoError(err)
+	assert.Equal(len(summary.Added), 0)
+	assert.Equal(len(summary.AlreadyOwned), 1)
+}
+
+func testNominations(t *testing.T, s Store) {
+	assert := assert.New(t)
+
+	nominations, err := s.GetNominations("7")
+	assert.NoError(err)
+	assert.Equal(len(nominations), 0)
+
+	catan := &Nomination{MeetingID: "7", GameID: "1", Title: "Catan", NominatedBy: "10"}
+	assert.NoError(s.Nominate(catan))
+	assert.NotEqual(catan.ID, "")
+	assert.Equal(s.Nominate(&Nomination{MeetingID: "7", Title: "CATAN", NominatedBy: "11"}), NominationAlreadyExists)
+	chess := &Nomination{MeetingID: "7", Title: "Chess", NominatedBy: "11"}
+	assert.NoError(s.Nominate(chess))
+	assert.NoError(s.Nominate(&Nomination{MeetingID: "8", Title: "Catan", NominatedBy: "10"}))
+
+	assert.NoError(s.SetVote(catan.ID, "10", true))
+	assert.NoError(s.SetVote(catan.ID, "10", true))
+	assert.NoError(s.SetVote(catan.ID, "11", true))
+	assert.NoError(s.SetVote(chess.ID, "11", true))
+	assert.NoError(s.SetVote(chess.ID, "11", false))
+	assert.NoError(s.SetVote(chess.ID, "12", false))
+	assert.Equal(s.SetVote("999", "10", true), NominationNotFound)
+
+	nominations, err = s.GetNominations("7")
+	assert.NoError(err)
+	assert.Equal(len(nominations), 2)
+	assert.Equal(nominations[0].Title, "Catan")
+	assert.Equal(nominations[0].GameID, "1")
+	assert.ElementsMatch(nominations[0].Votes, []string{"10", "11"})
+	assert.True(nominations[0].HasVote("11"))
+	assert.Equal(nominations[1].Title, "Chess")
+	assert.Equal(nominations[1].Votes, []string{})
+	assert.False(nominations[1].HasVote("11"))
+}
+
+func testPlays(t *testing.T, s Store) {
+	assert := assert.New(t)
+
+	plays, err := s.GetPlays("1")
+	assert.NoError(err)
+	assert.Equal(len(plays), 0)
+
+	june := time.Date(2019, 6, 6, 19, 0, 0, 0, time.UTC)
+	catan := &Play{GroupID: "1", MeetingID: "7", Time: june, GameID: "3", Title: "Catan", Scored: true, LoggedBy: "10", Players: []*Player{
+		&Player{UserID: "10", Score: 10, Winner: true},
+		&Player{UserID: "11", Score: 8},
+	}}
+	assert.NoError(s.LogPlay(catan))
+	assert.NotEqual(catan.ID, "")
+	pandemic := &Play{GroupID: "1", MeetingID: "9", Time: june.AddDate(0, 1, 0), Title: "Pandemic", Cooperative: true, Won: true, LoggedBy: "11", Players: []*Player{
+		&Player{UserID: "11"},
+		&Player{UserID: "12"},
+	}}
+	assert.NoError(s.LogPlay(pandemic))
+	assert.NoError(s.LogPlay(&Play{GroupID: "2", MeetingID: "8", Time: june, Title: "Chess", LoggedBy: "10", Players: []*Player{&Player{UserID: "10", Winner: true}}}))
+	assert.Equal(s.LogPlay(&Play{GroupID: "1", MeetingID: "7", Time: june, Title: "Azul", LoggedBy: "10"}), InvalidPlay)
+
+	plays, err = s.GetPlays("1")
+	assert.NoError(err)
+	assert.Equal(len(plays), 2)
+	assert.Equal(plays[0].Title, "Pandemic")
+	assert.True(plays[0].Cooperative)
+	assert.True(plays[0].Won)
+	assert.Equal(plays[0].Winners(), []string{"11", "12"})
+	assert.Equal(plays[1].ID, catan.ID)
+	assert.Equal(plays[1].MeetingID, "7")
+	assert.True(plays[1].Time.Equal(june))
+	assert.Equal(plays[1].GameID, "3")
+	assert.True(plays[1].Scored)
+	assert.Equal(plays[1].LoggedBy, "10")
+	assert.Equal(len(plays[1].Players), 2)
+	assert.Equal(*plays[1].Players[0], Player{UserID: "10", Score: 10, Winner: true})
+	assert.Equal(*plays[1].Players[1], Player{UserID: "11", Score: 8})
+	assert.Equal(plays[1].Winners(), []string{"10"})
+
+	assert.NoError(s.DeletePlay(pandemic.ID))
+	assert.Equal(s.DeletePlay(pandemic.ID), PlayNotFound)
+	assert.Equal(s.DeletePlay("x"), PlayNotFound)
+	plays, err = s.GetPlays("1")
+	assert.NoError(err)
+	assert.Equal(len(plays), 1)
+	assert.Equal(plays[0].ID, catan.ID)
+}
@@ -0,0 +1,48 @@
+package games
+
+// ImportSummary tells what happened to each game of an import.
+type ImportSummary struct {
+	Added []*Game
+	// AlreadyOwned are the games the owner had registered already
+	AlreadyOwned []*Game
+	// Shared are the added games other members own too, matched by their
+	// BoardGameGeek ID
+	Shared []*Game
+}
+
+// Import adds a member's games to the group. Games other members own are
+// matched by BoardGameGeek ID and take their title, so they are listed
+// together regardless of how each export names them.
+func Import(s Store, groupID string, ownerID string, list []*Game) (*ImportSummary, error) {
+	existing, err := s.GetGames(groupID)
+	if err != nil {
+		return nil, err
+	}
+	summary := &ImportSummary{Added: []*Game{}, AlreadyOwned: []*Game{}, Shared: []*Game{}}
+	for _, game := range list {
+		game.GroupID = groupID
+		game.OwnerID = ownerID
+		shared := false
+		for _, other := range existing {
+			if game.BGGID == 0 || other.BGGID != game.BGGID || other.OwnerID == ownerID {
+				continue
+			}
+			game.Title = other.Title
+			shared = true
+			break
+		}
+		err := s.AddGame(game)
+		if err == GameAlreadyAdded {
+			summary.AlreadyOwned = append(summary.AlreadyOwned, game)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		summary.Added = append(summary.Added, game)
+		if shared {
+			summary.Shared = append(summary.Shared, game)
+		}
+	}
+	return summary, nil
+}
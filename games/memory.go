@@ -0,0 +1,151 @@
+package games
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type Memory struct {
+	mutex            sync.Mutex
+	lastGameID       int
+	lastNominationID int
+	lastPlayID       int
+	games            map[string][]*Game
+	nominations      map[string][]*Nomination
+	plays            map[string][]*Play
+}
+
+func NewMemory() *Memory {
+	return &Memory{games: map[string][]*Game{}, nominations: map[string][]*Nomination{}, plays: map[string][]*Play{}}
+}
+
+func (m *Memory) AddGame(game *Game) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, g := range m.games[game.GroupID] {
+		if g.OwnerID == game.OwnerID && (strings.EqualFold(g.Title, game.Title) || (game.BGGID != 0 && g.BGGID == game.BGGID)) {
+			return GameAlreadyAdded
+		}
+	}
+	m.lastGameID++
+	game.ID = strconv.Itoa(m.lastGameID)
+	m.games[game.GroupID] = append(m.games[game.GroupID], game)
+	return nil
+}
+
+func (m *Memory) GetGames(groupID string) ([]*Game, error) {
+	return m.FindGames(groupID, "")
+}
+
+func (m *Memory) FindGames(groupID string, title string) ([]*Game, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	games := []*Game{}
+	for _, game := range m.games[groupID] {
+		if strings.Contains(strings.ToLower(game.Title), strings.ToLower(title)) {
+			games = append(games, game)
+		}
+	}
+	sort.SliceStable(games, func(i, j int) bool {
+		return strings.ToLower(games[i].Title) < strings.ToLower(games[j].Title)
+	})
+	return games, nil
+}
+
+func (m *Memory) Nominate(nomination *Nomination) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, n := range m.nominations[nomination.MeetingID] {
+		if strings.EqualFold(n.Title, nomination.Title) {
+			return NominationAlreadyExists
+		}
+	}
+	m.lastNominationID++
+	nomination.ID = strconv.Itoa(m.lastNominationID)
+	nomination.Votes = []string{}
+	m.nominations[nomination.MeetingID] = append(m.nominations[nomination.MeetingID], nomination)
+	return nil
+}
+
+func (m *Memory) GetNominations(meetingID string) ([]*Nomination, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	nominations := make([]*Nomination, len(m.nominations[meetingID]))
+	for i, n := range m.nominations[meetingID] {
+		nomination := *n
+		nomination.Votes = append([]string{}, n.Votes...)
+		nominations[i] = &nomination
+	}
+	return nominations, nil
+}
+
+func (m *Memory) SetVote(nominationID string, userID string, vote bool) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, nominations := range m.nominations {
+		for _, n := range nominations {
+			if n.ID != nominationID {
+				continue
+			}
+			votes := []string{}
+			for _, v := range n.Votes {
+				if v != userID {
+					votes = append(votes, v)
+				}
+			}
+			if vote {
+				votes = append(votes, userID)
+			}
+			n.Votes = votes
+			return nil
+		}
+	}
+	return NominationNotFound
+}
+
+func (m *Memory) LogPlay(play *Play) error {
+	if play.Title == "" || len(play.Players) == 0 {
+		return InvalidPlay
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.lastPlayID++
+	play.ID = strconv.Itoa(m.lastPlayID)
+	m.plays[play.GroupID] = append(m.plays[play.GroupID], play)
+	return nil
+}
+
+func (m *Memory) GetPlays(groupID string) ([]*Play, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	plays := make([]*Play, len(m.plays[groupID]))
+	for i, p := range m.plays[groupID] {
+		play := *p
+		play.Players = make([]*Player, len(p.Players))
+		for j, player := range p.Players {
+			copied := *player
+			play.Players[j] = &copied
+		}
+		plays[len(plays)-1-i] = &play
+	}
+	sort.SliceStable(plays, func(i, j int) bool {
+		return plays[i].Time.After(plays[j].Time)
+	})
+	return plays, nil
+}
+
+func (m *Memory) DeletePlay(playID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for groupID, plays := range m.plays {
+		for i, play := range plays {
+			if play.ID == playID {
+				m.plays[groupID] = append(plays[:i:i], plays[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return PlayNotFound
+}
@@ -0,0 +1,19 @@
+package games
+
+import "testing"
+
+func TestGamesMemory(t *testing.T) {
+	testGames(t, NewMemory())
+}
+
+func TestImportMemory(t *testing.T) {
+	testImport(t, NewMemory())
+}
+
+func TestNominationsMemory(t *testing.T) {
+	testNominations(t, NewMemory())
+}
+
+func TestPlaysMemory(t *testing.T) {
+	testPlays(t, NewMemory())
+}
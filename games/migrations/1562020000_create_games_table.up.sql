@@ -0,0 +1,11 @@
+CREATE TABLE games (
+    id serial PRIMARY KEY,
+    group_id VARCHAR (255) NOT NULL,
+    owner_id VARCHAR (255) NOT NULL,
+    title VARCHAR (255) NOT NULL,
+    min_players INTEGER NOT NULL,
+    max_players INTEGER NOT NULL,
+    playtime INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX games_group_id_owner_id_title_key ON games (group_id, owner_id, lower(title));
@@ -0,0 +1,4 @@
+DROP INDEX games_group_id_owner_id_bgg_id_key;
+ALTER TABLE games DROP COLUMN year;
+ALTER TABLE games DROP COLUMN weight;
+ALTER TABLE games DROP COLUMN bgg_id;
@@ -0,0 +1,4 @@
+ALTER TABLE games ADD COLUMN bgg_id INTEGER NOT NULL default 0;
+ALTER TABLE games ADD COLUMN weight DOUBLE PRECISION NOT NULL default 0;
+ALTER TABLE games ADD COLUMN year INTEGER NOT NULL default 0;
+CREATE UNIQUE INDEX games_group_id_owner_id_bgg_id_key ON games (group_id, owner_id, bgg_id) WHERE (bgg_id != 0);
@@ -0,0 +1,2 @@
+DROP TABLE nomination_votes;
+DROP TABLE nominations;
@@ -0,0 +1,15 @@
+CREATE TABLE nominations (
+    id serial PRIMARY KEY,
+    meeting_id VARCHAR (255) NOT NULL,
+    game_id VARCHAR (255) NOT NULL,
+    title VARCHAR (255) NOT NULL,
+    nominated_by VARCHAR (255) NOT NULL
+);
+
+CREATE UNIQUE INDEX nominations_meeting_id_title_key ON nominations (meeting_id, lower(title));
+
+CREATE TABLE nomination_votes (
+    nomination_id INTEGER NOT NULL REFERENCES nominations (id) ON DELETE CASCADE,
+    user_id VARCHAR (255) NOT NULL,
+    PRIMARY KEY (nomination_id, user_id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE play_players;
+DROP TABLE plays;
@@ -0,0 +1,23 @@
+CREATE TABLE plays (
+    id serial PRIMARY KEY,
+    group_id VARCHAR (255) NOT NULL,
+    meeting_id VARCHAR (255) NOT NULL,
+    time TIMESTAMP WITH TIME ZONE NOT NULL,
+    game_id VARCHAR (255) NOT NULL,
+    title VARCHAR (255) NOT NULL,
+    scored BOOLEAN NOT NULL,
+    cooperative BOOLEAN NOT NULL,
+    won BOOLEAN NOT NULL,
+    logged_by VARCHAR (255) NOT NULL
+);
+
+CREATE INDEX plays_group_id_time_idx ON plays (group_id, time);
+
+CREATE TABLE play_players (
+    play_id INTEGER NOT NULL REFERENCES plays (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    user_id VARCHAR (255) NOT NULL,
+    score INTEGER NOT NULL,
+    winner BOOLEAN NOT NULL,
+    PRIMARY KEY (play_id, position)
+);
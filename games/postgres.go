@@ -0,0 +1,268 @@
+package games
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/lib/pq"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PostgresConfig struct {
+	URL            string
+	MigrationsPath string
+}
+
+type Postgres struct {
+	db *sql.DB
+}
+
+func NewPostgres(config *PostgresConfig) (*Postgres, error) {
+	db, err := sql.Open("postgres", config.URL)
+	if err != nil {
+		log.Print("failed to connect to postgres database")
+		return nil, err
+	}
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		log.Print("failed to start driver")
+		return nil, err
+	}
+	m, err := migrate.NewWithDatabaseInstance(fmt.Sprintf("file://%s", config.MigrationsPath), "postgres", driver)
+	if err != nil {
+		log.Print("failed to start migrations")
+		return nil, err
+	}
+	err = m.Up()
+	if err != nil && err != migrate.ErrNoChange {
+		log.Print("failed to run migrations")
+		return nil, err
+	}
+	return &Postgres{db: db}, nil
+}
+
+func (p *Postgres) AddGame(game *Game) error {
+	query := `
+	INSERT INTO games (group_id, owner_id, title, min_players, max_players, playtime, bgg_id, weight, year)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	RETURNING id;
+	`
+	id := 0
+	err := p.db.QueryRow(query, game.GroupID, game.OwnerID, game.Title, game.MinPlayers, game.MaxPlayers, int(game.Playtime/time.Second), game.BGGID, game.Weight, game.Year).Scan(&id)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+			return GameAlreadyAdded
+		}
+		log.Print("failed to add game")
+		return err
+	}
+	game.ID = strconv.Itoa(id)
+	return nil
+}
+
+func (p *Postgres) GetGames(groupID string) ([]*Game, error) {
+	return p.FindGames(groupID, "")
+}
+
+func (p *Postgres) FindGames(groupID string, title string) ([]*Game, error) {
+	query := `
+	SELECT id, owner_id, title, min_players, max_players, playtime, bgg_id, weight, year FROM games
+	WHERE group_id = $1 AND strpos(lower(title), $2) > 0
+	ORDER BY lower(title), id
+	`
+	rows, err := p.db.Query(query, groupID, strings.ToLower(title))
+	if err != nil {
+		log.Print("failed to get games")
+		return nil, err
+	}
+	defer rows.Close()
+	games := []*Game{}
+	for rows.Next() {
+		id := 0
+		playtime := 0
+		game := &Game{GroupID: groupID}
+		err = rows.Scan(&id, &game.OwnerID, &game.Title, &game.MinPlayers, &game.MaxPlayers, &playtime, &game.BGGID, &game.Weight, &game.Year)
+		if err != nil {
+			log.Print("failed to read game")
+			return nil, err
+		}
+		game.ID = strconv.Itoa(id)
+		game.Playtime = time.Duration(playtime) * time.Second
+		games = append(games, game)
+	}
+	return games, rows.Err()
+}
+
+func (p *Postgres) Nominate(nomination *Nomination) error {
+	query := `
+	INSERT INTO nominations (meeting_id, game_id, title, nominated_by)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id;
+	`
+	id := 0
+	err := p.db.QueryRow(query, nomination.MeetingID, nomination.GameID, nomination.Title, nomination.NominatedBy).Scan(&id)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+			return NominationAlreadyExists
+		}
+		log.Print("failed to add nomination")
+		return err
+	}
+	nomination.ID = strconv.Itoa(id)
+	nomination.Votes = []string{}
+	return nil
+}
+
+func (p *Postgres) GetNominations(meetingID string) ([]*Nomination, error) {
+	query := `
+	SELECT nominations.id, game_id, title, nominated_by, array_remove(array_agg(nomination_votes.user_id ORDER BY nomination_votes.user_id), NULL)
+	FROM nominations LEFT JOIN nomination_votes ON nomination_votes.nomination_id = nominations.id
+	WHERE meeting_id = $1
+	GROUP BY nominations.id
+	ORDER BY nominations.id
+	`
+	rows, err := p.db.Query(query, meetingID)
+	if err != nil {
+		log.Print("failed to get nominations")
+		return nil, err
+	}
+	defer rows.Close()
+	nominations := []*Nomination{}
+	for rows.Next() {
+		id := 0
+		nomination := &Nomination{MeetingID: meetingID, Votes: []string{}}
+		err = rows.Scan(&id, &nomination.GameID, &nomination.Title, &nomination.NominatedBy, pq.Array(&nomination.Votes))
+		if err != nil {
+			log.Print("failed to read nomination")
+			return nil, err
+		}
+		nomination.ID = strconv.Itoa(id)
+		nominations = append(nominations, nomination)
+	}
+	return nominations, rows.Err()
+}
+
+func (p *Postgres) SetVote(nominationID string, userID string, vote bool) error {
+	id, err := strconv.Atoi(nominationID)
+	if err != nil {
+		return NominationNotFound
+	}
+	query := `
+	DELETE FROM nomination_votes WHERE nomination_id = $1 AND user_id = $2
+	`
+	if vote {
+		query = `
+		INSERT INTO nomination_votes (nomination_id, user_id) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+		`
+	}
+	_, err = p.db.Exec(query, id, userID)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Code.Name() == "foreign_key_violation" {
+			return NominationNotFound
+		}
+		log.Print("failed to set vote")
+		return err
+	}
+	return nil
+}
+
+func (p *Postgres) LogPlay(play *Play) error {
+	if play.Title == "" || len(play.Players) == 0 {
+		return InvalidPlay
+	}
+	tx, err := p.db.Begin()
+	if err != nil {
+		log.Print("failed to start transaction")
+		return err
+	}
+	defer tx.Rollback()
+	query := `
+	INSERT INTO plays (group_id, meeting_id, time, game_id, title, scored, cooperative, won, logged_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	RETURNING id;
+	`
+	id := 0
+	err = tx.QueryRow(query, play.GroupID, play.MeetingID, play.Time, play.GameID, play.Title, play.Scored, play.Cooperative, play.Won, play.LoggedBy).Scan(&id)
+	if err != nil {
+		log.Print("failed to add play")
+		return err
+	}
+	for i, player := range play.Players {
+		_, err = tx.Exec(`
+		INSERT INTO play_players (play_id, position, user_id, score, winner)
+		VALUES ($1, $2, $3, $4, $5)
+		`, id, i, player.UserID, player.Score, player.Winner)
+		if err != nil {
+			log.Print("failed to add play player")
+			return err
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		log.Print("failed to commit play")
+		return err
+	}
+	play.ID = strconv.Itoa(id)
+	return nil
+}
+
+func (p *Postgres) GetPlays(groupID string) ([]*Play, error) {
+	query := `
+	SELECT plays.id, meeting_id, time, game_id, title, scored, cooperative, won, logged_by, user_id, score, winner
+	FROM plays JOIN play_players ON play_players.play_id = plays.id
+	WHERE group_id = $1
+	ORDER BY time DESC, plays.id DESC, position
+	`
+	rows, err := p.db.Query(query, groupID)
+	if err != nil {
+		log.Print("failed to get plays")
+		return nil, err
+	}
+	defer rows.Close()
+	plays := []*Play{}
+	var play *Play
+	for rows.Next() {
+		id := 0
+		row := &Play{GroupID: groupID}
+		player := &Player{}
+		err = rows.Scan(&id, &row.MeetingID, &row.Time, &row.GameID, &row.Title, &row.Scored, &row.Cooperative, &row.Won, &row.LoggedBy, &player.UserID, &player.Score, &player.Winner)
+		if err != nil {
+			log.Print("failed to read play")
+			return nil, err
+		}
+		row.ID = strconv.Itoa(id)
+		if play == nil || play.ID != row.ID {
+			play = row
+			play.Players = []*Player{}
+			plays = append(plays, play)
+		}
+		play.Players = append(play.Players, player)
+	}
+	return plays, rows.Err()
+}
+
+func (p *Postgres) DeletePlay(playID string) error {
+	id, err := strconv.Atoi(playID)
+	if err != nil {
+		return PlayNotFound
+	}
+	result, err := p.db.Exec("DELETE FROM plays WHERE id = $1", id)
+	if err != nil {
+		log.Print("failed to delete play")
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return PlayNotFound
+	}
+	return nil
+}
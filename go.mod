@@ -2,7 +2,9 @@ module github.com/seppo0010/boardgamesorganizer
 
 require (
 	github.com/golang-migrate/migrate/v4 v4.4.0
+	github.com/golang/protobuf v1.3.1
 	github.com/lib/pq v1.0.0
 	github.com/stretchr/testify v1.3.0
+	google.golang.org/grpc v1.20.1
 	gopkg.in/tucnak/telebot.v2 v2.0.0-20190415090633-8c1c512262f2
 )
@@ -0,0 +1,70 @@
+package locations
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var LocationNotFound = errors.New("Location not found")
+var LocationAlreadyExists = errors.New("The group already has a location with that name")
+
+const mapURL = "https://www.google.com/maps/search/?api=1&query=%s"
+
+// Location is a place saved by a group to meet at.
+type Location struct {
+	ID      string
+	GroupID string
+	Name    string
+	Address string
+	// Latitude and Longitude are both zero when unknown
+	Latitude  float64
+	Longitude float64
+	// HostID is the user ID of whoever lives there or runs the place,
+	// empty when nobody does
+	HostID string
+	// MaxCapacity is how many people fit, zero when unknown
+	MaxCapacity int
+	// AccessNotes tell how to get in, like the doorbell or the floor
+	AccessNotes   string
+	Accessibility string
+	// Rotation is set when the host takes turns hosting the group's
+	// meetings there
+	Rotation bool
+}
+
+func (l *Location) HasCoordinates() bool {
+	return l.Latitude != 0 || l.Longitude != 0
+}
+
+// MapURL links to the location on a map, by its coordinates if known or
+// else by its address. It is empty when there is neither.
+func (l *Location) MapURL() string {
+	if l.HasCoordinates() {
+		return fmt.Sprintf(mapURL, url.QueryEscape(fmt.Sprintf("%g,%g", l.Latitude, l.Longitude)))
+	}
+	if l.Address != "" {
+		return fmt.Sprintf(mapURL, url.QueryEscape(l.Address))
+	}
+	return ""
+}
+
+type Store interface {
+	CreateLocation(location *Location) error
+	UpdateLocation(location *Location) error
+	DeleteLocation(groupID string, locationID string) error
+	GetLocation(locationID string) (*Location, error)
+	GetLocations(groupID string) ([]*Location, error)
+}
+
+// Find returns the location with that name, ignoring case, or nil.
+func Find(list []*Location, name string) *Location {
+	name = strings.TrimSpace(name)
+	for _, location := range list {
+		if strings.EqualFold(location.Name, name) {
+			return location
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package locations
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func testLocations(t *testing.T, s Store) {
+	assert := assert.New(t)
+
+	list, err := s.GetLocations("1")
+	assert.NoError(err)
+	assert.Equal(len(list), 0)
+
+	home := &Location{GroupID: "1", Name: "Home", Address: "Av. Corrientes 1234", Latitude: -34.6037, Longitude: -58.3816, HostID: "7", MaxCapacity: 8, AccessNotes: "Ring 3B", Accessibility: "Elevator", Rotation: true}
+	assert.NoError(s.CreateLocation(home))
+	assert.NotEqual(home.ID, "")
+	assert.Equal(s.CreateLocation(&Location{GroupID: "1", Name: "home"}), LocationAlreadyExists)
+	cafe := &Location{GroupID: "1", Name: "Cafe"}
+	assert.NoError(s.CreateLocation(cafe))
+	other := &Location{GroupID: "2", Name: "Home"}
+	assert.NoError(s.CreateLocation(other))
+	assert.NotEqual(home.ID, other.ID)
+
+	list, err = s.GetLocations("1")
+	assert.NoError(err)
+	assert.Equal(list, []*Location{home, cafe})
+	location, err := s.GetLocation(home.ID)
+	assert.NoError(err)
+	assert.Equal(location, home)
+	_, err = s.GetLocation("1000")
+	assert.Equal(err, LocationNotFound)
+
+	updated := &Location{ID: cafe.ID, GroupID: "1", Name: "Cafe", Address: "Florida 100", MaxCapacity: 20}
+	assert.NoError(s.UpdateLocation(updated))
+	location, err = s.GetLocation(cafe.ID)
+	assert.NoError(err)
+	assert.Equal(location, updated)
+	assert.Equal(s.UpdateLocation(&Location{ID: cafe.ID, GroupID: "1", Name: "HOME"}), LocationAlreadyExists)
+	assert.Equal(s.UpdateLocation(&Location{ID: cafe.ID, GroupID: "2", Name: "Cafe"}), LocationNotFound)
+
+	assert.Equal(s.DeleteLocation("2", home.ID), LocationNotFound)
+	assert.NoError(s.DeleteLocation("1", home.ID))
+	assert.Equal(s.DeleteLocation("1", home.ID), LocationNotFound)
+	list, err = s.GetLocations("1")
+	assert.NoError(err)
+	assert.Equal(list, []*Location{updated})
+}
+
+func TestFind(t *testing.T) {
+	assert := assert.New(t)
+	home := &Location{Name: "Home"}
+	list := []*Location{&Location{Name: "Cafe"}, home}
+	assert.Equal(Find(list, " home "), home)
+	assert.Nil(Find(list, "Hom"))
+}
+
+func TestMapURL(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal((&Location{Address: "Av. Corrientes 1234"}).MapURL(), "https://www.google.com/maps/search/?api=1&query=Av.+Corrientes+1234")
+	assert.Equal((&Location{Address: "Av. Corrientes 1234", Latitude: -34.6037, Longitude: -58.3816}).MapURL(), "https://www.google.com/maps/search/?api=1&query=-34.6037%2C-58.3816")
+	assert.Equal((&Location{Name: "Home"}).MapURL(), "")
+}
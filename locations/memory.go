@@ -0,0 +1,86 @@
+package locations
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type Memory struct {
+	mutex          sync.Mutex
+	lastLocationID int
+	locations      map[string][]*Location
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		locations: map[string][]*Location{},
+	}
+}
+
+func (m *Memory) nameTaken(location *Location) bool {
+	for _, l := range m.locations[location.GroupID] {
+		if l.ID != location.ID && strings.EqualFold(l.Name, location.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Memory) CreateLocation(location *Location) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.nameTaken(location) {
+		return LocationAlreadyExists
+	}
+	m.lastLocationID++
+	location.ID = strconv.Itoa(m.lastLocationID)
+	m.locations[location.GroupID] = append(m.locations[location.GroupID], location)
+	return nil
+}
+
+func (m *Memory) UpdateLocation(location *Location) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for i, l := range m.locations[location.GroupID] {
+		if l.ID == location.ID {
+			if m.nameTaken(location) {
+				return LocationAlreadyExists
+			}
+			m.locations[location.GroupID][i] = location
+			return nil
+		}
+	}
+	return LocationNotFound
+}
+
+func (m *Memory) DeleteLocation(groupID string, locationID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for i, location := range m.locations[groupID] {
+		if location.ID == locationID {
+			m.locations[groupID] = append(m.locations[groupID][:i], m.locations[groupID][i+1:]...)
+			return nil
+		}
+	}
+	return LocationNotFound
+}
+
+func (m *Memory) GetLocation(locationID string) (*Location, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, list := range m.locations {
+		for _, location := range list {
+			if location.ID == locationID {
+				return location, nil
+			}
+		}
+	}
+	return nil, LocationNotFound
+}
+
+func (m *Memory) GetLocations(groupID string) ([]*Location, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]*Location{}, m.locations[groupID]...), nil
+}
@@ -0,0 +1,7 @@
+package locations
+
+import "testing"
+
+func TestLocationsMemory(t *testing.T) {
+	testLocations(t, NewMemory())
+}
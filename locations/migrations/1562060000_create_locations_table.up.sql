@@ -0,0 +1,14 @@
+CREATE TABLE locations (
+    id serial PRIMARY KEY,
+    group_id VARCHAR (255) NOT NULL,
+    name VARCHAR (255) NOT NULL,
+    address TEXT NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    host_id VARCHAR (255) NOT NULL,
+    max_capacity INTEGER NOT NULL,
+    access_notes TEXT NOT NULL,
+    accessibility TEXT NOT NULL
+);
+
+CREATE UNIQUE INDEX locations_group_id_name_key ON locations (group_id, lower(name));
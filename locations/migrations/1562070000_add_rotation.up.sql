@@ -0,0 +1 @@
+ALTER TABLE locations ADD COLUMN rotation BOOL NOT NULL default FALSE;
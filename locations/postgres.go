@@ -0,0 +1,180 @@
+package locations
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/lib/pq"
+	"log"
+	"strconv"
+)
+
+type PostgresConfig struct {
+	URL            string
+	MigrationsPath string
+}
+
+type Postgres struct {
+	db *sql.DB
+}
+
+func NewPostgres(config *PostgresConfig) (*Postgres, error) {
+	db, err := sql.Open("postgres", config.URL)
+	if err != nil {
+		log.Print("failed to connect to postgres database")
+		return nil, err
+	}
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		log.Print("failed to start driver")
+		return nil, err
+	}
+	m, err := migrate.NewWithDatabaseInstance(fmt.Sprintf("file://%s", config.MigrationsPath), "postgres", driver)
+	if err != nil {
+		log.Print("failed to start migrations")
+		return nil, err
+	}
+	err = m.Up()
+	if err != nil && err != migrate.ErrNoChange {
+		log.Print("failed to run migrations")
+		return nil, err
+	}
+	return &Postgres{db: db}, nil
+}
+
+func isUniqueViolation(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code.Name() == "unique_violation"
+}
+
+func (p *Postgres) CreateLocation(location *Location) error {
+	query := `
+	INSERT INTO locations (group_id, name, address, latitude, longitude, host_id, max_capacity, access_notes, accessibility, rotation)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING id;
+	`
+	id := 0
+	err := p.db.QueryRow(query, location.GroupID, location.Name, location.Address, location.Latitude, location.Longitude, location.HostID, location.MaxCapacity, location.AccessNotes, location.Accessibility, location.Rotation).Scan(&id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return LocationAlreadyExists
+		}
+		log.Print("failed to create location")
+		return err
+	}
+	location.ID = strconv.Itoa(id)
+	return nil
+}
+
+func (p *Postgres) UpdateLocation(location *Location) error {
+	id, err := strconv.Atoi(location.ID)
+	if err != nil {
+		return LocationNotFound
+	}
+	query := `
+	UPDATE locations
+	SET name = $3, address = $4, latitude = $5, longitude = $6, host_id = $7, max_capacity = $8, access_notes = $9, accessibility = $10, rotation = $11
+	WHERE group_id = $1 AND id = $2
+	`
+	res, err := p.db.Exec(query, location.GroupID, id, location.Name, location.Address, location.Latitude, location.Longitude, location.HostID, location.MaxCapacity, location.AccessNotes, location.Accessibility, location.Rotation)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return LocationAlreadyExists
+		}
+		log.Print("failed to update location")
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		log.Print("failed to update location")
+		return err
+	}
+	if count == 0 {
+		return LocationNotFound
+	}
+	return nil
+}
+
+func (p *Postgres) DeleteLocation(groupID string, locationID string) error {
+	id, err := strconv.Atoi(locationID)
+	if err != nil {
+		return LocationNotFound
+	}
+	query := `
+	DELETE FROM locations WHERE group_id = $1 AND id = $2
+	`
+	res, err := p.db.Exec(query, groupID, id)
+	if err != nil {
+		log.Print("failed to delete location")
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		log.Print("failed to delete location")
+		return err
+	}
+	if count == 0 {
+		return LocationNotFound
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanLocation(row scanner) (*Location, error) {
+	id := 0
+	location := &Location{}
+	err := row.Scan(&id, &location.GroupID, &location.Name, &location.Address, &location.Latitude, &location.Longitude, &location.HostID, &location.MaxCapacity, &location.AccessNotes, &location.Accessibility, &location.Rotation)
+	if err != nil {
+		return nil, err
+	}
+	location.ID = strconv.Itoa(id)
+	return location, nil
+}
+
+func (p *Postgres) GetLocation(locationID string) (*Location, error) {
+	id, err := strconv.Atoi(locationID)
+	if err != nil {
+		return nil, LocationNotFound
+	}
+	query := `
+	SELECT id, group_id, name, address, latitude, longitude, host_id, max_capacity, access_notes, accessibility, rotation
+	FROM locations WHERE id = $1
+	`
+	location, err := scanLocation(p.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, LocationNotFound
+		}
+		log.Print("failed to get location")
+		return nil, err
+	}
+	return location, nil
+}
+
+func (p *Postgres) GetLocations(groupID string) ([]*Location, error) {
+	query := `
+	SELECT id, group_id, name, address, latitude, longitude, host_id, max_capacity, access_notes, accessibility, rotation
+	FROM locations WHERE group_id = $1 ORDER BY id
+	`
+	rows, err := p.db.Query(query, groupID)
+	if err != nil {
+		log.Print("failed to get locations")
+		return nil, err
+	}
+	defer rows.Close()
+	locations := []*Location{}
+	for rows.Next() {
+		location, err := scanLocation(rows)
+		if err != nil {
+			log.Print("failed to read location")
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+	return locations, rows.Err()
+}
@@ -1,26 +1,329 @@
 package main
 
 import (
+	"github.com/seppo0010/boardgamesorganizer/api"
+	"github.com/seppo0010/boardgamesorganizer/calendar"
+	"github.com/seppo0010/boardgamesorganizer/email"
+	"github.com/seppo0010/boardgamesorganizer/games"
+	"github.com/seppo0010/boardgamesorganizer/locations"
 	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/recurrence"
+	"github.com/seppo0010/boardgamesorganizer/rpc"
+	"github.com/seppo0010/boardgamesorganizer/scheduler"
 	"github.com/seppo0010/boardgamesorganizer/users"
+	"github.com/seppo0010/boardgamesorganizer/webhooks"
+	"google.golang.org/grpc"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 func main() {
 	token := os.Getenv("BGO_TELEGRAM_BOT_TOKEN")
 	postgresMeetingsURL := os.Getenv("BGO_MEETINGS_POSTGRES_URL")
 	postgresUsersURL := os.Getenv("BGO_USERS_POSTGRES_URL")
-	mf, err := meetings.NewPostgres(&meetings.PostgresConfig{URL: postgresMeetingsURL, MigrationsPath: "./meetings/migrations"})
-	if err != nil {
-		log.Fatalf("error starting meetings factory: %#v", err)
+	httpAddr := os.Getenv("BGO_HTTP_ADDR")
+	smtpAddr := os.Getenv("BGO_SMTP_ADDR")
+	apiTokens := os.Getenv("BGO_API_TOKENS")
+	grpcAddr := os.Getenv("BGO_GRPC_ADDR")
+	grpcBackend := os.Getenv("BGO_GRPC_BACKEND")
+	grpcToken := os.Getenv("BGO_GRPC_TOKEN")
+	grpcCertFile := os.Getenv("BGO_GRPC_CERT_FILE")
+	grpcKeyFile := os.Getenv("BGO_GRPC_KEY_FILE")
+	grpcCAFile := os.Getenv("BGO_GRPC_CA_FILE")
+	calendarSecret := os.Getenv("BGO_CALENDAR_SECRET")
+	postgresWebhooksURL := os.Getenv("BGO_WEBHOOKS_POSTGRES_URL")
+	reminders := os.Getenv("BGO_REMINDERS")
+	noShowRule := os.Getenv("BGO_NO_SHOW_RULE")
+	postgresGamesURL := os.Getenv("BGO_GAMES_POSTGRES_URL")
+	postgresLocationsURL := os.Getenv("BGO_LOCATIONS_POSTGRES_URL")
+
+	var mf *meetings.Factory
+	var uf users.Factory
+	if grpcBackend != "" {
+		opts, err := rpc.DialOptions(grpcBackend, grpcToken, grpcCAFile)
+		if err != nil {
+			log.Fatalf("error connecting to grpc backend: %#v", err)
+		}
+		client, err := rpc.Dial(grpcBackend, opts...)
+		if err != nil {
+			log.Fatalf("error connecting to grpc backend: %#v", err)
+		}
+		mf = meetings.NewFactory(client)
+		uf = client
+	} else {
+		var err error
+		mf, err = meetings.NewPostgres(&meetings.PostgresConfig{URL: postgresMeetingsURL, MigrationsPath: "./meetings/migrations"})
+		if err != nil {
+			log.Fatalf("error starting meetings factory: %#v", err)
+		}
+		uf, err = users.NewPostgres(&users.PostgresConfig{URL: postgresUsersURL, MigrationsPath: "./users/migrations"})
+		if err != nil {
+			log.Fatalf("error starting users factory: %#v", err)
+		}
 	}
-	uf, err := users.NewPostgres(&users.PostgresConfig{URL: postgresUsersURL, MigrationsPath: "./users/migrations"})
-	if err != nil {
-		log.Fatalf("error starting users factory: %#v", err)
+
+	var webhookStore webhooks.Store
+	if postgresWebhooksURL != "" {
+		store, err := webhooks.NewPostgres(&webhooks.PostgresConfig{URL: postgresWebhooksURL, MigrationsPath: "./webhooks/migrations"})
+		if err != nil {
+			log.Fatalf("error starting webhooks store: %#v", err)
+		}
+		webhookStore = store
+		mf = meetings.NewFactory(webhooks.NewMeetings(mf.Inner, webhooks.NewDispatcher(store)))
+	}
+
+	var rpcServer *rpc.Server
+	if grpcAddr != "" {
+		rpcServer = &rpc.Server{Users: uf}
+		mf = meetings.NewFactory(rpc.NewWatchedMeetings(mf.Inner, rpcServer))
+	}
+
+	var gs games.Store
+	if postgresGamesURL != "" {
+		store, err := games.NewPostgres(&games.PostgresConfig{URL: postgresGamesURL, MigrationsPath: "./games/migrations"})
+		if err != nil {
+			log.Fatalf("error starting games store: %#v", err)
+		}
+		gs = store
+	}
+
+	var ls locations.Store
+	if postgresLocationsURL != "" {
+		store, err := locations.NewPostgres(&locations.PostgresConfig{URL: postgresLocationsURL, MigrationsPath: "./locations/migrations"})
+		if err != nil {
+			log.Fatalf("error starting locations store: %#v", err)
+		}
+		ls = store
+	}
+
+	var mailer *email.Mailer
+	emailConfig := &email.Config{
+		SMTPAddr: smtpAddr,
+		Username: os.Getenv("BGO_SMTP_USERNAME"),
+		Password: os.Getenv("BGO_SMTP_PASSWORD"),
+		From:     os.Getenv("BGO_EMAIL_FROM"),
+		BaseURL:  os.Getenv("BGO_PUBLIC_URL"),
+		Secret:   []byte(os.Getenv("BGO_EMAIL_SECRET")),
+		Location: defaultLocation,
+	}
+	if smtpAddr != "" {
+		if len(emailConfig.Secret) == 0 {
+			log.Fatal("BGO_EMAIL_SECRET is required when BGO_SMTP_ADDR is set")
+		}
+		mailer = email.NewMailer(emailConfig, uf)
+	}
+
+	var feed *calendar.Feed
+	if calendarSecret != "" {
+		feed = &calendar.Feed{
+			BaseURL:  os.Getenv("BGO_PUBLIC_URL"),
+			Secret:   []byte(calendarSecret),
+			Meetings: mf,
+			Users:    uf,
+		}
 	}
-	err = startTelegram(token, mf, uf)
+
+	t, err := newTelegram(token, mf, uf, gs, ls, mailer, feed)
 	if err != nil {
 		log.Fatalf("error running telegram: %#v", err)
 	}
+
+	mf.OnClose = onClose(t)
+	mf.OnCancel = onCancel(t)
+	mf.NoShowLimit, mf.NoShowWindow, mf.NoShowDelay = parseNoShowRule(noShowRule)
+	series := recurrence.NewSeries(mf)
+	series.Location = t.groupLocation
+	series.OnCreated = onMeetingCreated(t)
+	mf.OnClosed = onClosed(t, series)
+	sched := scheduler.New(mf, parseReminders(reminders))
+	sched.OnReminder = onReminder(t)
+	sched.OnRSVPClosed = onRSVPClosed(t)
+	sched.OnMaybeNudge = onMaybeNudge(t)
+	go sched.Run(time.Minute, nil)
+
+	if httpAddr != "" {
+		mux := http.NewServeMux()
+		if mailer != nil {
+			mux.Handle("/rsvp", &email.Handler{
+				Secret:   emailConfig.Secret,
+				Meetings: mf,
+				OnRSVP:   onRSVP(t),
+			})
+		}
+		if feed != nil {
+			mux.Handle("/calendar/", feed)
+		}
+		if apiTokens != "" {
+			mux.Handle("/", &api.Server{
+				Meetings:         mf,
+				Users:            uf,
+				Tokens:           parseAPITokens(apiTokens),
+				Webhooks:         webhookStore,
+				OnMeetingCreated: onMeetingCreated(t),
+				OnRSVP:           onRSVP(t),
+			})
+		}
+		go func() {
+			log.Fatal(http.ListenAndServe(httpAddr, mux))
+		}()
+	}
+
+	if grpcAddr != "" {
+		opts, err := rpc.ServerOptions(grpcAddr, grpcToken, grpcCertFile, grpcKeyFile)
+		if err != nil {
+			log.Fatalf("error listening for grpc: %#v", err)
+		}
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			log.Fatalf("error listening for grpc: %#v", err)
+		}
+		g := grpc.NewServer(opts...)
+		rpcServer.Meetings = mf
+		rpcServer.OnRSVP = onRSVP(t)
+		rpcServer.Register(g)
+		go func() {
+			log.Fatal(g.Serve(listener))
+		}()
+	}
+
+	t.Start()
+}
+
+func onRSVP(t *telegram) func(groupID string) {
+	return func(groupID string) {
+		err := t.refreshMeetingMessage(groupID)
+		if err != nil {
+			log.Print(err)
+		}
+	}
+}
+
+func onMeetingCreated(t *telegram) func(groupID string) {
+	return func(groupID string) {
+		err := t.postMeetingMessage(groupID)
+		if err != nil {
+			log.Print(err)
+		}
+	}
+}
+
+func onClose(t *telegram) func(groupID string, meeting *meetings.Meeting) {
+	return func(groupID string, meeting *meetings.Meeting) {
+		err := t.closeMeetingMessage(groupID, meeting)
+		if err != nil {
+			log.Print(err)
+		}
+	}
+}
+
+func onCancel(t *telegram) func(groupID string, meeting *meetings.Meeting) {
+	return func(groupID string, meeting *meetings.Meeting) {
+		err := t.cancelMeetingMessage(groupID, meeting)
+		if err != nil {
+			log.Print(err)
+		}
+	}
+}
+
+// onClosed sends the host the check-in list once the meeting starts, and
+// then schedules the next one of its series.
+func onClosed(t *telegram, series *recurrence.Series) func(groupID string, meeting *meetings.Meeting) {
+	return func(groupID string, meeting *meetings.Meeting) {
+		if !meeting.Cancelled {
+			err := t.sendCheckIn(groupID, meeting)
+			if err != nil {
+				log.Print(err)
+			}
+		}
+		series.Continue(groupID, meeting)
+	}
+}
+
+func onReminder(t *telegram) func(groupID string, meeting *meetings.Meeting, before time.Duration) {
+	return func(groupID string, meeting *meetings.Meeting, before time.Duration) {
+		err := t.remind(groupID, meeting)
+		if err != nil {
+			log.Print(err)
+		}
+	}
+}
+
+func onRSVPClosed(t *telegram) func(groupID string, meeting *meetings.Meeting) {
+	return func(groupID string, meeting *meetings.Meeting) {
+		err := t.refreshMeetingMessage(groupID)
+		if err != nil {
+			log.Print(err)
+		}
+		err = t.postTables(groupID, meeting)
+		if err != nil {
+			log.Print(err)
+		}
+	}
+}
+
+func onMaybeNudge(t *telegram) func(groupID string, meeting *meetings.Meeting) {
+	return func(groupID string, meeting *meetings.Meeting) {
+		err := t.nudgeMaybes(groupID, meeting)
+		if err != nil {
+			log.Print(err)
+		}
+	}
+}
+
+func parseReminders(input string) []time.Duration {
+	if input == "" {
+		return scheduler.DefaultReminders
+	}
+	reminders := []time.Duration{}
+	for _, value := range strings.Split(input, ",") {
+		reminder, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || reminder <= 0 {
+			log.Fatalf("invalid BGO_REMINDERS entry %q, expected a duration like 24h", value)
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders
+}
+
+// parseNoShowRule reads "limit,window,delay", like "2,5,24h" for members
+// who missed 2 of the last 5 meetings to sign up a day later. Empty
+// disables the rule.
+func parseNoShowRule(input string) (int, int, time.Duration) {
+	if input == "" {
+		return 0, 0, 0
+	}
+	data := strings.Split(input, ",")
+	if len(data) != 3 {
+		log.Fatalf("invalid BGO_NO_SHOW_RULE %q, expected limit,window,delay like 2,5,24h", input)
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(data[0]))
+	if err != nil || limit <= 0 {
+		log.Fatalf("invalid BGO_NO_SHOW_RULE limit %q, expected a positive number", data[0])
+	}
+	window, err := strconv.Atoi(strings.TrimSpace(data[1]))
+	if err != nil || window < limit {
+		log.Fatalf("invalid BGO_NO_SHOW_RULE window %q, expected a number of meetings of at least %d", data[1], limit)
+	}
+	delay, err := time.ParseDuration(strings.TrimSpace(data[2]))
+	if err != nil || delay <= 0 {
+		log.Fatalf("invalid BGO_NO_SHOW_RULE delay %q, expected a duration like 24h", data[2])
+	}
+	return limit, window, delay
+}
+
+func parseAPITokens(input string) map[string]string {
+	tokens := map[string]string{}
+	for _, pair := range strings.Split(input, ",") {
+		data := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(data) != 2 {
+			log.Fatalf("invalid BGO_API_TOKENS entry %q, expected groupID:token", pair)
+		}
+		tokens[data[0]] = data[1]
+	}
+	return tokens
 }
@@ -3,6 +3,7 @@ package meetings
 import (
 	"errors"
 	ftime "github.com/seppo0010/boardgamesorganizer/time"
+	"strings"
 	"time"
 )
 
@@ -13,17 +14,84 @@ var UserAlreadyAttendsMeeting = errors.New("User is already attending meeting")
 var UserDoesNotAttendMeeting = errors.New("User is not attending meeting")
 var UnexpectedError = errors.New("Unexpected error")
 var MeetingIsFull = errors.New("Meeting is full")
+var MeetingsOverlap = errors.New("Meeting overlaps with another meeting of the group")
+var RSVPClosed = errors.New("RSVPs for this meeting are closed")
+var TooManyGuests = errors.New("Too many guests for this meeting")
+var SignUpNotOpen = errors.New("You missed meetings you said you would go to, so sign up opens later for you")
+
+// NoGuests is the MaxGuests of meetings where attendees cannot bring anyone.
+const NoGuests = -1
 
 type Meeting struct {
-	Time     time.Time
-	Location string
-	Capacity int
-	Closed   bool
+	ID        string
+	Time      time.Time
+	Location  string
+	Capacity  int
+	Closed    bool
+	Cancelled bool
+	// Recurrence is an RRULE, empty for one-off meetings
+	Recurrence string
+	// Duration is zero when the meeting has no known end
+	Duration time.Duration
+	// RSVPCutoff is how long before the start people can no longer RSVP,
+	// zero to allow it until the meeting starts
+	RSVPCutoff time.Duration
+	// MaxGuests is how many guests each attendee can bring, zero for no
+	// limit other than the capacity and NoGuests for none
+	MaxGuests int
+	// CreatedAt is zero for meetings created before it was kept
+	CreatedAt time.Time
+	// HostID is the user ID of whoever hosts the meeting, empty when unknown
+	HostID string
+	// LocationID is the saved location the meeting takes place at, empty
+	// when Location is only a name
+	LocationID string
+}
+
+func (m *Meeting) RSVPDeadline() time.Time {
+	return m.Time.Add(-m.RSVPCutoff)
+}
+
+func (m *Meeting) End() time.Time {
+	return m.Time.Add(m.Duration)
+}
+
+// Overlaps reports whether both meetings take place at the same time.
+// Meetings without a duration only overlap if they start together.
+func (m *Meeting) Overlaps(other *Meeting) bool {
+	if m.Time.Equal(other.Time) {
+		return true
+	}
+	return m.Time.Before(other.End()) && other.Time.Before(m.End())
 }
 
 type Attendee struct {
 	UserID string
 	Amount int
+	// Guests holds the names of up to Amount-1 people the attendee brings
+	Guests []*Guest
+	// Maybe marks a tentative RSVP, which takes none of the capacity
+	Maybe bool
+	// RSVPTime is when the attendee last changed their RSVP, zero if it was
+	// before it was kept
+	RSVPTime time.Time
+	// CheckedIn and GuestsCheckedIn record who showed up once the meeting
+	// started
+	CheckedIn       bool
+	GuestsCheckedIn int
+}
+
+// Going reports whether the attendee confirmed they are going.
+func (a *Attendee) Going() bool {
+	return a.Amount > 0 && !a.Maybe
+}
+
+type Guest struct {
+	Name string `json:"name"`
+	// Handle is the guest's Telegram username, if known
+	Handle string `json:"handle,omitempty"`
+	// UserID links the guest to a users.ExternalUser once they are known
+	UserID string `json:"user_id,omitempty"`
 }
 
 type Inner interface {
@@ -35,11 +103,37 @@ type Inner interface {
 	UserRSVPMeeting(groupID string, attendee *Attendee) error
 	GetMeetingAttendees(groupID string) ([]*Attendee, error)
 	CloseMeeting(groupID string) error
+	CancelMeeting(groupID string) error
+	GetMeetings(groupID string) ([]*Meeting, error)
+	GetMeetingAttendeesByID(meetingID string) ([]*Attendee, error)
+	GetActiveMeetings() (map[string]*Meeting, error)
+	SetMeetingRecurrence(groupID string, recurrence string) error
+	SetMeetingRSVPCutoff(groupID string, cutoff time.Duration) error
+	SetMeetingMaxGuests(groupID string, maxGuests int) error
+	SetAttendeeGuests(groupID string, userID string, guests []*Guest) error
+	// CheckIn records whether an attendee of a meeting, which may be
+	// closed, showed up and how many of their guests did.
+	CheckIn(meetingID string, userID string, checkedIn bool, guests int) error
 }
 
 type Factory struct {
 	Inner
-	timeFactory ftime.Factory
+	// OnClose runs right before a meeting is closed, while its attendees
+	// data can still be read.
+	OnClose func(groupID string, meeting *Meeting)
+	// OnCancel runs right before a meeting is cancelled, while its attendees
+	// data can still be read.
+	OnCancel func(groupID string, meeting *Meeting)
+	// OnClosed runs once a meeting has been closed or cancelled, when a new
+	// one can already be created.
+	OnClosed func(groupID string, meeting *Meeting)
+	// Members with at least NoShowLimit no-shows in the last NoShowWindow
+	// checked in meetings can only RSVP NoShowDelay after a meeting is
+	// created. Zero disables it.
+	NoShowLimit  int
+	NoShowWindow int
+	NoShowDelay  time.Duration
+	timeFactory  ftime.Factory
 }
 
 func NewFactory(inner Inner) *Factory {
@@ -60,11 +154,30 @@ func (f *Factory) closeMeetingIfNeeded(groupID string) (*Meeting, error) {
 		if err != nil {
 			return nil, err
 		}
-		return nil, NoActiveMeeting
+		// OnClosed may have created the next meeting already
+		return f.closeMeetingIfNeeded(groupID)
 	}
 	return meeting, nil
 }
 
+func (f *Factory) CloseMeeting(groupID string) error {
+	meeting, err := f.Inner.GetMeeting(groupID)
+	if err != nil {
+		return err
+	}
+	if f.OnClose != nil {
+		f.OnClose(groupID, meeting)
+	}
+	if err := f.Inner.CloseMeeting(groupID); err != nil {
+		return err
+	}
+	if f.OnClosed != nil {
+		meeting.Closed = true
+		f.OnClosed(groupID, meeting)
+	}
+	return nil
+}
+
 func (f *Factory) SetTimeFactory(tf ftime.Factory) {
 	f.timeFactory = tf
 }
@@ -79,33 +192,210 @@ func (f *Factory) CanCreateMeeting(groupID string, meeting *Meeting) error {
 	return nil
 }
 func (f *Factory) CreateMeeting(groupID string, meeting *Meeting) error {
-	if _, err := f.closeMeetingIfNeeded(groupID); err != nil && err != NoActiveMeeting {
+	_, err := f.closeMeetingIfNeeded(groupID)
+	if err != nil && err != NoActiveMeeting {
 		return err
 	}
 	if meeting.Time.Before(f.timeFactory.Now()) {
 		return MeetingIsInThePast
 	}
+	if err == nil {
+		return MeetingAlreadyActive
+	}
+	if err := f.checkOverlap(groupID, meeting); err != nil {
+		return err
+	}
+	meeting.CreatedAt = f.timeFactory.Now()
 	return f.Inner.CreateMeeting(groupID, meeting)
 }
 
+// checkOverlap rejects meetings that overlap with one of the group that was
+// not cancelled, including one that started already and is still going on.
+func (f *Factory) checkOverlap(groupID string, meeting *Meeting) error {
+	meetings, err := f.Inner.GetMeetings(groupID)
+	if err != nil {
+		return err
+	}
+	for _, m := range meetings {
+		if !m.Cancelled && m.Overlaps(meeting) {
+			return MeetingsOverlap
+		}
+	}
+	return nil
+}
+
+func (f *Factory) CancelMeeting(groupID string) error {
+	meeting, err := f.GetMeeting(groupID)
+	if err != nil {
+		return err
+	}
+	if f.OnCancel != nil {
+		f.OnCancel(groupID, meeting)
+	}
+	if err := f.Inner.CancelMeeting(groupID); err != nil {
+		return err
+	}
+	if f.OnClosed != nil {
+		meeting.Closed = true
+		meeting.Cancelled = true
+		f.OnClosed(groupID, meeting)
+	}
+	return nil
+}
+
+func (f *Factory) GetMeetings(groupID string) ([]*Meeting, error) {
+	if _, err := f.closeMeetingIfNeeded(groupID); err != nil && err != NoActiveMeeting {
+		return nil, err
+	}
+	return f.Inner.GetMeetings(groupID)
+}
+
 func (f *Factory) UserRSVPMeeting(groupID string, attendee *Attendee) error {
 	meeting, err := f.GetMeeting(groupID)
 	if err != nil {
 		return err
 	}
+	if !f.timeFactory.Now().Before(meeting.RSVPDeadline()) {
+		return RSVPClosed
+	}
+	if attendee.Amount > 1 && (meeting.MaxGuests == NoGuests || (meeting.MaxGuests > 0 && attendee.Amount-1 > meeting.MaxGuests)) {
+		return TooManyGuests
+	}
 	attendees, err := f.GetMeetingAttendees(groupID)
 	if err != nil {
 		return err
 	}
 	taken := 0
+	attending := false
 	for _, att := range attendees {
-		if att.UserID != attendee.UserID {
+		if att.UserID == attendee.UserID {
+			attending = att.Amount > 0
+		} else if att.Going() {
 			taken += att.Amount
 		}
 	}
-	if meeting.Capacity > 0 && meeting.Capacity < taken+attendee.Amount {
+	if attendee.Amount > 0 && !attending {
+		if err := f.checkSignUpOpen(groupID, meeting, attendee.UserID); err != nil {
+			return err
+		}
+	}
+	if meeting.Capacity > 0 && attendee.Going() && meeting.Capacity < taken+attendee.Amount {
 		return MeetingIsFull
 	}
+	attendee.RSVPTime = f.timeFactory.Now()
 	// FIXME: possible race condition if two people RSVP at the same time
 	return f.Inner.UserRSVPMeeting(groupID, attendee)
 }
+
+func (f *Factory) SetAttendeeGuests(groupID string, userID string, guests []*Guest) error {
+	attendees, err := f.GetMeetingAttendees(groupID)
+	if err != nil {
+		return err
+	}
+	for _, attendee := range attendees {
+		if attendee.UserID == userID && attendee.Amount > 0 {
+			if len(guests) > attendee.Amount-1 {
+				return TooManyGuests
+			}
+			return f.Inner.SetAttendeeGuests(groupID, userID, guests)
+		}
+	}
+	return UserDoesNotAttendMeeting
+}
+
+// LinkGuests links the guests of the active meeting with the given handle to
+// a user. It returns how many were linked.
+func (f *Factory) LinkGuests(groupID string, handle string, userID string) (int, error) {
+	attendees, err := f.GetMeetingAttendees(groupID)
+	if err != nil {
+		return 0, err
+	}
+	linked := 0
+	for _, attendee := range attendees {
+		changed := false
+		for _, guest := range attendee.Guests {
+			if guest.UserID == "" && guest.Handle != "" && strings.EqualFold(guest.Handle, handle) {
+				guest.UserID = userID
+				changed = true
+				linked++
+			}
+		}
+		if changed {
+			if err := f.Inner.SetAttendeeGuests(groupID, attendee.UserID, attendee.Guests); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return linked, nil
+}
+
+func (f *Factory) CheckIn(meetingID string, userID string, checkedIn bool, guests int) error {
+	if guests < 0 {
+		return TooManyGuests
+	}
+	attendees, err := f.GetMeetingAttendeesByID(meetingID)
+	if err != nil {
+		return err
+	}
+	for _, attendee := range attendees {
+		if attendee.UserID == userID && attendee.Amount > 0 {
+			if guests > attendee.Amount-1 {
+				return TooManyGuests
+			}
+			return f.Inner.CheckIn(meetingID, userID, checkedIn, guests)
+		}
+	}
+	return UserDoesNotAttendMeeting
+}
+
+// NoShows counts the meetings the user said they would go to but did not
+// check in, among the group's last held meetings where anybody checked in.
+func (f *Factory) NoShows(groupID string, userID string, window int) (int, error) {
+	list, err := f.Inner.GetMeetings(groupID)
+	if err != nil {
+		return 0, err
+	}
+	noShows := 0
+	for i := len(list) - 1; i >= 0 && window > 0; i-- {
+		if !list[i].Closed || list[i].Cancelled {
+			continue
+		}
+		attendees, err := f.GetMeetingAttendeesByID(list[i].ID)
+		if err != nil {
+			return 0, err
+		}
+		checkedIn := false
+		var attendee *Attendee
+		for _, att := range attendees {
+			checkedIn = checkedIn || att.CheckedIn
+			if att.UserID == userID {
+				attendee = att
+			}
+		}
+		if !checkedIn {
+			continue
+		}
+		window--
+		if attendee != nil && attendee.Going() && !attendee.CheckedIn {
+			noShows++
+		}
+	}
+	return noShows, nil
+}
+
+func (f *Factory) checkSignUpOpen(groupID string, meeting *Meeting, userID string) error {
+	if f.NoShowLimit <= 0 || f.NoShowDelay <= 0 || meeting.CreatedAt.IsZero() {
+		return nil
+	}
+	if !f.timeFactory.Now().Before(meeting.CreatedAt.Add(f.NoShowDelay)) {
+		return nil
+	}
+	noShows, err := f.NoShows(groupID, userID, f.NoShowWindow)
+	if err != nil {
+		return err
+	}
+	if noShows >= f.NoShowLimit {
+		return SignUpNotOpen
+	}
+	return nil
+}
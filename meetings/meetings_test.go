@@ -31,7 +31,9 @@ func testCreateGetDeleteMeeting(t *testing.T, f *Factory) {
 	setTimeFactory(f)
 
 	m := &Meeting{
-		Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC),
+		Time:       time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC),
+		Location:   "Home",
+		LocationID: "3",
 	}
 	groupID := "ashf"
 
@@ -102,7 +104,8 @@ func testAttendees(t *testing.T, f *Factory) {
 
 	attendees, err = f.GetMeetingAttendees(groupID)
 	assert.NoError(err)
-	assert.Equal(attendees, []*Attendee{&Attendee{UserID: userID, Amount: 1}})
+	rsvpTime := time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC)
+	assert.Equal(attendees, []*Attendee{&Attendee{UserID: userID, Amount: 1, RSVPTime: rsvpTime}})
 
 	err = f.UserRSVPMeeting(groupID, &Attendee{UserID: userID2, Amount: 1})
 	assert.NoError(err)
@@ -111,7 +114,7 @@ func testAttendees(t *testing.T, f *Factory) {
 	assert.NoError(err)
 	attendeesByUserID := byUserID(attendees)
 	sort.Sort(attendeesByUserID)
-	expectedAttendees := byUserID{&Attendee{UserID: userID, Amount: 1}, &Attendee{UserID: userID2, Amount: 1}}
+	expectedAttendees := byUserID{&Attendee{UserID: userID, Amount: 1, RSVPTime: rsvpTime}, &Attendee{UserID: userID2, Amount: 1, RSVPTime: rsvpTime}}
 	sort.Sort(expectedAttendees)
 	assert.Equal(attendeesByUserID, expectedAttendees)
 }
@@ -285,3 +288,516 @@ func testMeetingAttendeesData(t *testing.T, f *Factory) {
 	assert.NoError(err)
 	assert.Equal(data, attendeesData)
 }
+
+func testMeetingIDs(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	tf := setTimeFactory(f)
+	groupID := "ashf"
+
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC)}
+	err := f.CreateMeeting(groupID, m)
+	assert.NoError(err)
+	assert.NotEmpty(m.ID)
+
+	m2, err := f.GetMeeting(groupID)
+	assert.NoError(err)
+	assert.Equal(m.ID, m2.ID)
+
+	tf.CurrentNow = time.Date(2019, 5, 3, 20, 3, 7, 0, time.UTC)
+
+	m3 := &Meeting{Time: time.Date(2019, 5, 4, 20, 3, 7, 0, time.UTC)}
+	err = f.CreateMeeting(groupID, m3)
+	assert.NoError(err)
+	assert.NotEmpty(m3.ID)
+	assert.NotEqual(m.ID, m3.ID)
+}
+
+func testAttendeesAreNotCarriedOver(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	tf := setTimeFactory(f)
+	groupID := "ashf"
+	userID := "oihf"
+
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC)}
+	err := f.CreateMeeting(groupID, m)
+	assert.NoError(err)
+
+	err = f.UserRSVPMeeting(groupID, &Attendee{UserID: userID, Amount: 2})
+	assert.NoError(err)
+
+	tf.CurrentNow = time.Date(2019, 5, 3, 20, 3, 7, 0, time.UTC)
+
+	err = f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 4, 20, 3, 7, 0, time.UTC)})
+	assert.NoError(err)
+
+	attendees, err := f.GetMeetingAttendees(groupID)
+	assert.NoError(err)
+	assert.Equal(attendees, []*Attendee{})
+
+	err = f.UserRSVPMeeting(groupID, &Attendee{UserID: userID, Amount: 1})
+	assert.NoError(err)
+
+	attendees, err = f.GetMeetingAttendeesByID(m.ID)
+	assert.NoError(err)
+	assert.Equal(attendees, []*Attendee{&Attendee{UserID: userID, Amount: 2, RSVPTime: time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC)}})
+}
+
+func testCancelMeeting(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	setTimeFactory(f)
+	groupID := "ashf"
+
+	err := f.CancelMeeting(groupID)
+	assert.Equal(err, NoActiveMeeting)
+
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}
+	err = f.CreateMeeting(groupID, m)
+	assert.NoError(err)
+
+	err = f.CancelMeeting(groupID)
+	assert.NoError(err)
+
+	_, err = f.GetMeeting(groupID)
+	assert.Equal(err, NoActiveMeeting)
+
+	err = f.CancelMeeting(groupID)
+	assert.Equal(err, NoActiveMeeting)
+
+	meetings, err := f.GetMeetings(groupID)
+	assert.NoError(err)
+	assert.Equal(len(meetings), 1)
+	assert.Equal(meetings[0].ID, m.ID)
+	assert.True(meetings[0].Closed)
+	assert.True(meetings[0].Cancelled)
+
+	err = f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 3, 20, 3, 7, 0, time.UTC)})
+	assert.NoError(err)
+}
+
+func testGetMeetings(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	tf := setTimeFactory(f)
+	groupID := "ashf"
+
+	meetings, err := f.GetMeetings(groupID)
+	assert.NoError(err)
+	assert.Equal(len(meetings), 0)
+
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}
+	err = f.CreateMeeting(groupID, m)
+	assert.NoError(err)
+
+	tf.CurrentNow = time.Date(2019, 5, 3, 20, 3, 7, 0, time.UTC)
+
+	m2 := &Meeting{Time: time.Date(2019, 5, 4, 20, 3, 7, 0, time.UTC), Location: "Bar", Capacity: 4}
+	err = f.CreateMeeting(groupID, m2)
+	assert.NoError(err)
+
+	err = f.CreateMeeting("other", &Meeting{Time: time.Date(2019, 5, 5, 20, 3, 7, 0, time.UTC)})
+	assert.NoError(err)
+
+	meetings, err = f.GetMeetings(groupID)
+	assert.NoError(err)
+	assert.Equal(len(meetings), 2)
+	assert.Equal(meetings[0].ID, m.ID)
+	assert.Equal(meetings[0].Location, "Home")
+	assert.True(meetings[0].Closed)
+	assert.Equal(meetings[1].ID, m2.ID)
+	assert.Equal(meetings[1].Location, "Bar")
+	assert.Equal(meetings[1].Capacity, 4)
+	assert.False(meetings[1].Closed)
+	assert.False(meetings[1].Cancelled)
+}
+
+func testGetActiveMeetings(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	tf := setTimeFactory(f)
+
+	active, err := f.GetActiveMeetings()
+	assert.NoError(err)
+	assert.Equal(len(active), 0)
+
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}
+	assert.NoError(f.CreateMeeting("ashf", m))
+	m2 := &Meeting{Time: time.Date(2019, 5, 4, 20, 3, 7, 0, time.UTC), Location: "Bar", Capacity: 4}
+	assert.NoError(f.CreateMeeting("other", m2))
+
+	active, err = f.GetActiveMeetings()
+	assert.NoError(err)
+	assert.Equal(len(active), 2)
+	assert.Equal(active["ashf"].ID, m.ID)
+	assert.Equal(active["other"].ID, m2.ID)
+	assert.Equal(active["other"].Capacity, 4)
+	assert.True(active["other"].Time.Equal(m2.Time))
+
+	tf.CurrentNow = time.Date(2019, 5, 3, 20, 3, 7, 0, time.UTC)
+	_, err = f.GetMeeting("ashf")
+	assert.Equal(err, NoActiveMeeting)
+
+	active, err = f.GetActiveMeetings()
+	assert.NoError(err)
+	assert.Equal(len(active), 1)
+	assert.Equal(active["other"].ID, m2.ID)
+}
+
+func testOnClose(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	tf := setTimeFactory(f)
+	groupID := "ashf"
+
+	closed := []string{}
+	f.OnClose = func(groupID string, meeting *Meeting) {
+		data := map[string]string{}
+		assert.NoError(f.GetMeetingAttendeesData(groupID, &data))
+		closed = append(closed, meeting.Location+" "+data["message"])
+	}
+
+	assert.NoError(f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}))
+	assert.NoError(f.SetMeetingAttendeesData(groupID, map[string]string{"message": "1"}))
+	tf.CurrentNow = time.Date(2019, 5, 3, 20, 3, 7, 0, time.UTC)
+	_, err := f.GetMeeting(groupID)
+	assert.Equal(err, NoActiveMeeting)
+
+	assert.NoError(f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 4, 20, 3, 7, 0, time.UTC), Location: "Bar"}))
+	assert.NoError(f.SetMeetingAttendeesData(groupID, map[string]string{"message": "2"}))
+	assert.NoError(f.CloseMeeting(groupID))
+	assert.Equal(f.CloseMeeting(groupID), NoActiveMeeting)
+
+	assert.Equal(closed, []string{"Home 1", "Bar 2"})
+}
+
+func testOnCancel(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	setTimeFactory(f)
+	groupID := "ascn"
+
+	cancelled := []string{}
+	f.OnCancel = func(groupID string, meeting *Meeting) {
+		data := map[string]string{}
+		assert.NoError(f.GetMeetingAttendeesData(groupID, &data))
+		cancelled = append(cancelled, meeting.Location+" "+data["message"])
+	}
+	f.OnClosed = func(groupID string, meeting *Meeting) {
+		assert.True(meeting.Cancelled)
+		assert.NoError(f.CreateMeeting(groupID, &Meeting{Time: meeting.Time.Add(7 * 24 * time.Hour), Location: meeting.Location}))
+	}
+
+	assert.NoError(f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}))
+	assert.NoError(f.SetMeetingAttendeesData(groupID, map[string]string{"message": "1"}))
+	assert.NoError(f.CancelMeeting(groupID))
+
+	assert.Equal(cancelled, []string{"Home 1"})
+	meeting, err := f.GetMeeting(groupID)
+	assert.NoError(err)
+	assert.Equal(meeting.Time, time.Date(2019, 5, 9, 20, 3, 7, 0, time.UTC))
+}
+
+func testRecurrence(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	tf := setTimeFactory(f)
+	groupID := "ashf"
+
+	closed := []*Meeting{}
+	f.OnClosed = func(groupID string, meeting *Meeting) {
+		// a new meeting can be created as soon as the previous one closes
+		_, err := f.GetMeeting(groupID)
+		assert.Equal(err, NoActiveMeeting)
+		closed = append(closed, meeting)
+	}
+
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home", Recurrence: "FREQ=WEEKLY"}
+	assert.NoError(f.CreateMeeting(groupID, m))
+	m2, err := f.GetMeeting(groupID)
+	assert.NoError(err)
+	assert.Equal(m2.Recurrence, "FREQ=WEEKLY")
+
+	assert.NoError(f.SetMeetingRecurrence(groupID, "FREQ=MONTHLY;BYDAY=1TH"))
+	active, err := f.GetActiveMeetings()
+	assert.NoError(err)
+	assert.Equal(active[groupID].Recurrence, "FREQ=MONTHLY;BYDAY=1TH")
+
+	tf.CurrentNow = time.Date(2019, 5, 3, 20, 3, 7, 0, time.UTC)
+	_, err = f.GetMeeting(groupID)
+	assert.Equal(err, NoActiveMeeting)
+	assert.Equal(f.SetMeetingRecurrence(groupID, ""), NoActiveMeeting)
+
+	assert.NoError(f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 4, 20, 3, 7, 0, time.UTC), Location: "Bar"}))
+	assert.NoError(f.CancelMeeting(groupID))
+
+	all, err := f.GetMeetings(groupID)
+	assert.NoError(err)
+	assert.Equal(len(all), 2)
+	assert.Equal(all[0].Recurrence, "FREQ=MONTHLY;BYDAY=1TH")
+	assert.Equal(all[1].Recurrence, "")
+
+	assert.Equal(len(closed), 2)
+	assert.Equal(closed[0].ID, m.ID)
+	assert.True(closed[0].Closed)
+	assert.False(closed[0].Cancelled)
+	assert.Equal(closed[0].Recurrence, "FREQ=MONTHLY;BYDAY=1TH")
+	assert.Equal(closed[1].Location, "Bar")
+	assert.True(closed[1].Cancelled)
+}
+
+func testOverlap(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	tf := setTimeFactory(f)
+	groupID := "ashf"
+
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home", Duration: 4 * time.Hour}
+	assert.NoError(f.CreateMeeting(groupID, m))
+	m2, err := f.GetMeeting(groupID)
+	assert.NoError(err)
+	assert.Equal(m2.Duration, 4*time.Hour)
+	assert.True(m2.End().Equal(time.Date(2019, 5, 3, 0, 0, 0, 0, time.UTC)))
+
+	// the meeting is closed once it starts but is still going on
+	tf.CurrentNow = time.Date(2019, 5, 2, 21, 0, 0, 0, time.UTC)
+	assert.Equal(f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 2, 23, 0, 0, 0, time.UTC), Location: "Bar"}), MeetingsOverlap)
+	assert.NoError(f.CreateMeeting("other", &Meeting{Time: time.Date(2019, 5, 2, 23, 0, 0, 0, time.UTC), Location: "Bar"}))
+	assert.NoError(f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 3, 0, 0, 0, 0, time.UTC), Location: "Bar", Duration: time.Hour}))
+	assert.NoError(f.CancelMeeting(groupID))
+	assert.NoError(f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 3, 0, 30, 0, 0, time.UTC), Location: "Bar"}))
+
+	all, err := f.GetMeetings(groupID)
+	assert.NoError(err)
+	assert.Equal(len(all), 3)
+	assert.Equal(all[0].Duration, 4*time.Hour)
+	assert.Equal(all[1].Duration, time.Hour)
+	assert.Equal(all[2].Duration, time.Duration(0))
+}
+
+func testRSVPCutoff(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	tf := setTimeFactory(f)
+	groupID := "ashf"
+
+	assert.NoError(f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home", RSVPCutoff: 6 * time.Hour}))
+	m, err := f.GetMeeting(groupID)
+	assert.NoError(err)
+	assert.Equal(m.RSVPCutoff, 6*time.Hour)
+	assert.True(m.RSVPDeadline().Equal(time.Date(2019, 5, 2, 14, 0, 0, 0, time.UTC)))
+
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "1", Amount: 1}))
+	tf.CurrentNow = time.Date(2019, 5, 2, 14, 0, 0, 0, time.UTC)
+	assert.Equal(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1}), RSVPClosed)
+	assert.Equal(f.UserRSVPMeeting(groupID, &Attendee{UserID: "1", Amount: 0}), RSVPClosed)
+
+	// the meeting is still visible until it starts
+	_, err = f.GetMeeting(groupID)
+	assert.NoError(err)
+
+	assert.NoError(f.SetMeetingRSVPCutoff(groupID, 2*time.Hour))
+	active, err := f.GetActiveMeetings()
+	assert.NoError(err)
+	assert.Equal(active[groupID].RSVPCutoff, 2*time.Hour)
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1}))
+
+	attendees, err := f.GetMeetingAttendees(groupID)
+	assert.NoError(err)
+	assert.Equal(len(attendees), 2)
+	assert.Equal(f.SetMeetingRSVPCutoff("other", time.Hour), NoActiveMeeting)
+}
+
+func testMaxGuests(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	setTimeFactory(f)
+	groupID := "ashf"
+
+	assert.NoError(f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home", Capacity: 6, MaxGuests: 2}))
+	m, err := f.GetMeeting(groupID)
+	assert.NoError(err)
+	assert.Equal(m.MaxGuests, 2)
+
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "1", Amount: 3}))
+	assert.Equal(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 4}), TooManyGuests)
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 3}))
+	// the capacity still applies
+	assert.Equal(f.UserRSVPMeeting(groupID, &Attendee{UserID: "3", Amount: 2}), MeetingIsFull)
+
+	assert.NoError(f.SetMeetingMaxGuests(groupID, NoGuests))
+	assert.Equal(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 2}), TooManyGuests)
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1}))
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "3", Amount: 1}))
+
+	assert.NoError(f.SetMeetingMaxGuests(groupID, 0))
+	active, err := f.GetActiveMeetings()
+	assert.NoError(err)
+	assert.Equal(active[groupID].MaxGuests, 0)
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 2}))
+	assert.Equal(f.SetMeetingMaxGuests("other", 1), NoActiveMeeting)
+}
+
+func testAttendeeGuests(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	setTimeFactory(f)
+	groupID := "ashf"
+
+	assert.NoError(f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home", Capacity: 6}))
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "1", Amount: 3}))
+	assert.Equal(f.SetAttendeeGuests(groupID, "2", []*Guest{&Guest{Name: "Bob"}}), UserDoesNotAttendMeeting)
+	assert.Equal(f.SetAttendeeGuests(groupID, "1", []*Guest{&Guest{Name: "Bob"}, &Guest{Name: "Carol"}, &Guest{Name: "Dave"}}), TooManyGuests)
+	assert.NoError(f.SetAttendeeGuests(groupID, "1", []*Guest{&Guest{Name: "Bob"}, &Guest{Name: "Carol", Handle: "carol"}}))
+
+	attendees, err := f.GetMeetingAttendees(groupID)
+	assert.NoError(err)
+	assert.Equal(len(attendees), 1)
+	assert.Equal(attendees[0].Guests, []*Guest{&Guest{Name: "Bob"}, &Guest{Name: "Carol", Handle: "carol"}})
+
+	linked, err := f.LinkGuests(groupID, "Carol", "5")
+	assert.NoError(err)
+	assert.Equal(linked, 1)
+	attendees, err = f.GetMeetingAttendees(groupID)
+	assert.NoError(err)
+	assert.Equal(attendees[0].Guests[1].UserID, "5")
+
+	linked, err = f.LinkGuests(groupID, "carol", "5")
+	assert.NoError(err)
+	assert.Equal(linked, 0)
+	_, err = f.LinkGuests("other", "carol", "5")
+	assert.Equal(err, NoActiveMeeting)
+}
+
+func testMaybe(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	setTimeFactory(f)
+	groupID := "ashf"
+
+	assert.NoError(f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home", Capacity: 2}))
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "1", Amount: 2}))
+	// maybes take none of the capacity
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1, Maybe: true}))
+	assert.Equal(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1, Maybe: true}), UserAlreadyAttendsMeeting)
+	assert.Equal(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1}), MeetingIsFull)
+
+	attendees, err := f.GetMeetingAttendees(groupID)
+	assert.NoError(err)
+	assert.Equal(len(attendees), 2)
+	for _, attendee := range attendees {
+		assert.Equal(attendee.Maybe, attendee.UserID == "2")
+	}
+
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "1", Amount: 2, Maybe: true}))
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1}))
+	attendees, err = f.GetMeetingAttendees(groupID)
+	assert.NoError(err)
+	for _, attendee := range attendees {
+		assert.Equal(attendee.Going(), attendee.UserID == "2")
+	}
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "1", Amount: 0}))
+}
+
+func testTimestamps(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	tf := setTimeFactory(f)
+	groupID := "ashf"
+	created := tf.Now()
+
+	assert.NoError(f.CreateMeeting(groupID, &Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home"}))
+	tf.AdvaceTime(time.Hour)
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "1", Amount: 1}))
+	tf.AdvaceTime(time.Hour)
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1}))
+	tf.AdvaceTime(time.Hour)
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "1", Amount: 2}))
+	assert.Equal(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1}), UserAlreadyAttendsMeeting)
+
+	meeting, err := f.GetMeeting(groupID)
+	assert.NoError(err)
+	assert.True(meeting.CreatedAt.Equal(created))
+	attendees, err := f.GetMeetingAttendees(groupID)
+	assert.NoError(err)
+	assert.Equal(len(attendees), 2)
+	for _, attendee := range attendees {
+		if attendee.UserID == "1" {
+			assert.True(attendee.RSVPTime.Equal(created.Add(3 * time.Hour)))
+		} else {
+			assert.True(attendee.RSVPTime.Equal(created.Add(2 * time.Hour)))
+		}
+	}
+	meetings, err := f.GetMeetings(groupID)
+	assert.NoError(err)
+	assert.True(meetings[0].CreatedAt.Equal(created))
+}
+
+func testCheckIn(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	tf := setTimeFactory(f)
+	groupID := "ashf"
+
+	m := &Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home", HostID: "1"}
+	assert.NoError(f.CreateMeeting(groupID, m))
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "1", Amount: 3}))
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1}))
+	tf.CurrentNow = time.Date(2019, 5, 2, 20, 30, 0, 0, time.UTC)
+	_, err := f.GetMeeting(groupID)
+	assert.Equal(err, NoActiveMeeting)
+
+	// check-in happens once the meeting is closed
+	assert.NoError(f.CheckIn(m.ID, "1", true, 2))
+	assert.NoError(f.CheckIn(m.ID, "1", true, 1))
+	assert.Equal(f.CheckIn(m.ID, "1", true, 3), TooManyGuests)
+	assert.Equal(f.CheckIn(m.ID, "2", true, 1), TooManyGuests)
+	assert.Equal(f.CheckIn(m.ID, "3", true, 0), UserDoesNotAttendMeeting)
+
+	attendees, err := f.GetMeetingAttendeesByID(m.ID)
+	assert.NoError(err)
+	for _, attendee := range attendees {
+		if attendee.UserID == "1" {
+			assert.True(attendee.CheckedIn)
+			assert.Equal(attendee.GuestsCheckedIn, 1)
+		} else {
+			assert.False(attendee.CheckedIn)
+			assert.Equal(attendee.GuestsCheckedIn, 0)
+		}
+	}
+	meetings, err := f.GetMeetings(groupID)
+	assert.NoError(err)
+	assert.Equal(meetings[0].HostID, "1")
+
+	noShows, err := f.NoShows(groupID, "2", 5)
+	assert.NoError(err)
+	assert.Equal(noShows, 1)
+	noShows, err = f.NoShows(groupID, "1", 5)
+	assert.NoError(err)
+	assert.Equal(noShows, 0)
+}
+
+func testNoShowRule(t *testing.T, f *Factory) {
+	assert := assert.New(t)
+	tf := setTimeFactory(f)
+	groupID := "ashf"
+	f.NoShowLimit = 2
+	f.NoShowWindow = 3
+	f.NoShowDelay = 24 * time.Hour
+
+	meet := func(day int, checkIn bool) {
+		m := &Meeting{Time: time.Date(2019, 5, day, 20, 0, 0, 0, time.UTC), Location: "Home"}
+		assert.NoError(f.CreateMeeting(groupID, m))
+		assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "1", Amount: 1}))
+		assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1}))
+		tf.CurrentNow = m.Time.Add(time.Hour)
+		_, err := f.GetMeeting(groupID)
+		assert.Equal(err, NoActiveMeeting)
+		if checkIn {
+			assert.NoError(f.CheckIn(m.ID, "1", true, 0))
+		}
+	}
+	meet(2, true)
+	// meetings without check-ins do not count
+	meet(4, false)
+	meet(6, true)
+
+	m := &Meeting{Time: time.Date(2019, 5, 10, 20, 0, 0, 0, time.UTC), Location: "Home"}
+	assert.NoError(f.CreateMeeting(groupID, m))
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "1", Amount: 1}))
+	assert.Equal(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1}), SignUpNotOpen)
+	assert.Equal(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1, Maybe: true}), SignUpNotOpen)
+	tf.AdvaceTime(24 * time.Hour)
+	assert.NoError(f.UserRSVPMeeting(groupID, &Attendee{UserID: "2", Amount: 1}))
+
+	f.NoShowWindow = 1
+	noShows, err := f.NoShows(groupID, "2", f.NoShowWindow)
+	assert.NoError(err)
+	assert.Equal(noShows, 1)
+}
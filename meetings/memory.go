@@ -3,11 +3,15 @@ package meetings
 import (
 	"encoding/json"
 	"log"
+	"sort"
+	"strconv"
+	"time"
 )
 
 type Memory struct {
+	lastMeetingID        int
 	groupMeetings        map[string]*Meeting
-	groupAttendees       map[string][]*Attendee
+	meetingAttendees     map[string][]*Attendee
 	closedMeetings       map[string][]*Meeting
 	meetingAttendeesData map[string][]byte
 }
@@ -15,7 +19,7 @@ type Memory struct {
 func NewMemory() *Factory {
 	return NewFactory(&Memory{
 		groupMeetings:        map[string]*Meeting{},
-		groupAttendees:       map[string][]*Attendee{},
+		meetingAttendees:     map[string][]*Attendee{},
 		closedMeetings:       map[string][]*Meeting{},
 		meetingAttendeesData: map[string][]byte{},
 	})
@@ -25,15 +29,19 @@ func (m *Memory) CreateMeeting(groupID string, meeting *Meeting) error {
 	if _, found := m.groupMeetings[groupID]; found {
 		return MeetingAlreadyActive
 	}
+	m.lastMeetingID++
+	meeting.ID = strconv.Itoa(m.lastMeetingID)
 	m.groupMeetings[groupID] = meeting
 	return nil
 }
 
 func (m *Memory) DeleteMeeting(groupID string) error {
-	if _, found := m.groupMeetings[groupID]; !found {
+	meeting, found := m.groupMeetings[groupID]
+	if !found {
 		return NoActiveMeeting
 	}
 	delete(m.groupMeetings, groupID)
+	delete(m.meetingAttendees, meeting.ID)
 	return nil
 }
 
@@ -46,55 +54,133 @@ func (m *Memory) GetMeeting(groupID string) (*Meeting, error) {
 }
 
 func (m *Memory) UserRSVPMeeting(groupID string, attendee *Attendee) error {
-	if _, found := m.groupMeetings[groupID]; !found {
+	meeting, found := m.groupMeetings[groupID]
+	if !found {
 		return NoActiveMeeting
 	}
-	if attendees, found := m.groupAttendees[groupID]; found {
+	if attendees, found := m.meetingAttendees[meeting.ID]; found {
 		for _, att := range attendees {
 			if attendee.UserID == att.UserID {
-				if attendee.Amount == att.Amount {
+				if attendee.Amount == att.Amount && (attendee.Maybe == att.Maybe || att.Amount == 0) {
 					if att.Amount == 0 {
 						return UserDoesNotAttendMeeting
 					}
 					return UserAlreadyAttendsMeeting
 				}
 				att.Amount = attendee.Amount
+				att.Maybe = attendee.Maybe && attendee.Amount > 0
+				att.RSVPTime = attendee.RSVPTime
 				return nil
 			}
 		}
 	} else {
-		m.groupAttendees[groupID] = []*Attendee{}
+		m.meetingAttendees[meeting.ID] = []*Attendee{}
 	}
 	if attendee.Amount == 0 {
 		return UserDoesNotAttendMeeting
 	}
-	m.groupAttendees[groupID] = append(m.groupAttendees[groupID], attendee)
+	m.meetingAttendees[meeting.ID] = append(m.meetingAttendees[meeting.ID], attendee)
 	return nil
 }
 func (m *Memory) GetMeetingAttendees(groupID string) ([]*Attendee, error) {
-	if _, found := m.groupMeetings[groupID]; !found {
+	meeting, found := m.groupMeetings[groupID]
+	if !found {
 		return nil, NoActiveMeeting
 	}
-	attendees, found := m.groupAttendees[groupID]
+	return m.GetMeetingAttendeesByID(meeting.ID)
+}
+func (m *Memory) GetMeetingAttendeesByID(meetingID string) ([]*Attendee, error) {
+	attendees, found := m.meetingAttendees[meetingID]
 	if !found {
 		return []*Attendee{}, nil
 	}
 	return attendees, nil
-
 }
 func (m *Memory) CloseMeeting(groupID string) error {
-	if _, found := m.groupMeetings[groupID]; !found {
+	meeting, found := m.groupMeetings[groupID]
+	if !found {
 		return NoActiveMeeting
 	}
-	if _, found := m.closedMeetings[groupID]; !found {
-		m.closedMeetings[groupID] = []*Meeting{}
-	}
-	m.closedMeetings[groupID] = append(m.closedMeetings[groupID], m.groupMeetings[groupID])
+	meeting.Closed = true
+	m.closedMeetings[groupID] = append(m.closedMeetings[groupID], meeting)
 	delete(m.groupMeetings, groupID)
 	return nil
 }
+func (m *Memory) CancelMeeting(groupID string) error {
+	meeting, found := m.groupMeetings[groupID]
+	if !found {
+		return NoActiveMeeting
+	}
+	meeting.Cancelled = true
+	return m.CloseMeeting(groupID)
+}
+func (m *Memory) GetMeetings(groupID string) ([]*Meeting, error) {
+	meetings := append([]*Meeting{}, m.closedMeetings[groupID]...)
+	if meeting, found := m.groupMeetings[groupID]; found {
+		meetings = append(meetings, meeting)
+	}
+	sort.SliceStable(meetings, func(i, j int) bool {
+		return meetings[i].Time.Before(meetings[j].Time)
+	})
+	return meetings, nil
+}
+func (m *Memory) GetActiveMeetings() (map[string]*Meeting, error) {
+	meetings := make(map[string]*Meeting, len(m.groupMeetings))
+	for groupID, meeting := range m.groupMeetings {
+		meetings[groupID] = meeting
+	}
+	return meetings, nil
+}
+func (m *Memory) SetMeetingRecurrence(groupID string, recurrence string) error {
+	meeting, found := m.groupMeetings[groupID]
+	if !found {
+		return NoActiveMeeting
+	}
+	meeting.Recurrence = recurrence
+	return nil
+}
+func (m *Memory) SetMeetingRSVPCutoff(groupID string, cutoff time.Duration) error {
+	meeting, found := m.groupMeetings[groupID]
+	if !found {
+		return NoActiveMeeting
+	}
+	meeting.RSVPCutoff = cutoff
+	return nil
+}
+func (m *Memory) SetMeetingMaxGuests(groupID string, maxGuests int) error {
+	meeting, found := m.groupMeetings[groupID]
+	if !found {
+		return NoActiveMeeting
+	}
+	meeting.MaxGuests = maxGuests
+	return nil
+}
+func (m *Memory) SetAttendeeGuests(groupID string, userID string, guests []*Guest) error {
+	meeting, found := m.groupMeetings[groupID]
+	if !found {
+		return NoActiveMeeting
+	}
+	for _, attendee := range m.meetingAttendees[meeting.ID] {
+		if attendee.UserID == userID && attendee.Amount > 0 {
+			attendee.Guests = guests
+			return nil
+		}
+	}
+	return UserDoesNotAttendMeeting
+}
+func (m *Memory) CheckIn(meetingID string, userID string, checkedIn bool, guests int) error {
+	for _, attendee := range m.meetingAttendees[meetingID] {
+		if attendee.UserID == userID && attendee.Amount > 0 {
+			attendee.CheckedIn = checkedIn
+			attendee.GuestsCheckedIn = guests
+			return nil
+		}
+	}
+	return UserDoesNotAttendMeeting
+}
 func (m *Memory) SetMeetingAttendeesData(groupID string, data interface{}) error {
-	if _, found := m.groupMeetings[groupID]; !found {
+	meeting, found := m.groupMeetings[groupID]
+	if !found {
 		return NoActiveMeeting
 	}
 	v, err := json.Marshal(data)
@@ -102,14 +188,15 @@ func (m *Memory) SetMeetingAttendeesData(groupID string, data interface{}) error
 		log.Print(err)
 		return err
 	}
-	m.meetingAttendeesData[groupID] = v
+	m.meetingAttendeesData[meeting.ID] = v
 	return nil
 }
 func (m *Memory) GetMeetingAttendeesData(groupID string, v interface{}) error {
-	if _, found := m.groupMeetings[groupID]; !found {
+	meeting, found := m.groupMeetings[groupID]
+	if !found {
 		return NoActiveMeeting
 	}
-	data, found := m.meetingAttendeesData[groupID]
+	data, found := m.meetingAttendeesData[meeting.ID]
 	if !found {
 		return nil
 	}
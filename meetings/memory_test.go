@@ -51,3 +51,67 @@ func TestHaveMultipleClosedMeetingsMemory(t *testing.T) {
 func TestMeetingAttendeesDataMemory(t *testing.T) {
 	testMeetingAttendeesData(t, NewMemory())
 }
+
+func TestMeetingIDsMemory(t *testing.T) {
+	testMeetingIDs(t, NewMemory())
+}
+
+func TestAttendeesAreNotCarriedOverMemory(t *testing.T) {
+	testAttendeesAreNotCarriedOver(t, NewMemory())
+}
+
+func TestCancelMeetingMemory(t *testing.T) {
+	testCancelMeeting(t, NewMemory())
+}
+
+func TestGetMeetingsMemory(t *testing.T) {
+	testGetMeetings(t, NewMemory())
+}
+
+func TestGetActiveMeetingsMemory(t *testing.T) {
+	testGetActiveMeetings(t, NewMemory())
+}
+
+func TestOnCloseMemory(t *testing.T) {
+	testOnClose(t, NewMemory())
+}
+
+func TestOnCancelMemory(t *testing.T) {
+	testOnCancel(t, NewMemory())
+}
+
+func TestRecurrenceMemory(t *testing.T) {
+	testRecurrence(t, NewMemory())
+}
+
+func TestOverlapMemory(t *testing.T) {
+	testOverlap(t, NewMemory())
+}
+
+func TestRSVPCutoffMemory(t *testing.T) {
+	testRSVPCutoff(t, NewMemory())
+}
+
+func TestMaxGuestsMemory(t *testing.T) {
+	testMaxGuests(t, NewMemory())
+}
+
+func TestAttendeeGuestsMemory(t *testing.T) {
+	testAttendeeGuests(t, NewMemory())
+}
+
+func TestMaybeMemory(t *testing.T) {
+	testMaybe(t, NewMemory())
+}
+
+func TestTimestampsMemory(t *testing.T) {
+	testTimestamps(t, NewMemory())
+}
+
+func TestCheckInMemory(t *testing.T) {
+	testCheckIn(t, NewMemory())
+}
+
+func TestNoShowRuleMemory(t *testing.T) {
+	testNoShowRule(t, NewMemory())
+}
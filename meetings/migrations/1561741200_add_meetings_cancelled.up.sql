@@ -0,0 +1 @@
+ALTER TABLE meetings ADD COLUMN cancelled BOOL NOT NULL default FALSE;
@@ -0,0 +1,4 @@
+ALTER TABLE attendees DROP CONSTRAINT attendees_meeting_id_user_id_key;
+DELETE FROM attendees a USING attendees b WHERE a.group_id = b.group_id AND a.user_id = b.user_id AND a.id < b.id;
+ALTER TABLE attendees ADD CONSTRAINT attendees_group_id_user_id_key UNIQUE (group_id, user_id);
+ALTER TABLE attendees DROP COLUMN meeting_id;
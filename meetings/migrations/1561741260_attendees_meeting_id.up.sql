@@ -0,0 +1,6 @@
+ALTER TABLE attendees ADD COLUMN meeting_id INT;
+UPDATE attendees SET meeting_id = (SELECT max(id) FROM meetings WHERE meetings.group_id = attendees.group_id);
+DELETE FROM attendees WHERE meeting_id IS NULL;
+ALTER TABLE attendees ALTER COLUMN meeting_id SET NOT NULL;
+ALTER TABLE attendees DROP CONSTRAINT attendees_group_id_user_id_key;
+ALTER TABLE attendees ADD CONSTRAINT attendees_meeting_id_user_id_key UNIQUE (meeting_id, user_id);
@@ -0,0 +1 @@
+ALTER TABLE meetings ADD COLUMN recurrence VARCHAR(255) NOT NULL default '';
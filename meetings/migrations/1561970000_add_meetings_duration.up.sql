@@ -0,0 +1 @@
+ALTER TABLE meetings ADD COLUMN duration INTEGER NOT NULL default 0;
@@ -0,0 +1 @@
+ALTER TABLE meetings ADD COLUMN rsvp_cutoff INTEGER NOT NULL default 0;
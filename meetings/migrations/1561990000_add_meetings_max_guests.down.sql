@@ -0,0 +1 @@
+ALTER TABLE meetings DROP COLUMN max_guests;
@@ -0,0 +1 @@
+ALTER TABLE meetings ADD COLUMN max_guests INTEGER NOT NULL default 0;
@@ -0,0 +1 @@
+ALTER TABLE attendees ADD COLUMN guests TEXT NOT NULL default '[]';
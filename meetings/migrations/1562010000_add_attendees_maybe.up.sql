@@ -0,0 +1 @@
+ALTER TABLE attendees ADD COLUMN maybe BOOL NOT NULL default FALSE;
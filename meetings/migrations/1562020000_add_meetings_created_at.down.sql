@@ -0,0 +1,2 @@
+ALTER TABLE attendees DROP COLUMN rsvp_time;
+ALTER TABLE meetings DROP COLUMN created_at;
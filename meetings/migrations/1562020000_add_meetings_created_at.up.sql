@@ -0,0 +1,2 @@
+ALTER TABLE meetings ADD COLUMN created_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE attendees ADD COLUMN rsvp_time TIMESTAMP WITH TIME ZONE;
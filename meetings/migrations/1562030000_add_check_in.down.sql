@@ -0,0 +1,3 @@
+ALTER TABLE attendees DROP COLUMN guests_checked_in;
+ALTER TABLE attendees DROP COLUMN checked_in;
+ALTER TABLE meetings DROP COLUMN host_id;
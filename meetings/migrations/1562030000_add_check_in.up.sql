@@ -0,0 +1,3 @@
+ALTER TABLE meetings ADD COLUMN host_id VARCHAR (255) NOT NULL default '';
+ALTER TABLE attendees ADD COLUMN checked_in BOOL NOT NULL default FALSE;
+ALTER TABLE attendees ADD COLUMN guests_checked_in INTEGER NOT NULL default 0;
@@ -0,0 +1 @@
+ALTER TABLE meetings DROP COLUMN location_id;
@@ -0,0 +1 @@
+ALTER TABLE meetings ADD COLUMN location_id VARCHAR (255) NOT NULL default '';
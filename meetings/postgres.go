@@ -7,7 +7,9 @@ import (
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/lib/pq"
 	"log"
+	"strconv"
 	"time"
 )
 
@@ -46,13 +48,13 @@ func NewPostgres(config *PostgresConfig) (*Factory, error) {
 
 func (p *Postgres) CreateMeeting(groupID string, meeting *Meeting) error {
 	query := `
-	INSERT INTO meetings (group_id, time, location, capacity, closed)
-	VALUES ($1, $2, $3, $4, $5)
+	INSERT INTO meetings (group_id, time, location, capacity, closed, recurrence, duration, rsvp_cutoff, max_guests, created_at, host_id, location_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	ON CONFLICT DO NOTHING
 	RETURNING id;
 	`
 	id := 0
-	err := p.db.QueryRow(query, groupID, meeting.Time, meeting.Location, meeting.Capacity, meeting.Closed).Scan(&id)
+	err := p.db.QueryRow(query, groupID, meeting.Time, meeting.Location, meeting.Capacity, meeting.Closed, meeting.Recurrence, int64(meeting.Duration/time.Second), int64(meeting.RSVPCutoff/time.Second), meeting.MaxGuests, nullTime(meeting.CreatedAt), meeting.HostID, meeting.LocationID).Scan(&id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return MeetingAlreadyActive
@@ -60,12 +62,23 @@ func (p *Postgres) CreateMeeting(groupID string, meeting *Meeting) error {
 		log.Printf("failed to create meeting: %#v", err)
 		return UnexpectedError
 	}
+	meeting.ID = strconv.Itoa(id)
 	return nil
 }
 
 func (p *Postgres) DeleteMeeting(groupID string) error {
 	query := `
-	DELETE FROM meetings WHERE group_id = $1
+	DELETE FROM attendees WHERE meeting_id = (
+		SELECT id FROM meetings WHERE group_id = $1 AND closed = false
+	)
+	`
+	_, err := p.db.Exec(query, groupID)
+	if err != nil {
+		log.Printf("failed to delete meeting attendees: %#v", err)
+		return UnexpectedError
+	}
+	query = `
+	DELETE FROM meetings WHERE group_id = $1 AND closed = false
 	`
 	result, err := p.db.Exec(query, groupID)
 	if err != nil {
@@ -85,10 +98,12 @@ func (p *Postgres) DeleteMeeting(groupID string) error {
 
 func (p *Postgres) GetMeeting(groupID string) (*Meeting, error) {
 	query := `
-	SELECT time AT TIME ZONE 'GMT', location, capacity FROM meetings WHERE group_id = $1 AND closed = false
+	SELECT id, time AT TIME ZONE 'GMT', location, capacity, recurrence, duration, rsvp_cutoff, max_guests, created_at, host_id, location_id FROM meetings WHERE group_id = $1 AND closed = false
 	`
 	m := &Meeting{}
-	err := p.db.QueryRow(query, groupID).Scan(&m.Time, &m.Location, &m.Capacity)
+	duration, rsvpCutoff := int64(0), int64(0)
+	createdAt := pq.NullTime{}
+	err := p.db.QueryRow(query, groupID).Scan(&m.ID, &m.Time, &m.Location, &m.Capacity, &m.Recurrence, &duration, &rsvpCutoff, &m.MaxGuests, &createdAt, &m.HostID, &m.LocationID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, NoActiveMeeting
@@ -97,13 +112,18 @@ func (p *Postgres) GetMeeting(groupID string) (*Meeting, error) {
 		return nil, UnexpectedError
 	}
 	m.Time = m.Time.In(time.UTC)
+	m.Duration = time.Duration(duration) * time.Second
+	m.RSVPCutoff = time.Duration(rsvpCutoff) * time.Second
+	m.CreatedAt = timeOrZero(createdAt)
 	return m, nil
 }
 
 func (p *Postgres) UserRSVPMeeting(groupID string, attendee *Attendee) error {
 	if attendee.Amount == 0 {
 		query := `
-       DELETE FROM attendees WHERE group_id = $1 AND user_id = $2
+       DELETE FROM attendees WHERE user_id = $2 AND meeting_id = (
+               SELECT id FROM meetings WHERE group_id = $1 AND closed = false
+       )
        `
 		result, err := p.db.Exec(query, groupID, attendee.UserID)
 		if err != nil {
@@ -121,12 +141,12 @@ func (p *Postgres) UserRSVPMeeting(groupID string, attendee *Attendee) error {
 		return nil
 	}
 	query := `
-	INSERT INTO attendees (group_id, user_id, amount)
-	VALUES ($1, $2, $3)
-	ON CONFLICT (group_id, user_id) DO UPDATE SET amount = $3 WHERE attendees.amount != $3
+	INSERT INTO attendees (group_id, meeting_id, user_id, amount, maybe, rsvp_time)
+	SELECT $1, id, $2, $3, $4, $5 FROM meetings WHERE group_id = $1 AND closed = false
+	ON CONFLICT (meeting_id, user_id) DO UPDATE SET amount = $3, maybe = $4, rsvp_time = $5 WHERE attendees.amount != $3 OR attendees.maybe != $4
 	RETURNING id;
 	`
-	result, err := p.db.Exec(query, groupID, attendee.UserID, attendee.Amount)
+	result, err := p.db.Exec(query, groupID, attendee.UserID, attendee.Amount, attendee.Maybe, nullTime(attendee.RSVPTime))
 	if err != nil {
 		log.Printf("failed to add attendee: %#v", err)
 		return UnexpectedError
@@ -143,9 +163,25 @@ func (p *Postgres) UserRSVPMeeting(groupID string, attendee *Attendee) error {
 }
 func (p *Postgres) GetMeetingAttendees(groupID string) ([]*Attendee, error) {
 	query := `
-	SELECT user_id, amount FROM attendees WHERE group_id = $1
+	SELECT attendees.user_id, attendees.amount, attendees.guests, attendees.maybe, attendees.rsvp_time, attendees.checked_in, attendees.guests_checked_in FROM attendees
+	JOIN meetings ON attendees.meeting_id = meetings.id
+	WHERE meetings.group_id = $1 AND meetings.closed = false
 	`
-	rows, err := p.db.Query(query, groupID)
+	return p.queryAttendees(query, groupID)
+}
+
+func (p *Postgres) GetMeetingAttendeesByID(meetingID string) ([]*Attendee, error) {
+	if _, err := strconv.Atoi(meetingID); err != nil {
+		return []*Attendee{}, nil
+	}
+	query := `
+	SELECT user_id, amount, guests, maybe, rsvp_time, checked_in, guests_checked_in FROM attendees WHERE meeting_id = $1
+	`
+	return p.queryAttendees(query, meetingID)
+}
+
+func (p *Postgres) queryAttendees(query string, args ...interface{}) ([]*Attendee, error) {
+	rows, err := p.db.Query(query, args...)
 	if err != nil {
 		log.Printf("failed to get attendees: %#v", err)
 		return nil, UnexpectedError
@@ -154,10 +190,20 @@ func (p *Postgres) GetMeetingAttendees(groupID string) ([]*Attendee, error) {
 	attendees := make([]*Attendee, 0)
 	for rows.Next() {
 		attendee := &Attendee{}
-		if err := rows.Scan(&attendee.UserID, &attendee.Amount); err != nil {
+		guests := ""
+		rsvpTime := pq.NullTime{}
+		if err := rows.Scan(&attendee.UserID, &attendee.Amount, &guests, &attendee.Maybe, &rsvpTime, &attendee.CheckedIn, &attendee.GuestsCheckedIn); err != nil {
 			log.Printf("failed to get next attendee: %#v", err)
 			return nil, UnexpectedError
 		}
+		if err := json.Unmarshal([]byte(guests), &attendee.Guests); err != nil {
+			log.Printf("failed to decode attendee guests: %#v", err)
+			return nil, UnexpectedError
+		}
+		if len(attendee.Guests) == 0 {
+			attendee.Guests = nil
+		}
+		attendee.RSVPTime = timeOrZero(rsvpTime)
 		attendees = append(attendees, attendee)
 	}
 	if err := rows.Err(); err != nil {
@@ -187,6 +233,207 @@ func (p *Postgres) CloseMeeting(groupID string) error {
 	return nil
 }
 
+func (p *Postgres) CancelMeeting(groupID string) error {
+	query := `
+	UPDATE meetings SET closed = true, cancelled = true WHERE group_id = $1 AND closed = false
+	`
+	result, err := p.db.Exec(query, groupID)
+	if err != nil {
+		log.Printf("failed to cancel meeting: %#v", err)
+		return UnexpectedError
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("failed to get affected rows after cancelling meeting: %#v", err)
+		return UnexpectedError
+	}
+	if affectedRows == 0 {
+		return NoActiveMeeting
+	}
+	return nil
+}
+
+func (p *Postgres) GetMeetings(groupID string) ([]*Meeting, error) {
+	query := `
+	SELECT id, time AT TIME ZONE 'GMT', location, capacity, closed, cancelled, recurrence, duration, rsvp_cutoff, max_guests, created_at, host_id, location_id FROM meetings WHERE group_id = $1 ORDER BY time
+	`
+	rows, err := p.db.Query(query, groupID)
+	if err != nil {
+		log.Printf("failed to get meetings: %#v", err)
+		return nil, UnexpectedError
+	}
+	defer rows.Close()
+	meetings := make([]*Meeting, 0)
+	for rows.Next() {
+		m := &Meeting{}
+		duration, rsvpCutoff := int64(0), int64(0)
+		createdAt := pq.NullTime{}
+		if err := rows.Scan(&m.ID, &m.Time, &m.Location, &m.Capacity, &m.Closed, &m.Cancelled, &m.Recurrence, &duration, &rsvpCutoff, &m.MaxGuests, &createdAt, &m.HostID, &m.LocationID); err != nil {
+			log.Printf("failed to get next meeting: %#v", err)
+			return nil, UnexpectedError
+		}
+		m.Time = m.Time.In(time.UTC)
+		m.Duration = time.Duration(duration) * time.Second
+		m.RSVPCutoff = time.Duration(rsvpCutoff) * time.Second
+		m.CreatedAt = timeOrZero(createdAt)
+		meetings = append(meetings, m)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("failed to get close meetings: %#v", err)
+		return nil, UnexpectedError
+	}
+	return meetings, nil
+}
+
+func (p *Postgres) GetActiveMeetings() (map[string]*Meeting, error) {
+	query := `
+	SELECT group_id, id, time AT TIME ZONE 'GMT', location, capacity, recurrence, duration, rsvp_cutoff, max_guests, created_at, host_id, location_id FROM meetings WHERE closed = false
+	`
+	rows, err := p.db.Query(query)
+	if err != nil {
+		log.Printf("failed to get active meetings: %#v", err)
+		return nil, UnexpectedError
+	}
+	defer rows.Close()
+	meetings := map[string]*Meeting{}
+	for rows.Next() {
+		groupID := ""
+		m := &Meeting{}
+		duration, rsvpCutoff := int64(0), int64(0)
+		createdAt := pq.NullTime{}
+		if err := rows.Scan(&groupID, &m.ID, &m.Time, &m.Location, &m.Capacity, &m.Recurrence, &duration, &rsvpCutoff, &m.MaxGuests, &createdAt, &m.HostID, &m.LocationID); err != nil {
+			log.Printf("failed to get next active meeting: %#v", err)
+			return nil, UnexpectedError
+		}
+		m.Time = m.Time.In(time.UTC)
+		m.Duration = time.Duration(duration) * time.Second
+		m.RSVPCutoff = time.Duration(rsvpCutoff) * time.Second
+		m.CreatedAt = timeOrZero(createdAt)
+		meetings[groupID] = m
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("failed to get active meetings: %#v", err)
+		return nil, UnexpectedError
+	}
+	return meetings, nil
+}
+
+func (p *Postgres) SetMeetingRecurrence(groupID string, recurrence string) error {
+	query := `
+	UPDATE meetings SET recurrence = $1 WHERE group_id = $2 AND closed = false
+	`
+	result, err := p.db.Exec(query, recurrence, groupID)
+	if err != nil {
+		log.Printf("failed to set meeting recurrence: %#v", err)
+		return UnexpectedError
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("failed to get affected rows after setting meeting recurrence: %#v", err)
+		return UnexpectedError
+	}
+	if affectedRows == 0 {
+		return NoActiveMeeting
+	}
+	return nil
+}
+
+func (p *Postgres) SetMeetingRSVPCutoff(groupID string, cutoff time.Duration) error {
+	query := `
+	UPDATE meetings SET rsvp_cutoff = $1 WHERE group_id = $2 AND closed = false
+	`
+	result, err := p.db.Exec(query, int64(cutoff/time.Second), groupID)
+	if err != nil {
+		log.Printf("failed to set meeting rsvp cutoff: %#v", err)
+		return UnexpectedError
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("failed to get affected rows after setting meeting rsvp cutoff: %#v", err)
+		return UnexpectedError
+	}
+	if affectedRows == 0 {
+		return NoActiveMeeting
+	}
+	return nil
+}
+
+func (p *Postgres) SetMeetingMaxGuests(groupID string, maxGuests int) error {
+	query := `
+	UPDATE meetings SET max_guests = $1 WHERE group_id = $2 AND closed = false
+	`
+	result, err := p.db.Exec(query, maxGuests, groupID)
+	if err != nil {
+		log.Printf("failed to set meeting max guests: %#v", err)
+		return UnexpectedError
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("failed to get affected rows after setting meeting max guests: %#v", err)
+		return UnexpectedError
+	}
+	if affectedRows == 0 {
+		return NoActiveMeeting
+	}
+	return nil
+}
+
+func (p *Postgres) SetAttendeeGuests(groupID string, userID string, guests []*Guest) error {
+	if guests == nil {
+		guests = []*Guest{}
+	}
+	v, err := json.Marshal(guests)
+	if err != nil {
+		log.Print(err)
+		return err
+	}
+	query := `
+	UPDATE attendees SET guests = $1 WHERE user_id = $3 AND amount > 0 AND meeting_id = (
+		SELECT id FROM meetings WHERE group_id = $2 AND closed = false
+	)
+	`
+	result, err := p.db.Exec(query, string(v), groupID, userID)
+	if err != nil {
+		log.Printf("failed to set attendee guests: %#v", err)
+		return UnexpectedError
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("failed to get affected rows after setting attendee guests: %#v", err)
+		return UnexpectedError
+	}
+	if affectedRows == 0 {
+		if _, err := p.GetMeeting(groupID); err != nil {
+			return err
+		}
+		return UserDoesNotAttendMeeting
+	}
+	return nil
+}
+
+func (p *Postgres) CheckIn(meetingID string, userID string, checkedIn bool, guests int) error {
+	if _, err := strconv.Atoi(meetingID); err != nil {
+		return UserDoesNotAttendMeeting
+	}
+	query := `
+	UPDATE attendees SET checked_in = $1, guests_checked_in = $2 WHERE meeting_id = $3 AND user_id = $4 AND amount > 0
+	`
+	result, err := p.db.Exec(query, checkedIn, guests, meetingID, userID)
+	if err != nil {
+		log.Printf("failed to check in attendee: %#v", err)
+		return UnexpectedError
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("failed to get affected rows after checking in attendee: %#v", err)
+		return UnexpectedError
+	}
+	if affectedRows == 0 {
+		return UserDoesNotAttendMeeting
+	}
+	return nil
+}
+
 func (p *Postgres) SetMeetingAttendeesData(groupID string, data interface{}) error {
 	v, err := json.Marshal(data)
 	if err != nil {
@@ -237,3 +484,15 @@ func (p *Postgres) GetMeetingAttendeesData(groupID string, v interface{}) error
 	}
 	return nil
 }
+
+// nullTime stores zero times as NULL
+func nullTime(t time.Time) pq.NullTime {
+	return pq.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func timeOrZero(t pq.NullTime) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+	return t.Time.In(time.UTC)
+}
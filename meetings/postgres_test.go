@@ -78,3 +78,67 @@ func TestHaveMultipleClosedMeetingsPostgres(t *testing.T) {
 func TestMeetingAttendeesDataPostgres(t *testing.T) {
 	testMeetingAttendeesData(t, getPostgres(t))
 }
+
+func TestMeetingIDsPostgres(t *testing.T) {
+	testMeetingIDs(t, getPostgres(t))
+}
+
+func TestAttendeesAreNotCarriedOverPostgres(t *testing.T) {
+	testAttendeesAreNotCarriedOver(t, getPostgres(t))
+}
+
+func TestCancelMeetingPostgres(t *testing.T) {
+	testCancelMeeting(t, getPostgres(t))
+}
+
+func TestGetMeetingsPostgres(t *testing.T) {
+	testGetMeetings(t, getPostgres(t))
+}
+
+func TestGetActiveMeetingsPostgres(t *testing.T) {
+	testGetActiveMeetings(t, getPostgres(t))
+}
+
+func TestOnClosePostgres(t *testing.T) {
+	testOnClose(t, getPostgres(t))
+}
+
+func TestOnCancelPostgres(t *testing.T) {
+	testOnCancel(t, getPostgres(t))
+}
+
+func TestRecurrencePostgres(t *testing.T) {
+	testRecurrence(t, getPostgres(t))
+}
+
+func TestOverlapPostgres(t *testing.T) {
+	testOverlap(t, getPostgres(t))
+}
+
+func TestRSVPCutoffPostgres(t *testing.T) {
+	testRSVPCutoff(t, getPostgres(t))
+}
+
+func TestMaxGuestsPostgres(t *testing.T) {
+	testMaxGuests(t, getPostgres(t))
+}
+
+func TestAttendeeGuestsPostgres(t *testing.T) {
+	testAttendeeGuests(t, getPostgres(t))
+}
+
+func TestMaybePostgres(t *testing.T) {
+	testMaybe(t, getPostgres(t))
+}
+
+func TestTimestampsPostgres(t *testing.T) {
+	testTimestamps(t, getPostgres(t))
+}
+
+func TestCheckInPostgres(t *testing.T) {
+	testCheckIn(t, getPostgres(t))
+}
+
+func TestNoShowRulePostgres(t *testing.T) {
+	testNoShowRule(t, getPostgres(t))
+}
@@ -0,0 +1,167 @@
+package ratings
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/games"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Initial is the rating of players before their first play
+const Initial = 1500.0
+
+// K is how many points a two player game can move at most
+const K = 32.0
+
+type Rating struct {
+	UserID string
+	Rating float64
+	Plays  int
+	Wins   int
+}
+
+// Ratings keeps Elo ratings per game and overall. Games are told apart by
+// their title, so copies owned by different members share their ratings.
+// Cooperative plays and plays with a single player are not rated.
+type Ratings struct {
+	Overall map[string]*Rating
+	// Games maps GameKey to the ratings for that game
+	Games map[string]map[string]*Rating
+	// Titles maps GameKey to the title of the game
+	Titles map[string]string
+}
+
+func New() *Ratings {
+	return &Ratings{Overall: map[string]*Rating{}, Games: map[string]map[string]*Rating{}, Titles: map[string]string{}}
+}
+
+// Compute rates the plays, given the most recent first like
+// games.Store.GetPlays returns them.
+func Compute(plays []*games.Play) *Ratings {
+	r := New()
+	for i := len(plays) - 1; i >= 0; i-- {
+		r.Add(plays[i])
+	}
+	return r
+}
+
+func GameKey(title string) string {
+	return strings.ToLower(strings.TrimSpace(title))
+}
+
+// Add updates the ratings with a play that happened after every play
+// already added.
+func (r *Ratings) Add(play *games.Play) {
+	if play.Cooperative || len(play.Players) < 2 {
+		return
+	}
+	key := GameKey(play.Title)
+	if r.Games[key] == nil {
+		r.Games[key] = map[string]*Rating{}
+	}
+	r.Titles[key] = play.Title
+	update(r.Overall, play)
+	update(r.Games[key], play)
+}
+
+// outcome is what the first player scores against the second one: 1 for a
+// win, 0.5 for a draw and 0 for a loss
+func outcome(play *games.Play, a *games.Player, b *games.Player) float64 {
+	if a.Winner != b.Winner {
+		if a.Winner {
+			return 1
+		}
+		return 0
+	}
+	if play.Scored && a.Score != b.Score {
+		if a.Score > b.Score {
+			return 1
+		}
+		return 0
+	}
+	return 0.5
+}
+
+func expected(rating float64, opponent float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponent-rating)/400))
+}
+
+// update treats a play as a match between every pair of its players, with K
+// split among the opponents so the number of players does not inflate the
+// changes.
+func update(ratings map[string]*Rating, play *games.Play) {
+	before := make([]float64, len(play.Players))
+	for i, player := range play.Players {
+		if ratings[player.UserID] == nil {
+			ratings[player.UserID] = &Rating{UserID: player.UserID, Rating: Initial}
+		}
+		before[i] = ratings[player.UserID].Rating
+	}
+	k := K / float64(len(play.Players)-1)
+	for i, player := range play.Players {
+		delta := 0.0
+		for j, opponent := range play.Players {
+			if i != j {
+				delta += outcome(play, player, opponent) - expected(before[i], before[j])
+			}
+		}
+		rating := ratings[player.UserID]
+		rating.Rating += k * delta
+		rating.Plays++
+		if player.Winner {
+			rating.Wins++
+		}
+	}
+}
+
+// Leaderboard returns the ratings for the game with the given key, or the
+// overall ones when it is empty, the highest first.
+func (r *Ratings) Leaderboard(key string) []*Rating {
+	ratings := r.Overall
+	if key != "" {
+		ratings = r.Games[key]
+	}
+	leaderboard := []*Rating{}
+	for _, rating := range ratings {
+		leaderboard = append(leaderboard, rating)
+	}
+	sort.Slice(leaderboard, func(i, j int) bool {
+		if leaderboard[i].Rating != leaderboard[j].Rating {
+			return leaderboard[i].Rating > leaderboard[j].Rating
+		}
+		return leaderboard[i].UserID < leaderboard[j].UserID
+	})
+	return leaderboard
+}
+
+// FindGame returns the key of the rated game with the given title or, when
+// there is none, of the only one whose title contains it.
+func (r *Ratings) FindGame(title string) (string, bool) {
+	title = GameKey(title)
+	if _, found := r.Games[title]; found {
+		return title, true
+	}
+	matches := []string{}
+	for key := range r.Games {
+		if strings.Contains(key, title) {
+			matches = append(matches, key)
+		}
+	}
+	if len(matches) != 1 {
+		return "", false
+	}
+	return matches[0], true
+}
+
+// UserGames returns the keys of the games the user has a rating for, sorted
+// by title.
+func (r *Ratings) UserGames(userID string) []string {
+	keys := []string{}
+	for key, ratings := range r.Games {
+		if _, found := ratings[userID]; found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,90 @@
+package ratings
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/games"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func players(winners ...bool) []*games.Player {
+	list := []*games.Player{}
+	for i, winner := range winners {
+		list = append(list, &games.Player{UserID: string('a' + rune(i)), Winner: winner})
+	}
+	return list
+}
+
+func TestTwoPlayers(t *testing.T) {
+	assert := assert.New(t)
+	r := New()
+	r.Add(&games.Play{Title: "Chess", Players: players(true, false)})
+	assert.Equal(*r.Overall["a"], Rating{UserID: "a", Rating: 1516, Plays: 1, Wins: 1})
+	assert.Equal(*r.Overall["b"], Rating{UserID: "b", Rating: 1484, Plays: 1})
+	assert.Equal(*r.Games["chess"]["a"], Rating{UserID: "a", Rating: 1516, Plays: 1, Wins: 1})
+
+	// the underdog wins more than the favourite would have
+	r.Add(&games.Play{Title: "chess", Players: players(false, true)})
+	assert.InDelta(r.Overall["a"].Rating, 1498.53, 0.01)
+	assert.InDelta(r.Overall["b"].Rating, 1501.47, 0.01)
+	assert.Equal(r.Titles["chess"], "chess")
+}
+
+func TestScores(t *testing.T) {
+	assert := assert.New(t)
+	r := New()
+	r.Add(&games.Play{Title: "Catan", Scored: true, Players: []*games.Player{
+		&games.Player{UserID: "a", Score: 10, Winner: true},
+		&games.Player{UserID: "b", Score: 8},
+		&games.Player{UserID: "c", Score: 8},
+		&games.Player{UserID: "d", Score: 6},
+	}})
+	assert.InDelta(r.Overall["a"].Rating, 1516, 0.001)
+	assert.InDelta(r.Overall["b"].Rating, 1500, 0.001)
+	assert.InDelta(r.Overall["c"].Rating, 1500, 0.001)
+	assert.InDelta(r.Overall["d"].Rating, 1484, 0.001)
+}
+
+func TestUnratedPlays(t *testing.T) {
+	assert := assert.New(t)
+	r := New()
+	r.Add(&games.Play{Title: "Pandemic", Cooperative: true, Won: true, Players: players(false, false)})
+	r.Add(&games.Play{Title: "Patience", Players: players(true)})
+	assert.Equal(len(r.Overall), 0)
+	assert.Equal(len(r.Games), 0)
+}
+
+func TestCompute(t *testing.T) {
+	assert := assert.New(t)
+	older := &games.Play{Title: "Chess", Players: players(true, false)}
+	newer := &games.Play{Title: "Go", Players: players(false, true)}
+	r := Compute([]*games.Play{newer, older})
+	assert.InDelta(r.Overall["a"].Rating, 1498.53, 0.01)
+	assert.Equal(r.Overall["a"].Plays, 2)
+	assert.Equal(r.Overall["a"].Wins, 1)
+
+	leaderboard := r.Leaderboard("")
+	assert.Equal(leaderboard[0].UserID, "b")
+	assert.Equal(leaderboard[1].UserID, "a")
+	leaderboard = r.Leaderboard("chess")
+	assert.Equal(leaderboard[0].UserID, "a")
+	assert.Equal(leaderboard[1].UserID, "b")
+	assert.Equal(len(r.Leaderboard("catan")), 0)
+
+	key, found := r.FindGame("CHE")
+	assert.True(found)
+	assert.Equal(key, "chess")
+	_, found = r.FindGame("x")
+	assert.False(found)
+	assert.Equal(r.UserGames("a"), []string{"chess", "go"})
+}
+
+func TestLeaderboardTies(t *testing.T) {
+	assert := assert.New(t)
+	r := Compute([]*games.Play{&games.Play{Title: "Chess", Players: []*games.Player{
+		&games.Player{UserID: "b"},
+		&games.Player{UserID: "a"},
+	}}})
+	leaderboard := r.Leaderboard("chess")
+	assert.Equal(leaderboard[0].UserID, "a")
+	assert.Equal(leaderboard[1].UserID, "b")
+}
@@ -0,0 +1,209 @@
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/dates"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRule = errors.New("Unknown recurrence. Try weekly, biweekly, monthly, '2nd thursday' or an RRULE like FREQ=WEEKLY;INTERVAL=2")
+
+type Frequency int
+
+const (
+	Weekly Frequency = iota
+	Monthly
+)
+
+// Rule is the subset of RFC 5545 recurrence rules meetings support:
+// FREQ=WEEKLY or FREQ=MONTHLY, an INTERVAL and a single BYDAY, which for
+// monthly rules carries the week of the month, like 2TH or -1FR.
+type Rule struct {
+	Frequency Frequency
+	Interval  int
+	Weekday   time.Weekday
+	HasDay    bool
+	Nth       int
+}
+
+var dayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+var byDay = regexp.MustCompile(`^([+-]?\d)?(SU|MO|TU|WE|TH|FR|SA)$`)
+var everyN = regexp.MustCompile(`^(?:every|cada) (\d+|two|dos) (?:weeks|semanas)$`)
+var nthWeekday = regexp.MustCompile(`^(?:the |el )?(\S+) (\S+)(?: of (?:every |each |the )?month| de cada mes| de todos los meses| del mes)?$`)
+
+var ordinals = map[string]int{
+	"1st": 1, "first": 1, "primer": 1, "primero": 1,
+	"2nd": 2, "second": 2, "segundo": 2,
+	"3rd": 3, "third": 3, "tercer": 3, "tercero": 3,
+	"4th": 4, "fourth": 4, "cuarto": 4,
+	"5th": 5, "fifth": 5, "quinto": 5,
+	"last": -1, "ultimo": -1,
+}
+
+var accents = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u")
+
+func Parse(rule string) (*Rule, error) {
+	r := &Rule{Interval: 1}
+	hasFrequency := false
+	for _, part := range strings.Split(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:"), ";") {
+		pair := strings.SplitN(part, "=", 2)
+		if len(pair) != 2 {
+			return nil, ErrInvalidRule
+		}
+		switch pair[0] {
+		case "FREQ":
+			switch pair[1] {
+			case "WEEKLY":
+				r.Frequency = Weekly
+			case "MONTHLY":
+				r.Frequency = Monthly
+			default:
+				return nil, ErrInvalidRule
+			}
+			hasFrequency = true
+		case "INTERVAL":
+			interval, err := strconv.Atoi(pair[1])
+			if err != nil || interval < 1 {
+				return nil, ErrInvalidRule
+			}
+			r.Interval = interval
+		case "BYDAY":
+			m := byDay.FindStringSubmatch(pair[1])
+			if m == nil {
+				return nil, ErrInvalidRule
+			}
+			r.HasDay = true
+			for i, code := range dayCodes {
+				if code == m[2] {
+					r.Weekday = time.Weekday(i)
+				}
+			}
+			if m[1] != "" {
+				r.Nth, _ = strconv.Atoi(m[1])
+				if r.Nth == 0 || r.Nth > 5 || r.Nth < -1 {
+					return nil, ErrInvalidRule
+				}
+			}
+		default:
+			return nil, ErrInvalidRule
+		}
+	}
+	if !hasFrequency || (r.Frequency == Weekly && r.Nth != 0) || (r.Frequency == Monthly && r.HasDay && r.Nth == 0) {
+		return nil, ErrInvalidRule
+	}
+	return r, nil
+}
+
+// ParseText understands the way people describe a schedule, in English or
+// Spanish, falling back to an RRULE.
+func ParseText(text string) (*Rule, error) {
+	text = accents.Replace(strings.ToLower(strings.Join(strings.Fields(text), " ")))
+	switch text {
+	case "weekly", "every week", "semanal", "cada semana", "todas las semanas":
+		return &Rule{Frequency: Weekly, Interval: 1}, nil
+	case "biweekly", "fortnightly", "every other week", "quincenal":
+		return &Rule{Frequency: Weekly, Interval: 2}, nil
+	case "monthly", "every month", "mensual", "cada mes", "todos los meses":
+		return &Rule{Frequency: Monthly, Interval: 1}, nil
+	}
+	if m := everyN.FindStringSubmatch(text); m != nil {
+		interval := 2
+		if m[1] != "two" && m[1] != "dos" {
+			interval, _ = strconv.Atoi(m[1])
+		}
+		if interval < 1 {
+			return nil, ErrInvalidRule
+		}
+		return &Rule{Frequency: Weekly, Interval: interval}, nil
+	}
+	if m := nthWeekday.FindStringSubmatch(text); m != nil {
+		nth, found := ordinals[m[1]]
+		weekday, isWeekday := dates.Weekday(m[2])
+		if found && isWeekday {
+			return &Rule{Frequency: Monthly, Interval: 1, Weekday: weekday, HasDay: true, Nth: nth}, nil
+		}
+	}
+	return Parse(text)
+}
+
+func (r *Rule) String() string {
+	parts := []string{"FREQ=WEEKLY"}
+	if r.Frequency == Monthly {
+		parts[0] = "FREQ=MONTHLY"
+	}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if r.HasDay {
+		if r.Nth != 0 {
+			parts = append(parts, fmt.Sprintf("BYDAY=%d%s", r.Nth, dayCodes[r.Weekday]))
+		} else {
+			parts = append(parts, "BYDAY="+dayCodes[r.Weekday])
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+func (r *Rule) Describe() string {
+	unit := "week"
+	if r.Frequency == Monthly {
+		unit = "month"
+	}
+	every := "every " + unit
+	if r.Interval == 2 {
+		every = "every other " + unit
+	} else if r.Interval > 2 {
+		every = fmt.Sprintf("every %d %ss", r.Interval, unit)
+	}
+	if !r.HasDay {
+		return "Repeats " + every
+	}
+	if r.Nth == 0 {
+		return fmt.Sprintf("Repeats %s on %s", every, r.Weekday)
+	}
+	nth := map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 5: "5th", -1: "last"}[r.Nth]
+	return fmt.Sprintf("Repeats on the %s %s of %s", nth, r.Weekday, every)
+}
+
+// Next returns the first occurrence after the given one, keeping its wall
+// clock time in location so meetings don't move across daylight saving time
+// changes.
+func (r *Rule) Next(occurrence time.Time, location *time.Location) time.Time {
+	occurrence = occurrence.In(location)
+	hour, minute, second := occurrence.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, location)
+	}
+	year, month, day := occurrence.Date()
+	if r.Frequency == Weekly {
+		days := 7 * r.Interval
+		if r.HasDay {
+			days = (int(r.Weekday)-int(occurrence.Weekday())+6)%7 + 1 + 7*(r.Interval-1)
+		}
+		return at(year, month, day+days)
+	}
+	for i := 1; ; i++ {
+		first := time.Date(year, month+time.Month(i*r.Interval), 1, 0, 0, 0, 0, location)
+		candidateDay := day
+		if r.HasDay {
+			candidateDay = nthWeekdayOfMonth(first, r.Weekday, r.Nth)
+		}
+		candidate := at(first.Year(), first.Month(), candidateDay)
+		// months without that day, like the 31st or a 5th friday, are skipped
+		if candidate.Month() == first.Month() {
+			return candidate
+		}
+	}
+}
+
+func nthWeekdayOfMonth(first time.Time, weekday time.Weekday, nth int) int {
+	if nth < 0 {
+		last := first.AddDate(0, 1, -1)
+		return last.Day() - (int(last.Weekday())-int(weekday)+7)%7
+	}
+	return 1 + (int(weekday)-int(first.Weekday())+7)%7 + 7*(nth-1)
+}
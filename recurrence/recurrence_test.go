@@ -0,0 +1,105 @@
+package recurrence
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+	for input, expected := range map[string]string{
+		"FREQ=WEEKLY":                        "FREQ=WEEKLY",
+		"RRULE:FREQ=WEEKLY;INTERVAL=2":       "FREQ=WEEKLY;INTERVAL=2",
+		"freq=weekly;byday=th":               "FREQ=WEEKLY;BYDAY=TH",
+		"FREQ=MONTHLY;BYDAY=2TH":             "FREQ=MONTHLY;BYDAY=2TH",
+		"FREQ=MONTHLY;INTERVAL=3;BYDAY=-1FR": "FREQ=MONTHLY;INTERVAL=3;BYDAY=-1FR",
+		"FREQ=MONTHLY":                       "FREQ=MONTHLY",
+		"FREQ=DAILY":                         "",
+		"INTERVAL=2":                         "",
+		"FREQ=WEEKLY;INTERVAL=0":             "",
+		"FREQ=WEEKLY;BYDAY=2TH":              "",
+		"FREQ=MONTHLY;BYDAY=TH":              "",
+		"FREQ=MONTHLY;BYDAY=6TH":             "",
+		"FREQ=WEEKLY;COUNT=3":                "",
+	} {
+		rule, err := Parse(input)
+		if expected == "" {
+			assert.Equal(err, ErrInvalidRule, input)
+			continue
+		}
+		if assert.NoError(err, input) {
+			assert.Equal(rule.String(), expected, input)
+		}
+	}
+}
+
+func TestParseText(t *testing.T) {
+	assert := assert.New(t)
+	for input, expected := range map[string]string{
+		"weekly":                             "FREQ=WEEKLY",
+		"Semanal":                            "FREQ=WEEKLY",
+		"biweekly":                           "FREQ=WEEKLY;INTERVAL=2",
+		"every 3 weeks":                      "FREQ=WEEKLY;INTERVAL=3",
+		"cada dos semanas":                   "FREQ=WEEKLY;INTERVAL=2",
+		"monthly":                            "FREQ=MONTHLY",
+		"2nd thursday":                       "FREQ=MONTHLY;BYDAY=2TH",
+		"the second Thursday of every month": "FREQ=MONTHLY;BYDAY=2TH",
+		"last friday of the month":           "FREQ=MONTHLY;BYDAY=-1FR",
+		"segundo jueves de cada mes":         "FREQ=MONTHLY;BYDAY=2TH",
+		"último viernes del mes":             "FREQ=MONTHLY;BYDAY=-1FR",
+		"FREQ=WEEKLY;BYDAY=SA":               "FREQ=WEEKLY;BYDAY=SA",
+		"sometimes":                          "",
+		"2nd someday":                        "",
+	} {
+		rule, err := ParseText(input)
+		if expected == "" {
+			assert.Equal(err, ErrInvalidRule, input)
+			continue
+		}
+		if assert.NoError(err, input) {
+			assert.Equal(rule.String(), expected, input)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	assert := assert.New(t)
+	for input, expected := range map[string]string{
+		"FREQ=WEEKLY":                        "Repeats every week",
+		"FREQ=WEEKLY;INTERVAL=2":             "Repeats every other week",
+		"FREQ=WEEKLY;INTERVAL=3;BYDAY=SA":    "Repeats every 3 weeks on Saturday",
+		"FREQ=MONTHLY;BYDAY=2TH":             "Repeats on the 2nd Thursday of every month",
+		"FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR": "Repeats on the last Friday of every other month",
+	} {
+		rule, err := Parse(input)
+		assert.NoError(err)
+		assert.Equal(rule.Describe(), expected)
+	}
+}
+
+func TestNext(t *testing.T) {
+	assert := assert.New(t)
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	assert.NoError(err)
+
+	next := func(rule string, occurrence time.Time) time.Time {
+		r, err := Parse(rule)
+		assert.NoError(err)
+		return r.Next(occurrence, madrid)
+	}
+	// Thursday 2019-03-28 20:00 CET, before clocks go forward
+	thursday := time.Date(2019, 3, 28, 20, 0, 0, 0, madrid)
+	assert.Equal(next("FREQ=WEEKLY", thursday), time.Date(2019, 4, 4, 20, 0, 0, 0, madrid))
+	assert.Equal(next("FREQ=WEEKLY", thursday).Sub(thursday), 7*24*time.Hour-time.Hour)
+	assert.Equal(next("FREQ=WEEKLY;INTERVAL=2", thursday), time.Date(2019, 4, 11, 20, 0, 0, 0, madrid))
+	assert.Equal(next("FREQ=WEEKLY;BYDAY=SA", thursday), time.Date(2019, 3, 30, 20, 0, 0, 0, madrid))
+	assert.Equal(next("FREQ=WEEKLY;INTERVAL=2;BYDAY=TU", thursday), time.Date(2019, 4, 9, 20, 0, 0, 0, madrid))
+	assert.Equal(next("FREQ=MONTHLY;BYDAY=2TH", thursday), time.Date(2019, 4, 11, 20, 0, 0, 0, madrid))
+	assert.Equal(next("FREQ=MONTHLY;BYDAY=-1FR", thursday), time.Date(2019, 4, 26, 20, 0, 0, 0, madrid))
+	assert.Equal(next("FREQ=MONTHLY", thursday), time.Date(2019, 4, 28, 20, 0, 0, 0, madrid))
+	// May has five Thursdays, June does not
+	assert.Equal(next("FREQ=MONTHLY;BYDAY=5TH", thursday), time.Date(2019, 5, 30, 20, 0, 0, 0, madrid))
+	assert.Equal(next("FREQ=MONTHLY;BYDAY=5TH", time.Date(2019, 5, 30, 20, 0, 0, 0, madrid)), time.Date(2019, 8, 29, 20, 0, 0, 0, madrid))
+	assert.Equal(next("FREQ=MONTHLY", time.Date(2019, 1, 31, 20, 0, 0, 0, madrid)), time.Date(2019, 3, 31, 20, 0, 0, 0, madrid))
+}
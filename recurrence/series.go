@@ -0,0 +1,65 @@
+package recurrence
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	ftime "github.com/seppo0010/boardgamesorganizer/time"
+	"log"
+	"time"
+)
+
+// Series creates the next occurrence of a recurring meeting once the current
+// one is closed or cancelled, with the same location, capacity and rule.
+type Series struct {
+	Meetings  *meetings.Factory
+	Location  func(groupID string) *time.Location
+	OnCreated func(groupID string)
+
+	timeFactory ftime.Factory
+}
+
+func NewSeries(mf *meetings.Factory) *Series {
+	return &Series{Meetings: mf, timeFactory: ftime.NewReal()}
+}
+
+func (s *Series) SetTimeFactory(tf ftime.Factory) {
+	s.timeFactory = tf
+}
+
+// Continue is meant to be used as meetings.Factory.OnClosed.
+func (s *Series) Continue(groupID string, meeting *meetings.Meeting) {
+	if meeting.Recurrence == "" {
+		return
+	}
+	rule, err := Parse(meeting.Recurrence)
+	if err != nil {
+		log.Printf("failed to parse recurrence %q: %#v", meeting.Recurrence, err)
+		return
+	}
+	location := time.UTC
+	if s.Location != nil {
+		location = s.Location(groupID)
+	}
+	now := s.timeFactory.Now()
+	next := rule.Next(meeting.Time, location)
+	for !next.After(now) {
+		next = rule.Next(next, location)
+	}
+	err = s.Meetings.CreateMeeting(groupID, &meetings.Meeting{
+		Time:       next.In(time.UTC),
+		Location:   meeting.Location,
+		Capacity:   meeting.Capacity,
+		Recurrence: rule.String(),
+		Duration:   meeting.Duration,
+		RSVPCutoff: meeting.RSVPCutoff,
+		MaxGuests:  meeting.MaxGuests,
+		HostID:     meeting.HostID,
+		LocationID: meeting.LocationID,
+	})
+	if err != nil {
+		log.Printf("failed to create next meeting: %#v", err)
+		return
+	}
+	if s.OnCreated != nil {
+		s.OnCreated(groupID)
+	}
+}
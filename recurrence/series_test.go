@@ -0,0 +1,74 @@
+package recurrence
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	ftime "github.com/seppo0010/boardgamesorganizer/time"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSeries(t *testing.T) {
+	assert := assert.New(t)
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	assert.NoError(err)
+	mf := meetings.NewMemory()
+	tf := &ftime.Fake{CurrentNow: time.Date(2019, 3, 20, 12, 0, 0, 0, time.UTC)}
+	mf.SetTimeFactory(tf)
+	s := NewSeries(mf)
+	s.SetTimeFactory(tf)
+	s.Location = func(groupID string) *time.Location { return madrid }
+	created := []string{}
+	s.OnCreated = func(groupID string) {
+		created = append(created, groupID)
+	}
+	mf.OnClosed = s.Continue
+	groupID := "ashf"
+
+	current := func() *meetings.Meeting {
+		meeting, err := mf.GetMeeting(groupID)
+		assert.NoError(err)
+		return meeting
+	}
+
+	assert.NoError(mf.CreateMeeting(groupID, &meetings.Meeting{
+		Time:       time.Date(2019, 3, 21, 20, 0, 0, 0, madrid),
+		Location:   "Home",
+		Capacity:   6,
+		Recurrence: "FREQ=WEEKLY",
+	}))
+
+	// closing lazily after it starts creates the next one
+	tf.CurrentNow = time.Date(2019, 3, 21, 21, 0, 0, 0, time.UTC)
+	next := current()
+	assert.True(next.Time.Equal(time.Date(2019, 3, 28, 20, 0, 0, 0, madrid)))
+	assert.Equal(next.Location, "Home")
+	assert.Equal(next.Capacity, 6)
+	assert.Equal(next.Recurrence, "FREQ=WEEKLY")
+	assert.Equal(created, []string{groupID})
+
+	// keeps the wall clock time across the daylight saving time change
+	assert.NoError(mf.CloseMeeting(groupID))
+	assert.True(current().Time.Equal(time.Date(2019, 4, 4, 20, 0, 0, 0, madrid)))
+
+	// skipping an occurrence
+	assert.NoError(mf.CancelMeeting(groupID))
+	assert.True(current().Time.Equal(time.Date(2019, 4, 11, 20, 0, 0, 0, madrid)))
+
+	// occurrences missed while nobody looked are not created in the past
+	tf.CurrentNow = time.Date(2019, 4, 26, 12, 0, 0, 0, time.UTC)
+	assert.True(current().Time.Equal(time.Date(2019, 5, 2, 20, 0, 0, 0, madrid)))
+	assert.Equal(len(created), 4)
+
+	// ending the series
+	assert.NoError(mf.SetMeetingRecurrence(groupID, ""))
+	assert.NoError(mf.CloseMeeting(groupID))
+	_, err = mf.GetMeeting(groupID)
+	assert.Equal(err, meetings.NoActiveMeeting)
+	assert.Equal(len(created), 4)
+
+	all, err := mf.GetMeetings(groupID)
+	assert.NoError(err)
+	assert.Equal(len(all), 5)
+	assert.True(all[2].Cancelled)
+}
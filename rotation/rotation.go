@@ -0,0 +1,40 @@
+package rotation
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/locations"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"sort"
+	"time"
+)
+
+// Host is a location in the rotation and when its host last hosted.
+type Host struct {
+	Location *locations.Location
+	// LastHosted is zero when they never hosted
+	LastHosted time.Time
+}
+
+// Order lists the group's locations in the rotation, starting by those
+// whose host hosted least recently according to the held meetings of
+// history. Hosts who never hosted go first, in the order they joined.
+func Order(list []*locations.Location, history []*meetings.Meeting) []*Host {
+	lastHosted := map[string]time.Time{}
+	for _, meeting := range history {
+		if !meeting.Closed || meeting.Cancelled || meeting.HostID == "" {
+			continue
+		}
+		if meeting.Time.After(lastHosted[meeting.HostID]) {
+			lastHosted[meeting.HostID] = meeting.Time
+		}
+	}
+	hosts := []*Host{}
+	for _, location := range list {
+		if location.Rotation && location.HostID != "" {
+			hosts = append(hosts, &Host{Location: location, LastHosted: lastHosted[location.HostID]})
+		}
+	}
+	sort.SliceStable(hosts, func(i, j int) bool {
+		return hosts[i].LastHosted.Before(hosts[j].LastHosted)
+	})
+	return hosts
+}
@@ -0,0 +1,35 @@
+package rotation
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/locations"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestOrder(t *testing.T) {
+	assert := assert.New(t)
+	alice := &locations.Location{ID: "1", Name: "Alice's", HostID: "a", Rotation: true}
+	bob := &locations.Location{ID: "2", Name: "Bob's", HostID: "b", Rotation: true}
+	carol := &locations.Location{ID: "3", Name: "Carol's", HostID: "c", Rotation: true}
+	dave := &locations.Location{ID: "4", Name: "Dave's", HostID: "d", Rotation: true}
+	cafe := &locations.Location{ID: "5", Name: "Cafe"}
+	march := time.Date(2019, 3, 1, 20, 0, 0, 0, time.UTC)
+	history := []*meetings.Meeting{
+		&meetings.Meeting{Time: march, HostID: "a", Closed: true},
+		&meetings.Meeting{Time: march.AddDate(0, 1, 0), HostID: "b", Closed: true},
+		&meetings.Meeting{Time: march.AddDate(0, 2, 0), HostID: "a", Closed: true},
+		// cancelled and upcoming meetings do not count as hosting
+		&meetings.Meeting{Time: march.AddDate(0, 3, 0), HostID: "c", Closed: true, Cancelled: true},
+		&meetings.Meeting{Time: march.AddDate(0, 4, 0), HostID: "d"},
+	}
+	hosts := Order([]*locations.Location{alice, bob, carol, cafe, dave}, history)
+	assert.Equal(hosts, []*Host{
+		&Host{Location: carol},
+		&Host{Location: dave},
+		&Host{Location: bob, LastHosted: march.AddDate(0, 1, 0)},
+		&Host{Location: alice, LastHosted: march.AddDate(0, 2, 0)},
+	})
+	assert.Equal(len(Order([]*locations.Location{cafe}, history)), 0)
+}
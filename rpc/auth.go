@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net"
+)
+
+var ErrInsecureListener = errors.New("grpc needs a token and TLS to listen on a non-loopback address")
+var ErrInsecureBackend = errors.New("grpc needs TLS to reach a non-loopback backend")
+
+const authorizationKey = "authorization"
+
+// IsLoopback tells whether addr only accepts local connections. An empty
+// host listens on every interface, so it is not.
+func IsLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ServerOptions checks every call carries token, and serves over TLS when a
+// certificate is given. Both can only be left out on a loopback address.
+func ServerOptions(addr, token, certFile, keyFile string) ([]grpc.ServerOption, error) {
+	if (token == "" || certFile == "") && !IsLoopback(addr) {
+		return nil, ErrInsecureListener
+	}
+	opts := []grpc.ServerOption{}
+	if certFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	if token != "" {
+		opts = append(opts,
+			grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				if err := authorize(ctx, token); err != nil {
+					return nil, err
+				}
+				return handler(ctx, req)
+			}),
+			grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				if err := authorize(ss.Context(), token); err != nil {
+					return err
+				}
+				return handler(srv, ss)
+			}),
+		)
+	}
+	return opts, nil
+}
+
+func authorize(ctx context.Context, token string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(authorizationKey) {
+		if subtle.ConstantTimeCompare([]byte(value), []byte("Bearer "+token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid token")
+}
+
+// DialOptions sends token on every call, over TLS trusting caFile when it is
+// given. Only a loopback backend can be reached without TLS.
+func DialOptions(addr, token, caFile string) ([]grpc.DialOption, error) {
+	opts := []grpc.DialOption{}
+	if caFile != "" {
+		creds, err := credentials.NewClientTLSFromFile(caFile, "")
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	} else if IsLoopback(addr) {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		return nil, ErrInsecureBackend
+	}
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials{token: token, secure: caFile != ""}))
+	}
+	return opts, nil
+}
+
+type tokenCredentials struct {
+	token  string
+	secure bool
+}
+
+func (c tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{authorizationKey: "Bearer " + c.token}, nil
+}
+
+func (c tokenCredentials) RequireTransportSecurity() bool {
+	return c.secure
+}
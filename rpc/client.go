@@ -0,0 +1,305 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"google.golang.org/grpc"
+	"io"
+	"log"
+	"time"
+)
+
+const requestTimeout = 10 * time.Second
+
+type Client struct {
+	conn     *grpc.ClientConn
+	meetings MeetingsClient
+	users    UsersClient
+}
+
+// Dial connects to addr, in plain text only when it is a loopback address
+// unless other options are given.
+func Dial(addr string, opts ...grpc.DialOption) (*Client, error) {
+	if len(opts) == 0 {
+		var err error
+		opts, err = DialOptions(addr, "", "")
+		if err != nil {
+			return nil, err
+		}
+	}
+	conn, err := grpc.Dial(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(conn), nil
+}
+
+func NewClient(conn *grpc.ClientConn) *Client {
+	return &Client{conn: conn, meetings: NewMeetingsClient(conn), users: NewUsersClient(conn)}
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) CreateMeeting(groupID string, meeting *meetings.Meeting) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	m, err := meetingToProto(meeting)
+	if err != nil {
+		return err
+	}
+	response, err := c.meetings.CreateMeeting(ctx, &CreateMeetingRequest{GroupId: groupID, Meeting: m})
+	if err != nil {
+		return fromStatus(err)
+	}
+	meeting.ID = response.GetId()
+	return nil
+}
+
+func (c *Client) DeleteMeeting(groupID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	_, err := c.meetings.DeleteMeeting(ctx, &GroupRequest{GroupId: groupID})
+	return fromStatus(err)
+}
+
+func (c *Client) GetMeeting(groupID string) (*meetings.Meeting, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	m, err := c.meetings.GetMeeting(ctx, &GroupRequest{GroupId: groupID})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return meetingFromProto(m)
+}
+
+func (c *Client) SetMeetingAttendeesData(groupID string, data interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	v, err := json.Marshal(data)
+	if err != nil {
+		log.Print(err)
+		return err
+	}
+	_, err = c.meetings.SetMeetingAttendeesData(ctx, &MeetingAttendeesData{GroupId: groupID, Data: v})
+	return fromStatus(err)
+}
+
+func (c *Client) GetMeetingAttendeesData(groupID string, v interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	data, err := c.meetings.GetMeetingAttendeesData(ctx, &GroupRequest{GroupId: groupID})
+	if err != nil {
+		return fromStatus(err)
+	}
+	if len(data.GetData()) == 0 {
+		return nil
+	}
+	err = json.Unmarshal(data.GetData(), v)
+	if err != nil {
+		log.Print(err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) UserRSVPMeeting(groupID string, attendee *meetings.Attendee) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	_, err := c.meetings.UserRSVPMeeting(ctx, &RSVPRequest{GroupId: groupID, Attendee: attendeeToProto(attendee)})
+	return fromStatus(err)
+}
+
+func (c *Client) GetMeetingAttendees(groupID string) ([]*meetings.Attendee, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	response, err := c.meetings.GetMeetingAttendees(ctx, &GroupRequest{GroupId: groupID})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return attendeesFromProto(response), nil
+}
+
+func (c *Client) GetMeetingAttendeesByID(meetingID string) ([]*meetings.Attendee, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	response, err := c.meetings.GetMeetingAttendeesByID(ctx, &IDRequest{Id: meetingID})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return attendeesFromProto(response), nil
+}
+
+func (c *Client) CloseMeeting(groupID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	_, err := c.meetings.CloseMeeting(ctx, &GroupRequest{GroupId: groupID})
+	return fromStatus(err)
+}
+
+func (c *Client) CancelMeeting(groupID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	_, err := c.meetings.CancelMeeting(ctx, &GroupRequest{GroupId: groupID})
+	return fromStatus(err)
+}
+
+func (c *Client) GetMeetings(groupID string) ([]*meetings.Meeting, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	response, err := c.meetings.GetMeetings(ctx, &GroupRequest{GroupId: groupID})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	retval := make([]*meetings.Meeting, len(response.GetMeetings()))
+	for i, meeting := range response.GetMeetings() {
+		retval[i], err = meetingFromProto(meeting)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return retval, nil
+}
+
+func (c *Client) GetActiveMeetings() (map[string]*meetings.Meeting, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	response, err := c.meetings.GetActiveMeetings(ctx, &Empty{})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	retval := map[string]*meetings.Meeting{}
+	for groupID, meeting := range response.GetMeetings() {
+		retval[groupID], err = meetingFromProto(meeting)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return retval, nil
+}
+
+func (c *Client) SetMeetingRecurrence(groupID string, recurrence string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	_, err := c.meetings.SetMeetingRecurrence(ctx, &MeetingRecurrenceRequest{GroupId: groupID, Recurrence: recurrence})
+	return fromStatus(err)
+}
+
+func (c *Client) SetMeetingRSVPCutoff(groupID string, cutoff time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	_, err := c.meetings.SetMeetingRSVPCutoff(ctx, &MeetingRSVPCutoffRequest{GroupId: groupID, RsvpCutoff: ptypes.DurationProto(cutoff)})
+	return fromStatus(err)
+}
+
+func (c *Client) SetMeetingMaxGuests(groupID string, maxGuests int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	_, err := c.meetings.SetMeetingMaxGuests(ctx, &MeetingMaxGuestsRequest{GroupId: groupID, MaxGuests: int32(maxGuests)})
+	return fromStatus(err)
+}
+
+func (c *Client) SetAttendeeGuests(groupID string, userID string, guests []*meetings.Guest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	_, err := c.meetings.SetAttendeeGuests(ctx, &AttendeeGuestsRequest{GroupId: groupID, UserId: userID, Guests: guestsToProto(guests)})
+	return fromStatus(err)
+}
+
+func (c *Client) CheckIn(meetingID string, userID string, checkedIn bool, guests int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	_, err := c.meetings.CheckIn(ctx, &CheckInRequest{MeetingId: meetingID, UserId: userID, CheckedIn: checkedIn, Guests: int32(guests)})
+	return fromStatus(err)
+}
+
+func (c *Client) WatchRSVPs(ctx context.Context, groupID string) (<-chan *meetings.Attendee, error) {
+	stream, err := c.meetings.WatchRSVPs(ctx, &GroupRequest{GroupId: groupID})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	updates := make(chan *meetings.Attendee)
+	go func() {
+		defer close(updates)
+		for {
+			update, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					log.Printf("failed to receive rsvp update: %#v", err)
+				}
+				return
+			}
+			select {
+			case updates <- attendeeFromProto(update.GetAttendee()):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return updates, nil
+}
+
+func (c *Client) GetOrCreateUser(user *users.ExternalUser) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	response, err := c.users.GetOrCreateUser(ctx, userToProto(user))
+	if err != nil {
+		return "", fromStatus(err)
+	}
+	return response.GetId(), nil
+}
+
+func (c *Client) GetExternalUser(userID string) (*users.ExternalUser, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	user, err := c.users.GetExternalUser(ctx, &IDRequest{Id: userID})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return userFromProto(user), nil
+}
+
+func (c *Client) GetOrCreateGroup(group *users.ExternalGroup) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	response, err := c.users.GetOrCreateGroup(ctx, groupToProto(group))
+	if err != nil {
+		return "", fromStatus(err)
+	}
+	return response.GetId(), nil
+}
+
+func (c *Client) GetExternalGroup(groupID string) (*users.ExternalGroup, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	group, err := c.users.GetExternalGroup(ctx, &IDRequest{Id: groupID})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return groupFromProto(group), nil
+}
+
+func (c *Client) GetUsers(userIDs []string) (map[string]*users.ExternalUser, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	response, err := c.users.GetUsers(ctx, &UsersRequest{UserIds: userIDs})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	retval := map[string]*users.ExternalUser{}
+	for id, user := range response.GetUsers() {
+		retval[id] = userFromProto(user)
+	}
+	return retval, nil
+}
+
+func (c *Client) SetGroupTimezone(groupID string, timezone string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	_, err := c.users.SetGroupTimezone(ctx, &GroupTimezoneRequest{GroupId: groupID, Timezone: timezone})
+	return fromStatus(err)
+}
@@ -0,0 +1,2259 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: organizer.proto
+
+package rpc
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Empty struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Empty) Reset()         { *m = Empty{} }
+func (m *Empty) String() string { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()    {}
+func (*Empty) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{0}
+}
+
+func (m *Empty) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Empty.Unmarshal(m, b)
+}
+func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+}
+func (m *Empty) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Empty.Merge(m, src)
+}
+func (m *Empty) XXX_Size() int {
+	return xxx_messageInfo_Empty.Size(m)
+}
+func (m *Empty) XXX_DiscardUnknown() {
+	xxx_messageInfo_Empty.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Empty proto.InternalMessageInfo
+
+type Meeting struct {
+	Time                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Location             string               `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Capacity             int32                `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Closed               bool                 `protobuf:"varint,4,opt,name=closed,proto3" json:"closed,omitempty"`
+	Id                   string               `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
+	Cancelled            bool                 `protobuf:"varint,6,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	Recurrence           string               `protobuf:"bytes,7,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	Duration             *duration.Duration   `protobuf:"bytes,8,opt,name=duration,proto3" json:"duration,omitempty"`
+	RsvpCutoff           *duration.Duration   `protobuf:"bytes,9,opt,name=rsvp_cutoff,json=rsvpCutoff,proto3" json:"rsvp_cutoff,omitempty"`
+	MaxGuests            int32                `protobuf:"varint,10,opt,name=max_guests,json=maxGuests,proto3" json:"max_guests,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	HostId               string               `protobuf:"bytes,12,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	LocationId           string               `protobuf:"bytes,13,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Meeting) Reset()         { *m = Meeting{} }
+func (m *Meeting) String() string { return proto.CompactTextString(m) }
+func (*Meeting) ProtoMessage()    {}
+func (*Meeting) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{1}
+}
+
+func (m *Meeting) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Meeting.Unmarshal(m, b)
+}
+func (m *Meeting) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Meeting.Marshal(b, m, deterministic)
+}
+func (m *Meeting) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Meeting.Merge(m, src)
+}
+func (m *Meeting) XXX_Size() int {
+	return xxx_messageInfo_Meeting.Size(m)
+}
+func (m *Meeting) XXX_DiscardUnknown() {
+	xxx_messageInfo_Meeting.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Meeting proto.InternalMessageInfo
+
+func (m *Meeting) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *Meeting) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *Meeting) GetCapacity() int32 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *Meeting) GetClosed() bool {
+	if m != nil {
+		return m.Closed
+	}
+	return false
+}
+
+func (m *Meeting) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Meeting) GetCancelled() bool {
+	if m != nil {
+		return m.Cancelled
+	}
+	return false
+}
+
+func (m *Meeting) GetRecurrence() string {
+	if m != nil {
+		return m.Recurrence
+	}
+	return ""
+}
+
+func (m *Meeting) GetDuration() *duration.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+func (m *Meeting) GetRsvpCutoff() *duration.Duration {
+	if m != nil {
+		return m.RsvpCutoff
+	}
+	return nil
+}
+
+func (m *Meeting) GetMaxGuests() int32 {
+	if m != nil {
+		return m.MaxGuests
+	}
+	return 0
+}
+
+func (m *Meeting) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Meeting) GetHostId() string {
+	if m != nil {
+		return m.HostId
+	}
+	return ""
+}
+
+func (m *Meeting) GetLocationId() string {
+	if m != nil {
+		return m.LocationId
+	}
+	return ""
+}
+
+type Attendee struct {
+	UserId               string               `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount               int32                `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Guests               []*Guest             `protobuf:"bytes,3,rep,name=guests,proto3" json:"guests,omitempty"`
+	Maybe                bool                 `protobuf:"varint,4,opt,name=maybe,proto3" json:"maybe,omitempty"`
+	RsvpTime             *timestamp.Timestamp `protobuf:"bytes,5,opt,name=rsvp_time,json=rsvpTime,proto3" json:"rsvp_time,omitempty"`
+	CheckedIn            bool                 `protobuf:"varint,6,opt,name=checked_in,json=checkedIn,proto3" json:"checked_in,omitempty"`
+	GuestsCheckedIn      int32                `protobuf:"varint,7,opt,name=guests_checked_in,json=guestsCheckedIn,proto3" json:"guests_checked_in,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Attendee) Reset()         { *m = Attendee{} }
+func (m *Attendee) String() string { return proto.CompactTextString(m) }
+func (*Attendee) ProtoMessage()    {}
+func (*Attendee) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{2}
+}
+
+func (m *Attendee) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Attendee.Unmarshal(m, b)
+}
+func (m *Attendee) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Attendee.Marshal(b, m, deterministic)
+}
+func (m *Attendee) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attendee.Merge(m, src)
+}
+func (m *Attendee) XXX_Size() int {
+	return xxx_messageInfo_Attendee.Size(m)
+}
+func (m *Attendee) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attendee.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attendee proto.InternalMessageInfo
+
+func (m *Attendee) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *Attendee) GetAmount() int32 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Attendee) GetGuests() []*Guest {
+	if m != nil {
+		return m.Guests
+	}
+	return nil
+}
+
+func (m *Attendee) GetMaybe() bool {
+	if m != nil {
+		return m.Maybe
+	}
+	return false
+}
+
+func (m *Attendee) GetRsvpTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.RsvpTime
+	}
+	return nil
+}
+
+func (m *Attendee) GetCheckedIn() bool {
+	if m != nil {
+		return m.CheckedIn
+	}
+	return false
+}
+
+func (m *Attendee) GetGuestsCheckedIn() int32 {
+	if m != nil {
+		return m.GuestsCheckedIn
+	}
+	return 0
+}
+
+type Guest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Handle               string   `protobuf:"bytes,2,opt,name=handle,proto3" json:"handle,omitempty"`
+	UserId               string   `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Guest) Reset()         { *m = Guest{} }
+func (m *Guest) String() string { return proto.CompactTextString(m) }
+func (*Guest) ProtoMessage()    {}
+func (*Guest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{3}
+}
+
+func (m *Guest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Guest.Unmarshal(m, b)
+}
+func (m *Guest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Guest.Marshal(b, m, deterministic)
+}
+func (m *Guest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Guest.Merge(m, src)
+}
+func (m *Guest) XXX_Size() int {
+	return xxx_messageInfo_Guest.Size(m)
+}
+func (m *Guest) XXX_DiscardUnknown() {
+	xxx_messageInfo_Guest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Guest proto.InternalMessageInfo
+
+func (m *Guest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Guest) GetHandle() string {
+	if m != nil {
+		return m.Handle
+	}
+	return ""
+}
+
+func (m *Guest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type GroupRequest struct {
+	GroupId              string   `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GroupRequest) Reset()         { *m = GroupRequest{} }
+func (m *GroupRequest) String() string { return proto.CompactTextString(m) }
+func (*GroupRequest) ProtoMessage()    {}
+func (*GroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{4}
+}
+
+func (m *GroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroupRequest.Unmarshal(m, b)
+}
+func (m *GroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroupRequest.Marshal(b, m, deterministic)
+}
+func (m *GroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupRequest.Merge(m, src)
+}
+func (m *GroupRequest) XXX_Size() int {
+	return xxx_messageInfo_GroupRequest.Size(m)
+}
+func (m *GroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupRequest proto.InternalMessageInfo
+
+func (m *GroupRequest) GetGroupId() string {
+	if m != nil {
+		return m.GroupId
+	}
+	return ""
+}
+
+type CreateMeetingRequest struct {
+	GroupId              string   `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Meeting              *Meeting `protobuf:"bytes,2,opt,name=meeting,proto3" json:"meeting,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateMeetingRequest) Reset()         { *m = CreateMeetingRequest{} }
+func (m *CreateMeetingRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateMeetingRequest) ProtoMessage()    {}
+func (*CreateMeetingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{5}
+}
+
+func (m *CreateMeetingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateMeetingRequest.Unmarshal(m, b)
+}
+func (m *CreateMeetingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateMeetingRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateMeetingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateMeetingRequest.Merge(m, src)
+}
+func (m *CreateMeetingRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateMeetingRequest.Size(m)
+}
+func (m *CreateMeetingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateMeetingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateMeetingRequest proto.InternalMessageInfo
+
+func (m *CreateMeetingRequest) GetGroupId() string {
+	if m != nil {
+		return m.GroupId
+	}
+	return ""
+}
+
+func (m *CreateMeetingRequest) GetMeeting() *Meeting {
+	if m != nil {
+		return m.Meeting
+	}
+	return nil
+}
+
+type MeetingAttendeesData struct {
+	GroupId              string   `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MeetingAttendeesData) Reset()         { *m = MeetingAttendeesData{} }
+func (m *MeetingAttendeesData) String() string { return proto.CompactTextString(m) }
+func (*MeetingAttendeesData) ProtoMessage()    {}
+func (*MeetingAttendeesData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{6}
+}
+
+func (m *MeetingAttendeesData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MeetingAttendeesData.Unmarshal(m, b)
+}
+func (m *MeetingAttendeesData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MeetingAttendeesData.Marshal(b, m, deterministic)
+}
+func (m *MeetingAttendeesData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MeetingAttendeesData.Merge(m, src)
+}
+func (m *MeetingAttendeesData) XXX_Size() int {
+	return xxx_messageInfo_MeetingAttendeesData.Size(m)
+}
+func (m *MeetingAttendeesData) XXX_DiscardUnknown() {
+	xxx_messageInfo_MeetingAttendeesData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MeetingAttendeesData proto.InternalMessageInfo
+
+func (m *MeetingAttendeesData) GetGroupId() string {
+	if m != nil {
+		return m.GroupId
+	}
+	return ""
+}
+
+func (m *MeetingAttendeesData) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type RSVPRequest struct {
+	GroupId              string    `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Attendee             *Attendee `protobuf:"bytes,2,opt,name=attendee,proto3" json:"attendee,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *RSVPRequest) Reset()         { *m = RSVPRequest{} }
+func (m *RSVPRequest) String() string { return proto.CompactTextString(m) }
+func (*RSVPRequest) ProtoMessage()    {}
+func (*RSVPRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{7}
+}
+
+func (m *RSVPRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RSVPRequest.Unmarshal(m, b)
+}
+func (m *RSVPRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RSVPRequest.Marshal(b, m, deterministic)
+}
+func (m *RSVPRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RSVPRequest.Merge(m, src)
+}
+func (m *RSVPRequest) XXX_Size() int {
+	return xxx_messageInfo_RSVPRequest.Size(m)
+}
+func (m *RSVPRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RSVPRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RSVPRequest proto.InternalMessageInfo
+
+func (m *RSVPRequest) GetGroupId() string {
+	if m != nil {
+		return m.GroupId
+	}
+	return ""
+}
+
+func (m *RSVPRequest) GetAttendee() *Attendee {
+	if m != nil {
+		return m.Attendee
+	}
+	return nil
+}
+
+type AttendeesResponse struct {
+	Attendees            []*Attendee `protobuf:"bytes,1,rep,name=attendees,proto3" json:"attendees,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *AttendeesResponse) Reset()         { *m = AttendeesResponse{} }
+func (m *AttendeesResponse) String() string { return proto.CompactTextString(m) }
+func (*AttendeesResponse) ProtoMessage()    {}
+func (*AttendeesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{8}
+}
+
+func (m *AttendeesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttendeesResponse.Unmarshal(m, b)
+}
+func (m *AttendeesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttendeesResponse.Marshal(b, m, deterministic)
+}
+func (m *AttendeesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttendeesResponse.Merge(m, src)
+}
+func (m *AttendeesResponse) XXX_Size() int {
+	return xxx_messageInfo_AttendeesResponse.Size(m)
+}
+func (m *AttendeesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttendeesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttendeesResponse proto.InternalMessageInfo
+
+func (m *AttendeesResponse) GetAttendees() []*Attendee {
+	if m != nil {
+		return m.Attendees
+	}
+	return nil
+}
+
+type MeetingsResponse struct {
+	Meetings             []*Meeting `protobuf:"bytes,1,rep,name=meetings,proto3" json:"meetings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *MeetingsResponse) Reset()         { *m = MeetingsResponse{} }
+func (m *MeetingsResponse) String() string { return proto.CompactTextString(m) }
+func (*MeetingsResponse) ProtoMessage()    {}
+func (*MeetingsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{9}
+}
+
+func (m *MeetingsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MeetingsResponse.Unmarshal(m, b)
+}
+func (m *MeetingsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MeetingsResponse.Marshal(b, m, deterministic)
+}
+func (m *MeetingsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MeetingsResponse.Merge(m, src)
+}
+func (m *MeetingsResponse) XXX_Size() int {
+	return xxx_messageInfo_MeetingsResponse.Size(m)
+}
+func (m *MeetingsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MeetingsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MeetingsResponse proto.InternalMessageInfo
+
+func (m *MeetingsResponse) GetMeetings() []*Meeting {
+	if m != nil {
+		return m.Meetings
+	}
+	return nil
+}
+
+type ActiveMeetingsResponse struct {
+	Meetings             map[string]*Meeting `protobuf:"bytes,1,rep,name=meetings,proto3" json:"meetings,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ActiveMeetingsResponse) Reset()         { *m = ActiveMeetingsResponse{} }
+func (m *ActiveMeetingsResponse) String() string { return proto.CompactTextString(m) }
+func (*ActiveMeetingsResponse) ProtoMessage()    {}
+func (*ActiveMeetingsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{10}
+}
+
+func (m *ActiveMeetingsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActiveMeetingsResponse.Unmarshal(m, b)
+}
+func (m *ActiveMeetingsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActiveMeetingsResponse.Marshal(b, m, deterministic)
+}
+func (m *ActiveMeetingsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActiveMeetingsResponse.Merge(m, src)
+}
+func (m *ActiveMeetingsResponse) XXX_Size() int {
+	return xxx_messageInfo_ActiveMeetingsResponse.Size(m)
+}
+func (m *ActiveMeetingsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActiveMeetingsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActiveMeetingsResponse proto.InternalMessageInfo
+
+func (m *ActiveMeetingsResponse) GetMeetings() map[string]*Meeting {
+	if m != nil {
+		return m.Meetings
+	}
+	return nil
+}
+
+type MeetingRecurrenceRequest struct {
+	GroupId              string   `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Recurrence           string   `protobuf:"bytes,2,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MeetingRecurrenceRequest) Reset()         { *m = MeetingRecurrenceRequest{} }
+func (m *MeetingRecurrenceRequest) String() string { return proto.CompactTextString(m) }
+func (*MeetingRecurrenceRequest) ProtoMessage()    {}
+func (*MeetingRecurrenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{11}
+}
+
+func (m *MeetingRecurrenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MeetingRecurrenceRequest.Unmarshal(m, b)
+}
+func (m *MeetingRecurrenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MeetingRecurrenceRequest.Marshal(b, m, deterministic)
+}
+func (m *MeetingRecurrenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MeetingRecurrenceRequest.Merge(m, src)
+}
+func (m *MeetingRecurrenceRequest) XXX_Size() int {
+	return xxx_messageInfo_MeetingRecurrenceRequest.Size(m)
+}
+func (m *MeetingRecurrenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MeetingRecurrenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MeetingRecurrenceRequest proto.InternalMessageInfo
+
+func (m *MeetingRecurrenceRequest) GetGroupId() string {
+	if m != nil {
+		return m.GroupId
+	}
+	return ""
+}
+
+func (m *MeetingRecurrenceRequest) GetRecurrence() string {
+	if m != nil {
+		return m.Recurrence
+	}
+	return ""
+}
+
+type MeetingRSVPCutoffRequest struct {
+	GroupId              string             `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	RsvpCutoff           *duration.Duration `protobuf:"bytes,2,opt,name=rsvp_cutoff,json=rsvpCutoff,proto3" json:"rsvp_cutoff,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *MeetingRSVPCutoffRequest) Reset()         { *m = MeetingRSVPCutoffRequest{} }
+func (m *MeetingRSVPCutoffRequest) String() string { return proto.CompactTextString(m) }
+func (*MeetingRSVPCutoffRequest) ProtoMessage()    {}
+func (*MeetingRSVPCutoffRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{12}
+}
+
+func (m *MeetingRSVPCutoffRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MeetingRSVPCutoffRequest.Unmarshal(m, b)
+}
+func (m *MeetingRSVPCutoffRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MeetingRSVPCutoffRequest.Marshal(b, m, deterministic)
+}
+func (m *MeetingRSVPCutoffRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MeetingRSVPCutoffRequest.Merge(m, src)
+}
+func (m *MeetingRSVPCutoffRequest) XXX_Size() int {
+	return xxx_messageInfo_MeetingRSVPCutoffRequest.Size(m)
+}
+func (m *MeetingRSVPCutoffRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MeetingRSVPCutoffRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MeetingRSVPCutoffRequest proto.InternalMessageInfo
+
+func (m *MeetingRSVPCutoffRequest) GetGroupId() string {
+	if m != nil {
+		return m.GroupId
+	}
+	return ""
+}
+
+func (m *MeetingRSVPCutoffRequest) GetRsvpCutoff() *duration.Duration {
+	if m != nil {
+		return m.RsvpCutoff
+	}
+	return nil
+}
+
+type MeetingMaxGuestsRequest struct {
+	GroupId              string   `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	MaxGuests            int32    `protobuf:"varint,2,opt,name=max_guests,json=maxGuests,proto3" json:"max_guests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MeetingMaxGuestsRequest) Reset()         { *m = MeetingMaxGuestsRequest{} }
+func (m *MeetingMaxGuestsRequest) String() string { return proto.CompactTextString(m) }
+func (*MeetingMaxGuestsRequest) ProtoMessage()    {}
+func (*MeetingMaxGuestsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{13}
+}
+
+func (m *MeetingMaxGuestsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MeetingMaxGuestsRequest.Unmarshal(m, b)
+}
+func (m *MeetingMaxGuestsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MeetingMaxGuestsRequest.Marshal(b, m, deterministic)
+}
+func (m *MeetingMaxGuestsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MeetingMaxGuestsRequest.Merge(m, src)
+}
+func (m *MeetingMaxGuestsRequest) XXX_Size() int {
+	return xxx_messageInfo_MeetingMaxGuestsRequest.Size(m)
+}
+func (m *MeetingMaxGuestsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MeetingMaxGuestsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MeetingMaxGuestsRequest proto.InternalMessageInfo
+
+func (m *MeetingMaxGuestsRequest) GetGroupId() string {
+	if m != nil {
+		return m.GroupId
+	}
+	return ""
+}
+
+func (m *MeetingMaxGuestsRequest) GetMaxGuests() int32 {
+	if m != nil {
+		return m.MaxGuests
+	}
+	return 0
+}
+
+type AttendeeGuestsRequest struct {
+	GroupId              string   `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	UserId               string   `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Guests               []*Guest `protobuf:"bytes,3,rep,name=guests,proto3" json:"guests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AttendeeGuestsRequest) Reset()         { *m = AttendeeGuestsRequest{} }
+func (m *AttendeeGuestsRequest) String() string { return proto.CompactTextString(m) }
+func (*AttendeeGuestsRequest) ProtoMessage()    {}
+func (*AttendeeGuestsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{14}
+}
+
+func (m *AttendeeGuestsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttendeeGuestsRequest.Unmarshal(m, b)
+}
+func (m *AttendeeGuestsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttendeeGuestsRequest.Marshal(b, m, deterministic)
+}
+func (m *AttendeeGuestsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttendeeGuestsRequest.Merge(m, src)
+}
+func (m *AttendeeGuestsRequest) XXX_Size() int {
+	return xxx_messageInfo_AttendeeGuestsRequest.Size(m)
+}
+func (m *AttendeeGuestsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttendeeGuestsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttendeeGuestsRequest proto.InternalMessageInfo
+
+func (m *AttendeeGuestsRequest) GetGroupId() string {
+	if m != nil {
+		return m.GroupId
+	}
+	return ""
+}
+
+func (m *AttendeeGuestsRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *AttendeeGuestsRequest) GetGuests() []*Guest {
+	if m != nil {
+		return m.Guests
+	}
+	return nil
+}
+
+type CheckInRequest struct {
+	MeetingId            string   `protobuf:"bytes,1,opt,name=meeting_id,json=meetingId,proto3" json:"meeting_id,omitempty"`
+	UserId               string   `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CheckedIn            bool     `protobuf:"varint,3,opt,name=checked_in,json=checkedIn,proto3" json:"checked_in,omitempty"`
+	Guests               int32    `protobuf:"varint,4,opt,name=guests,proto3" json:"guests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckInRequest) Reset()         { *m = CheckInRequest{} }
+func (m *CheckInRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckInRequest) ProtoMessage()    {}
+func (*CheckInRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{15}
+}
+
+func (m *CheckInRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckInRequest.Unmarshal(m, b)
+}
+func (m *CheckInRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckInRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckInRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckInRequest.Merge(m, src)
+}
+func (m *CheckInRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckInRequest.Size(m)
+}
+func (m *CheckInRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckInRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckInRequest proto.InternalMessageInfo
+
+func (m *CheckInRequest) GetMeetingId() string {
+	if m != nil {
+		return m.MeetingId
+	}
+	return ""
+}
+
+func (m *CheckInRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *CheckInRequest) GetCheckedIn() bool {
+	if m != nil {
+		return m.CheckedIn
+	}
+	return false
+}
+
+func (m *CheckInRequest) GetGuests() int32 {
+	if m != nil {
+		return m.Guests
+	}
+	return 0
+}
+
+type RSVPUpdate struct {
+	GroupId              string    `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Attendee             *Attendee `protobuf:"bytes,2,opt,name=attendee,proto3" json:"attendee,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *RSVPUpdate) Reset()         { *m = RSVPUpdate{} }
+func (m *RSVPUpdate) String() string { return proto.CompactTextString(m) }
+func (*RSVPUpdate) ProtoMessage()    {}
+func (*RSVPUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{16}
+}
+
+func (m *RSVPUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RSVPUpdate.Unmarshal(m, b)
+}
+func (m *RSVPUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RSVPUpdate.Marshal(b, m, deterministic)
+}
+func (m *RSVPUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RSVPUpdate.Merge(m, src)
+}
+func (m *RSVPUpdate) XXX_Size() int {
+	return xxx_messageInfo_RSVPUpdate.Size(m)
+}
+func (m *RSVPUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_RSVPUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RSVPUpdate proto.InternalMessageInfo
+
+func (m *RSVPUpdate) GetGroupId() string {
+	if m != nil {
+		return m.GroupId
+	}
+	return ""
+}
+
+func (m *RSVPUpdate) GetAttendee() *Attendee {
+	if m != nil {
+		return m.Attendee
+	}
+	return nil
+}
+
+type ExternalUser struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Source               int32    `protobuf:"varint,2,opt,name=source,proto3" json:"source,omitempty"`
+	DisplayName          string   `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExternalUser) Reset()         { *m = ExternalUser{} }
+func (m *ExternalUser) String() string { return proto.CompactTextString(m) }
+func (*ExternalUser) ProtoMessage()    {}
+func (*ExternalUser) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{17}
+}
+
+func (m *ExternalUser) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExternalUser.Unmarshal(m, b)
+}
+func (m *ExternalUser) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExternalUser.Marshal(b, m, deterministic)
+}
+func (m *ExternalUser) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExternalUser.Merge(m, src)
+}
+func (m *ExternalUser) XXX_Size() int {
+	return xxx_messageInfo_ExternalUser.Size(m)
+}
+func (m *ExternalUser) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExternalUser.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExternalUser proto.InternalMessageInfo
+
+func (m *ExternalUser) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ExternalUser) GetSource() int32 {
+	if m != nil {
+		return m.Source
+	}
+	return 0
+}
+
+func (m *ExternalUser) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+type ExternalGroup struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Source               int32    `protobuf:"varint,2,opt,name=source,proto3" json:"source,omitempty"`
+	Timezone             string   `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExternalGroup) Reset()         { *m = ExternalGroup{} }
+func (m *ExternalGroup) String() string { return proto.CompactTextString(m) }
+func (*ExternalGroup) ProtoMessage()    {}
+func (*ExternalGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{18}
+}
+
+func (m *ExternalGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExternalGroup.Unmarshal(m, b)
+}
+func (m *ExternalGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExternalGroup.Marshal(b, m, deterministic)
+}
+func (m *ExternalGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExternalGroup.Merge(m, src)
+}
+func (m *ExternalGroup) XXX_Size() int {
+	return xxx_messageInfo_ExternalGroup.Size(m)
+}
+func (m *ExternalGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExternalGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExternalGroup proto.InternalMessageInfo
+
+func (m *ExternalGroup) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ExternalGroup) GetSource() int32 {
+	if m != nil {
+		return m.Source
+	}
+	return 0
+}
+
+func (m *ExternalGroup) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+type GroupTimezoneRequest struct {
+	GroupId              string   `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Timezone             string   `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GroupTimezoneRequest) Reset()         { *m = GroupTimezoneRequest{} }
+func (m *GroupTimezoneRequest) String() string { return proto.CompactTextString(m) }
+func (*GroupTimezoneRequest) ProtoMessage()    {}
+func (*GroupTimezoneRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{19}
+}
+
+func (m *GroupTimezoneRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroupTimezoneRequest.Unmarshal(m, b)
+}
+func (m *GroupTimezoneRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroupTimezoneRequest.Marshal(b, m, deterministic)
+}
+func (m *GroupTimezoneRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupTimezoneRequest.Merge(m, src)
+}
+func (m *GroupTimezoneRequest) XXX_Size() int {
+	return xxx_messageInfo_GroupTimezoneRequest.Size(m)
+}
+func (m *GroupTimezoneRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupTimezoneRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupTimezoneRequest proto.InternalMessageInfo
+
+func (m *GroupTimezoneRequest) GetGroupId() string {
+	if m != nil {
+		return m.GroupId
+	}
+	return ""
+}
+
+func (m *GroupTimezoneRequest) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+type IDRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IDRequest) Reset()         { *m = IDRequest{} }
+func (m *IDRequest) String() string { return proto.CompactTextString(m) }
+func (*IDRequest) ProtoMessage()    {}
+func (*IDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{20}
+}
+
+func (m *IDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IDRequest.Unmarshal(m, b)
+}
+func (m *IDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IDRequest.Marshal(b, m, deterministic)
+}
+func (m *IDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IDRequest.Merge(m, src)
+}
+func (m *IDRequest) XXX_Size() int {
+	return xxx_messageInfo_IDRequest.Size(m)
+}
+func (m *IDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IDRequest proto.InternalMessageInfo
+
+func (m *IDRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type IDResponse struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IDResponse) Reset()         { *m = IDResponse{} }
+func (m *IDResponse) String() string { return proto.CompactTextString(m) }
+func (*IDResponse) ProtoMessage()    {}
+func (*IDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{21}
+}
+
+func (m *IDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IDResponse.Unmarshal(m, b)
+}
+func (m *IDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IDResponse.Marshal(b, m, deterministic)
+}
+func (m *IDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IDResponse.Merge(m, src)
+}
+func (m *IDResponse) XXX_Size() int {
+	return xxx_messageInfo_IDResponse.Size(m)
+}
+func (m *IDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IDResponse proto.InternalMessageInfo
+
+func (m *IDResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type UsersRequest struct {
+	UserIds              []string `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UsersRequest) Reset()         { *m = UsersRequest{} }
+func (m *UsersRequest) String() string { return proto.CompactTextString(m) }
+func (*UsersRequest) ProtoMessage()    {}
+func (*UsersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{22}
+}
+
+func (m *UsersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UsersRequest.Unmarshal(m, b)
+}
+func (m *UsersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UsersRequest.Marshal(b, m, deterministic)
+}
+func (m *UsersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsersRequest.Merge(m, src)
+}
+func (m *UsersRequest) XXX_Size() int {
+	return xxx_messageInfo_UsersRequest.Size(m)
+}
+func (m *UsersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UsersRequest proto.InternalMessageInfo
+
+func (m *UsersRequest) GetUserIds() []string {
+	if m != nil {
+		return m.UserIds
+	}
+	return nil
+}
+
+type UsersResponse struct {
+	Users                map[string]*ExternalUser `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *UsersResponse) Reset()         { *m = UsersResponse{} }
+func (m *UsersResponse) String() string { return proto.CompactTextString(m) }
+func (*UsersResponse) ProtoMessage()    {}
+func (*UsersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c5ec4a5730439df, []int{23}
+}
+
+func (m *UsersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UsersResponse.Unmarshal(m, b)
+}
+func (m *UsersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UsersResponse.Marshal(b, m, deterministic)
+}
+func (m *UsersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsersResponse.Merge(m, src)
+}
+func (m *UsersResponse) XXX_Size() int {
+	return xxx_messageInfo_UsersResponse.Size(m)
+}
+func (m *UsersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UsersResponse proto.InternalMessageInfo
+
+func (m *UsersResponse) GetUsers() map[string]*ExternalUser {
+	if m != nil {
+		return m.Users
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Empty)(nil), "organizer.Empty")
+	proto.RegisterType((*Meeting)(nil), "organizer.Meeting")
+	proto.RegisterType((*Attendee)(nil), "organizer.Attendee")
+	proto.RegisterType((*Guest)(nil), "organizer.Guest")
+	proto.RegisterType((*GroupRequest)(nil), "organizer.GroupRequest")
+	proto.RegisterType((*CreateMeetingRequest)(nil), "organizer.CreateMeetingRequest")
+	proto.RegisterType((*MeetingAttendeesData)(nil), "organizer.MeetingAttendeesData")
+	proto.RegisterType((*RSVPRequest)(nil), "organizer.RSVPRequest")
+	proto.RegisterType((*AttendeesResponse)(nil), "organizer.AttendeesResponse")
+	proto.RegisterType((*MeetingsResponse)(nil), "organizer.MeetingsResponse")
+	proto.RegisterType((*ActiveMeetingsResponse)(nil), "organizer.ActiveMeetingsResponse")
+	proto.RegisterMapType((map[string]*Meeting)(nil), "organizer.ActiveMeetingsResponse.MeetingsEntry")
+	proto.RegisterType((*MeetingRecurrenceRequest)(nil), "organizer.MeetingRecurrenceRequest")
+	proto.RegisterType((*MeetingRSVPCutoffRequest)(nil), "organizer.MeetingRSVPCutoffRequest")
+	proto.RegisterType((*MeetingMaxGuestsRequest)(nil), "organizer.MeetingMaxGuestsRequest")
+	proto.RegisterType((*AttendeeGuestsRequest)(nil), "organizer.AttendeeGuestsRequest")
+	proto.RegisterType((*CheckInRequest)(nil), "organizer.CheckInRequest")
+	proto.RegisterType((*RSVPUpdate)(nil), "organizer.RSVPUpdate")
+	proto.RegisterType((*ExternalUser)(nil), "organizer.ExternalUser")
+	proto.RegisterType((*ExternalGroup)(nil), "organizer.ExternalGroup")
+	proto.RegisterType((*GroupTimezoneRequest)(nil), "organizer.GroupTimezoneRequest")
+	proto.RegisterType((*IDRequest)(nil), "organizer.IDRequest")
+	proto.RegisterType((*IDResponse)(nil), "organizer.IDResponse")
+	proto.RegisterType((*UsersRequest)(nil), "organizer.UsersRequest")
+	proto.RegisterType((*UsersResponse)(nil), "organizer.UsersResponse")
+	proto.RegisterMapType((map[string]*ExternalUser)(nil), "organizer.UsersResponse.UsersEntry")
+}
+
+func init() { proto.RegisterFile("organizer.proto", fileDescriptor_6c5ec4a5730439df) }
+
+var fileDescriptor_6c5ec4a5730439df = []byte{
+	// 1360 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdf, 0x72, 0xda, 0xc6,
+	0x17, 0x1e, 0x81, 0x31, 0xe8, 0x00, 0xb1, 0xbd, 0x71, 0x6c, 0x85, 0xe4, 0x17, 0x13, 0xe5, 0x86,
+	0xfc, 0xa6, 0x05, 0xc7, 0x9d, 0xfe, 0x89, 0x33, 0xed, 0xd4, 0x06, 0x97, 0x32, 0x89, 0x9b, 0x54,
+	0x24, 0x69, 0xd3, 0x1b, 0x66, 0x2d, 0x6d, 0x30, 0x13, 0x90, 0x14, 0x69, 0xc9, 0x84, 0xdc, 0xf4,
+	0x41, 0x3a, 0x7d, 0x8d, 0x3e, 0x4e, 0xdf, 0xa3, 0x57, 0xed, 0x68, 0xff, 0x48, 0x2b, 0x10, 0x86,
+	0x66, 0x7a, 0xa7, 0xb3, 0xfb, 0xed, 0xb7, 0xdf, 0x9e, 0xb3, 0xfa, 0xce, 0xc2, 0x96, 0x17, 0x0c,
+	0xb1, 0x3b, 0xfa, 0x40, 0x82, 0xa6, 0x1f, 0x78, 0xd4, 0x43, 0x7a, 0x3c, 0x50, 0xbb, 0x33, 0xf4,
+	0xbc, 0xe1, 0x98, 0xb4, 0xd8, 0xc4, 0xc5, 0xf4, 0x75, 0xcb, 0x99, 0x06, 0x98, 0x8e, 0x3c, 0x97,
+	0x43, 0x6b, 0x07, 0xf3, 0xf3, 0x74, 0x34, 0x21, 0x21, 0xc5, 0x13, 0x9f, 0x03, 0xcc, 0x22, 0x14,
+	0xce, 0x26, 0x3e, 0x9d, 0x99, 0x7f, 0xe6, 0xa1, 0x78, 0x4e, 0x08, 0x1d, 0xb9, 0x43, 0xd4, 0x84,
+	0x8d, 0x08, 0x67, 0x68, 0x75, 0xad, 0x51, 0x3e, 0xaa, 0x35, 0x39, 0x49, 0x53, 0x92, 0x34, 0x9f,
+	0x4b, 0x12, 0x8b, 0xe1, 0x50, 0x0d, 0x4a, 0x63, 0xcf, 0x66, 0xfb, 0x1a, 0xb9, 0xba, 0xd6, 0xd0,
+	0xad, 0x38, 0x8e, 0xe6, 0x6c, 0xec, 0x63, 0x7b, 0x44, 0x67, 0x46, 0xbe, 0xae, 0x35, 0x0a, 0x56,
+	0x1c, 0xa3, 0x3d, 0xd8, 0xb4, 0xc7, 0x5e, 0x48, 0x1c, 0x63, 0xa3, 0xae, 0x35, 0x4a, 0x96, 0x88,
+	0xd0, 0x35, 0xc8, 0x8d, 0x1c, 0xa3, 0xc0, 0x98, 0x72, 0x23, 0x07, 0xdd, 0x06, 0xdd, 0xc6, 0xae,
+	0x4d, 0xc6, 0x63, 0xe2, 0x18, 0x9b, 0x0c, 0x9a, 0x0c, 0xa0, 0x3b, 0x00, 0x01, 0xb1, 0xa7, 0x41,
+	0x40, 0x5c, 0x9b, 0x18, 0x45, 0xb6, 0x4a, 0x19, 0x41, 0x9f, 0x43, 0x49, 0x66, 0xc5, 0x28, 0xb1,
+	0x13, 0xdd, 0x5c, 0x38, 0x51, 0x47, 0x00, 0xac, 0x18, 0x8a, 0x8e, 0xa1, 0x1c, 0x84, 0xef, 0xfc,
+	0x81, 0x3d, 0xa5, 0xde, 0xeb, 0xd7, 0x86, 0xbe, 0x6a, 0x25, 0x44, 0xe8, 0x36, 0x03, 0xa3, 0xff,
+	0x01, 0x4c, 0xf0, 0xfb, 0xc1, 0x70, 0x4a, 0x42, 0x1a, 0x1a, 0xc0, 0x8e, 0xad, 0x4f, 0xf0, 0xfb,
+	0x2e, 0x1b, 0x40, 0x0f, 0x01, 0xec, 0x80, 0x60, 0x4a, 0x9c, 0x01, 0xa6, 0x46, 0x79, 0x65, 0x96,
+	0x75, 0x81, 0x3e, 0xa1, 0x68, 0x1f, 0x8a, 0x97, 0x5e, 0x48, 0x07, 0x23, 0xc7, 0xa8, 0xb0, 0x93,
+	0x6e, 0x46, 0x61, 0xcf, 0x41, 0x07, 0x50, 0x96, 0x39, 0x8f, 0x26, 0xab, 0x3c, 0x0d, 0x72, 0xa8,
+	0xe7, 0x98, 0x7f, 0x6b, 0x50, 0x3a, 0xa1, 0x94, 0xb8, 0x0e, 0x21, 0x11, 0xcd, 0x34, 0x24, 0x41,
+	0x84, 0xd4, 0x38, 0x4d, 0x14, 0xf6, 0x9c, 0xa8, 0x24, 0x78, 0xe2, 0x4d, 0x5d, 0xca, 0x0a, 0x59,
+	0xb0, 0x44, 0x84, 0x1a, 0xb0, 0x29, 0x4e, 0x93, 0xaf, 0xe7, 0x1b, 0xe5, 0xa3, 0xed, 0x66, 0x72,
+	0x2b, 0xd9, 0xa9, 0x2c, 0x31, 0x8f, 0x76, 0xa1, 0x30, 0xc1, 0xb3, 0x0b, 0x22, 0x6a, 0xca, 0x03,
+	0xf4, 0x25, 0xe8, 0x2c, 0x9b, 0xec, 0x5e, 0x15, 0x56, 0x9e, 0xb8, 0x14, 0x81, 0xa3, 0x30, 0x4a,
+	0xa5, 0x7d, 0x49, 0xec, 0x37, 0xc4, 0x19, 0x8c, 0xdc, 0xb8, 0xf8, 0x7c, 0xa4, 0xe7, 0xa2, 0xff,
+	0xc3, 0x0e, 0xdf, 0x77, 0xa0, 0xa0, 0x8a, 0x4c, 0xfa, 0x16, 0x9f, 0x68, 0x4b, 0xac, 0xf9, 0x04,
+	0x0a, 0x4c, 0x2a, 0x42, 0xb0, 0xe1, 0x62, 0x71, 0xbf, 0x75, 0x8b, 0x7d, 0x47, 0x07, 0xbf, 0xc4,
+	0xae, 0x33, 0x26, 0xe2, 0x06, 0x8b, 0x48, 0xcd, 0x54, 0x5e, 0xcd, 0x94, 0x79, 0x1f, 0x2a, 0xdd,
+	0xc0, 0x9b, 0xfa, 0x16, 0x79, 0xcb, 0x48, 0x6f, 0x42, 0x69, 0x18, 0xc5, 0x49, 0x4e, 0x8b, 0x2c,
+	0xee, 0x39, 0xe6, 0x00, 0x76, 0xdb, 0xac, 0x82, 0xe2, 0x07, 0x5b, 0xbd, 0x04, 0x7d, 0x02, 0xc5,
+	0x09, 0x07, 0x33, 0x3d, 0xe5, 0x23, 0xa4, 0x24, 0x5c, 0xd2, 0x48, 0x88, 0x79, 0x06, 0xbb, 0x62,
+	0x4c, 0x56, 0x38, 0xec, 0x60, 0x8a, 0xaf, 0xda, 0x00, 0xc1, 0x86, 0x83, 0x29, 0x66, 0xec, 0x15,
+	0x8b, 0x7d, 0x9b, 0xaf, 0xa0, 0x6c, 0xf5, 0x5f, 0x3e, 0x5b, 0x43, 0x5e, 0x0b, 0x4a, 0x58, 0xec,
+	0x24, 0xf4, 0x5d, 0x57, 0xf4, 0x49, 0x11, 0x56, 0x0c, 0x32, 0xbf, 0x83, 0x9d, 0x58, 0x9a, 0x45,
+	0x42, 0xdf, 0x73, 0x43, 0x82, 0x1e, 0x80, 0x2e, 0x01, 0xa1, 0xa1, 0xd5, 0xf3, 0xcb, 0x68, 0x12,
+	0x94, 0x79, 0x0a, 0xdb, 0xe2, 0xa4, 0x09, 0x4d, 0x13, 0x4a, 0x22, 0x11, 0x92, 0x25, 0x2b, 0x59,
+	0x31, 0xc6, 0xfc, 0x43, 0x83, 0xbd, 0x13, 0x9b, 0x8e, 0xde, 0x91, 0x05, 0xaa, 0xc7, 0x0b, 0x54,
+	0x2d, 0x55, 0x50, 0xe6, 0x22, 0xb9, 0x43, 0x78, 0xe6, 0xd2, 0x60, 0x96, 0xec, 0x53, 0x7b, 0x0a,
+	0xd5, 0xd4, 0x14, 0xda, 0x86, 0xfc, 0x1b, 0x32, 0x13, 0xb9, 0x8c, 0x3e, 0x51, 0x03, 0x0a, 0xef,
+	0xf0, 0x78, 0x4a, 0xae, 0x28, 0x32, 0x07, 0x1c, 0xe7, 0xbe, 0xd2, 0xcc, 0x17, 0x60, 0xc8, 0xd1,
+	0xd8, 0xde, 0xd6, 0x28, 0x56, 0xda, 0x20, 0x73, 0xf3, 0x06, 0x69, 0xbe, 0x4d, 0x68, 0xfb, 0x2f,
+	0x9f, 0x71, 0x0b, 0x5b, 0x83, 0x76, 0xce, 0x20, 0x73, 0xff, 0xc2, 0x20, 0xcd, 0x3e, 0xec, 0x8b,
+	0x2d, 0xcf, 0xa5, 0x2b, 0xae, 0xb1, 0x63, 0xda, 0x56, 0x73, 0x73, 0xb6, 0x6a, 0x4e, 0xe1, 0x86,
+	0xbc, 0x32, 0x6b, 0x53, 0x2a, 0xbf, 0x77, 0x2e, 0x65, 0x84, 0x6b, 0x1b, 0x9e, 0xf9, 0x2b, 0x5c,
+	0x63, 0x1e, 0xd3, 0x73, 0xe5, 0x7e, 0x91, 0x4e, 0x7e, 0xba, 0x64, 0x47, 0x5d, 0x8c, 0x5c, 0xb5,
+	0x67, 0xda, 0xeb, 0xf2, 0xf3, 0x5e, 0xb7, 0x17, 0x4b, 0xda, 0xe0, 0xde, 0x2c, 0x04, 0xfc, 0x0c,
+	0x10, 0x15, 0xee, 0x85, 0xef, 0x60, 0x4a, 0xfe, 0xd3, 0xbf, 0xf6, 0x15, 0x54, 0xce, 0xde, 0x53,
+	0x12, 0xb8, 0x78, 0xfc, 0x22, 0x24, 0x81, 0x68, 0xcc, 0x5a, 0xdc, 0x98, 0xf7, 0x60, 0x33, 0xf4,
+	0xa6, 0x81, 0xb8, 0x55, 0x05, 0x4b, 0x44, 0xe8, 0x2e, 0x54, 0x9c, 0x51, 0xe8, 0x8f, 0xf1, 0x6c,
+	0xc0, 0x8c, 0x96, 0x3b, 0x67, 0x59, 0x8c, 0xfd, 0x80, 0x27, 0xc4, 0xec, 0x43, 0x55, 0x52, 0x33,
+	0x1b, 0x5d, 0x9b, 0xbb, 0x06, 0xa5, 0xa8, 0x89, 0x7c, 0xf0, 0x5c, 0xc9, 0x1b, 0xc7, 0xe6, 0x39,
+	0xec, 0x32, 0xb2, 0xe7, 0x62, 0x60, 0x8d, 0x0b, 0xa0, 0xd2, 0xe5, 0xe6, 0xe8, 0x6e, 0x81, 0xde,
+	0xeb, 0x48, 0x8e, 0x39, 0x7d, 0xe6, 0x6d, 0x80, 0x5e, 0x47, 0x7a, 0xc0, 0xc2, 0xec, 0x7d, 0xa8,
+	0x44, 0x19, 0x53, 0xaf, 0xa0, 0xa8, 0x39, 0x37, 0x16, 0xdd, 0x2a, 0xf2, 0xa2, 0x87, 0xe6, 0xef,
+	0x1a, 0x54, 0x05, 0x56, 0x90, 0x3d, 0x84, 0x42, 0x34, 0x29, 0x2d, 0xe8, 0x9e, 0x52, 0xa4, 0x14,
+	0x90, 0x47, 0xdc, 0x76, 0xf8, 0x8a, 0xda, 0x8f, 0x00, 0xc9, 0x60, 0x86, 0xe1, 0x7c, 0x9a, 0x36,
+	0x9c, 0x7d, 0x85, 0x5a, 0xad, 0xb4, 0xe2, 0x3a, 0x47, 0x7f, 0xe9, 0x50, 0x92, 0x3e, 0x86, 0xba,
+	0x50, 0x4d, 0xb5, 0x32, 0x74, 0xa0, 0x30, 0x64, 0x35, 0xb9, 0xda, 0x0d, 0x05, 0xa0, 0x24, 0xec,
+	0x18, 0xaa, 0x1d, 0x32, 0x26, 0x09, 0x91, 0x2a, 0x45, 0x6d, 0xac, 0x35, 0xf5, 0xcf, 0x63, 0x6f,
+	0xd5, 0xe8, 0xfd, 0xd4, 0x25, 0x74, 0xe5, 0xc2, 0x0c, 0x37, 0x45, 0x4f, 0x60, 0xbf, 0x1f, 0x2f,
+	0x4d, 0x37, 0xcb, 0x83, 0x45, 0x78, 0x0a, 0x90, 0x21, 0xa4, 0x0f, 0xfb, 0xdd, 0x25, 0x6c, 0x4b,
+	0x55, 0xad, 0xda, 0x06, 0x3d, 0x82, 0x2d, 0x56, 0x82, 0xfe, 0xcb, 0x67, 0x52, 0xf5, 0x9e, 0xb2,
+	0x46, 0xe9, 0xd0, 0x19, 0x8a, 0x9e, 0xc0, 0xf5, 0x0c, 0x45, 0xcb, 0xd5, 0xdc, 0xce, 0x30, 0x00,
+	0xf5, 0x22, 0x56, 0xda, 0xd1, 0x93, 0xfc, 0x23, 0x6a, 0x74, 0x0c, 0xd5, 0x36, 0x7b, 0xa2, 0x7f,
+	0xc4, 0xda, 0x36, 0x94, 0x93, 0x43, 0x5c, 0x21, 0xfe, 0xd6, 0x62, 0x2a, 0x13, 0xed, 0xe7, 0x99,
+	0xb5, 0x39, 0x9d, 0xf5, 0x3a, 0x68, 0x77, 0xee, 0x4a, 0xae, 0x93, 0x8a, 0xef, 0x61, 0xa7, 0x4b,
+	0x68, 0xfa, 0x05, 0x80, 0x16, 0xa4, 0xd7, 0xee, 0xae, 0x7c, 0x2e, 0xa0, 0x73, 0xd8, 0x4d, 0xae,
+	0x60, 0xd2, 0xc8, 0xd1, 0xbd, 0x8c, 0xe6, 0x3f, 0xdf, 0xe6, 0x33, 0x92, 0x95, 0xa6, 0x8b, 0x1b,
+	0x78, 0x26, 0xdd, 0x7c, 0x7b, 0xcf, 0xa0, 0x7b, 0x0c, 0xd7, 0x13, 0xba, 0xb8, 0x39, 0x23, 0x73,
+	0x91, 0x6d, 0xbe, 0x73, 0x67, 0x90, 0x75, 0x61, 0xa7, 0x4f, 0x68, 0xba, 0x29, 0xa3, 0x7a, 0x46,
+	0x9e, 0x57, 0x11, 0x7d, 0x01, 0x45, 0xd1, 0x63, 0xd1, 0x4d, 0xd5, 0x70, 0x52, 0x7d, 0x37, 0x63,
+	0xdd, 0x37, 0x00, 0x3f, 0x61, 0x6a, 0x5f, 0x46, 0x27, 0xbf, 0xe2, 0x22, 0xdd, 0x98, 0xfb, 0xbf,
+	0x78, 0x2b, 0x3d, 0xd4, 0x8e, 0x7e, 0xcb, 0x43, 0x81, 0xf9, 0x29, 0x3a, 0x81, 0xad, 0x2e, 0xa1,
+	0x4f, 0x03, 0xee, 0x71, 0xd1, 0x18, 0x5a, 0x66, 0x9e, 0xcb, 0x2c, 0xef, 0x5b, 0x46, 0xa1, 0x22,
+	0x97, 0xdc, 0xc4, 0x65, 0xc4, 0xa8, 0x0d, 0xdb, 0x8a, 0x08, 0xde, 0x37, 0x8d, 0x0c, 0x30, 0x9b,
+	0x59, 0x26, 0xe3, 0x94, 0x91, 0xa4, 0xa0, 0x4b, 0x74, 0x2c, 0xa5, 0x46, 0x5f, 0x43, 0xa9, 0x4b,
+	0x28, 0xcf, 0xcc, 0xfe, 0x62, 0x7b, 0x5a, 0x5c, 0x9e, 0x6e, 0x70, 0x67, 0xb0, 0xdd, 0x27, 0x34,
+	0xd5, 0xaa, 0x53, 0xf6, 0x9b, 0xd5, 0xc4, 0x17, 0xab, 0x7b, 0x7a, 0xf4, 0xcb, 0xe1, 0x70, 0x44,
+	0x2f, 0xa7, 0x17, 0x4d, 0xdb, 0x9b, 0xb4, 0x42, 0xe2, 0xfb, 0xde, 0xe1, 0xe1, 0x83, 0xc3, 0xd6,
+	0x85, 0x87, 0x03, 0x67, 0x88, 0x27, 0x24, 0x8c, 0x57, 0xb4, 0x02, 0xdf, 0x7e, 0x14, 0xf8, 0xf6,
+	0xc5, 0x26, 0x7b, 0x98, 0x7e, 0xf6, 0xcf, 0x00, 0xf9, 0x93, 0x9f, 0xb1, 0x56, 0x11, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// MeetingsClient is the client API for Meetings service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MeetingsClient interface {
+	CreateMeeting(ctx context.Context, in *CreateMeetingRequest, opts ...grpc.CallOption) (*IDResponse, error)
+	DeleteMeeting(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetMeeting(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*Meeting, error)
+	SetMeetingAttendeesData(ctx context.Context, in *MeetingAttendeesData, opts ...grpc.CallOption) (*Empty, error)
+	GetMeetingAttendeesData(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*MeetingAttendeesData, error)
+	UserRSVPMeeting(ctx context.Context, in *RSVPRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetMeetingAttendees(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*AttendeesResponse, error)
+	CloseMeeting(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*Empty, error)
+	CancelMeeting(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetMeetings(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*MeetingsResponse, error)
+	GetMeetingAttendeesByID(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*AttendeesResponse, error)
+	GetActiveMeetings(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ActiveMeetingsResponse, error)
+	SetMeetingRecurrence(ctx context.Context, in *MeetingRecurrenceRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetMeetingRSVPCutoff(ctx context.Context, in *MeetingRSVPCutoffRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetMeetingMaxGuests(ctx context.Context, in *MeetingMaxGuestsRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetAttendeeGuests(ctx context.Context, in *AttendeeGuestsRequest, opts ...grpc.CallOption) (*Empty, error)
+	CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*Empty, error)
+	WatchRSVPs(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (Meetings_WatchRSVPsClient, error)
+}
+
+type meetingsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMeetingsClient(cc *grpc.ClientConn) MeetingsClient {
+	return &meetingsClient{cc}
+}
+
+func (c *meetingsClient) CreateMeeting(ctx context.Context, in *CreateMeetingRequest, opts ...grpc.CallOption) (*IDResponse, error) {
+	out := new(IDResponse)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/CreateMeeting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) DeleteMeeting(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/DeleteMeeting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) GetMeeting(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*Meeting, error) {
+	out := new(Meeting)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/GetMeeting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) SetMeetingAttendeesData(ctx context.Context, in *MeetingAttendeesData, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/SetMeetingAttendeesData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) GetMeetingAttendeesData(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*MeetingAttendeesData, error) {
+	out := new(MeetingAttendeesData)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/GetMeetingAttendeesData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) UserRSVPMeeting(ctx context.Context, in *RSVPRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/UserRSVPMeeting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) GetMeetingAttendees(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*AttendeesResponse, error) {
+	out := new(AttendeesResponse)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/GetMeetingAttendees", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) CloseMeeting(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/CloseMeeting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) CancelMeeting(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/CancelMeeting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) GetMeetings(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*MeetingsResponse, error) {
+	out := new(MeetingsResponse)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/GetMeetings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) GetMeetingAttendeesByID(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*AttendeesResponse, error) {
+	out := new(AttendeesResponse)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/GetMeetingAttendeesByID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) GetActiveMeetings(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ActiveMeetingsResponse, error) {
+	out := new(ActiveMeetingsResponse)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/GetActiveMeetings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) SetMeetingRecurrence(ctx context.Context, in *MeetingRecurrenceRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/SetMeetingRecurrence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) SetMeetingRSVPCutoff(ctx context.Context, in *MeetingRSVPCutoffRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/SetMeetingRSVPCutoff", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) SetMeetingMaxGuests(ctx context.Context, in *MeetingMaxGuestsRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/SetMeetingMaxGuests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) SetAttendeeGuests(ctx context.Context, in *AttendeeGuestsRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/SetAttendeeGuests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/organizer.Meetings/CheckIn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meetingsClient) WatchRSVPs(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (Meetings_WatchRSVPsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Meetings_serviceDesc.Streams[0], "/organizer.Meetings/WatchRSVPs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &meetingsWatchRSVPsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Meetings_WatchRSVPsClient interface {
+	Recv() (*RSVPUpdate, error)
+	grpc.ClientStream
+}
+
+type meetingsWatchRSVPsClient struct {
+	grpc.ClientStream
+}
+
+func (x *meetingsWatchRSVPsClient) Recv() (*RSVPUpdate, error) {
+	m := new(RSVPUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MeetingsServer is the server API for Meetings service.
+type MeetingsServer interface {
+	CreateMeeting(context.Context, *CreateMeetingRequest) (*IDResponse, error)
+	DeleteMeeting(context.Context, *GroupRequest) (*Empty, error)
+	GetMeeting(context.Context, *GroupRequest) (*Meeting, error)
+	SetMeetingAttendeesData(context.Context, *MeetingAttendeesData) (*Empty, error)
+	GetMeetingAttendeesData(context.Context, *GroupRequest) (*MeetingAttendeesData, error)
+	UserRSVPMeeting(context.Context, *RSVPRequest) (*Empty, error)
+	GetMeetingAttendees(context.Context, *GroupRequest) (*AttendeesResponse, error)
+	CloseMeeting(context.Context, *GroupRequest) (*Empty, error)
+	CancelMeeting(context.Context, *GroupRequest) (*Empty, error)
+	GetMeetings(context.Context, *GroupRequest) (*MeetingsResponse, error)
+	GetMeetingAttendeesByID(context.Context, *IDRequest) (*AttendeesResponse, error)
+	GetActiveMeetings(context.Context, *Empty) (*ActiveMeetingsResponse, error)
+	SetMeetingRecurrence(context.Context, *MeetingRecurrenceRequest) (*Empty, error)
+	SetMeetingRSVPCutoff(context.Context, *MeetingRSVPCutoffRequest) (*Empty, error)
+	SetMeetingMaxGuests(context.Context, *MeetingMaxGuestsRequest) (*Empty, error)
+	SetAttendeeGuests(context.Context, *AttendeeGuestsRequest) (*Empty, error)
+	CheckIn(context.Context, *CheckInRequest) (*Empty, error)
+	WatchRSVPs(*GroupRequest, Meetings_WatchRSVPsServer) error
+}
+
+func RegisterMeetingsServer(s *grpc.Server, srv MeetingsServer) {
+	s.RegisterService(&_Meetings_serviceDesc, srv)
+}
+
+func _Meetings_CreateMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMeetingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).CreateMeeting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/CreateMeeting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).CreateMeeting(ctx, req.(*CreateMeetingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_DeleteMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).DeleteMeeting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/DeleteMeeting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).DeleteMeeting(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_GetMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).GetMeeting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/GetMeeting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).GetMeeting(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_SetMeetingAttendeesData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeetingAttendeesData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).SetMeetingAttendeesData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/SetMeetingAttendeesData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).SetMeetingAttendeesData(ctx, req.(*MeetingAttendeesData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_GetMeetingAttendeesData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).GetMeetingAttendeesData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/GetMeetingAttendeesData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).GetMeetingAttendeesData(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_UserRSVPMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RSVPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).UserRSVPMeeting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/UserRSVPMeeting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).UserRSVPMeeting(ctx, req.(*RSVPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_GetMeetingAttendees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).GetMeetingAttendees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/GetMeetingAttendees",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).GetMeetingAttendees(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_CloseMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).CloseMeeting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/CloseMeeting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).CloseMeeting(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_CancelMeeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).CancelMeeting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/CancelMeeting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).CancelMeeting(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_GetMeetings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).GetMeetings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/GetMeetings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).GetMeetings(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_GetMeetingAttendeesByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).GetMeetingAttendeesByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/GetMeetingAttendeesByID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).GetMeetingAttendeesByID(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_GetActiveMeetings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).GetActiveMeetings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/GetActiveMeetings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).GetActiveMeetings(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_SetMeetingRecurrence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeetingRecurrenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).SetMeetingRecurrence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/SetMeetingRecurrence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).SetMeetingRecurrence(ctx, req.(*MeetingRecurrenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_SetMeetingRSVPCutoff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeetingRSVPCutoffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).SetMeetingRSVPCutoff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/SetMeetingRSVPCutoff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).SetMeetingRSVPCutoff(ctx, req.(*MeetingRSVPCutoffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_SetMeetingMaxGuests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeetingMaxGuestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).SetMeetingMaxGuests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/SetMeetingMaxGuests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).SetMeetingMaxGuests(ctx, req.(*MeetingMaxGuestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_SetAttendeeGuests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttendeeGuestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).SetAttendeeGuests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/SetAttendeeGuests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).SetAttendeeGuests(ctx, req.(*AttendeeGuestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_CheckIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeetingsServer).CheckIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Meetings/CheckIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeetingsServer).CheckIn(ctx, req.(*CheckInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Meetings_WatchRSVPs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GroupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MeetingsServer).WatchRSVPs(m, &meetingsWatchRSVPsServer{stream})
+}
+
+type Meetings_WatchRSVPsServer interface {
+	Send(*RSVPUpdate) error
+	grpc.ServerStream
+}
+
+type meetingsWatchRSVPsServer struct {
+	grpc.ServerStream
+}
+
+func (x *meetingsWatchRSVPsServer) Send(m *RSVPUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Meetings_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "organizer.Meetings",
+	HandlerType: (*MeetingsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateMeeting",
+			Handler:    _Meetings_CreateMeeting_Handler,
+		},
+		{
+			MethodName: "DeleteMeeting",
+			Handler:    _Meetings_DeleteMeeting_Handler,
+		},
+		{
+			MethodName: "GetMeeting",
+			Handler:    _Meetings_GetMeeting_Handler,
+		},
+		{
+			MethodName: "SetMeetingAttendeesData",
+			Handler:    _Meetings_SetMeetingAttendeesData_Handler,
+		},
+		{
+			MethodName: "GetMeetingAttendeesData",
+			Handler:    _Meetings_GetMeetingAttendeesData_Handler,
+		},
+		{
+			MethodName: "UserRSVPMeeting",
+			Handler:    _Meetings_UserRSVPMeeting_Handler,
+		},
+		{
+			MethodName: "GetMeetingAttendees",
+			Handler:    _Meetings_GetMeetingAttendees_Handler,
+		},
+		{
+			MethodName: "CloseMeeting",
+			Handler:    _Meetings_CloseMeeting_Handler,
+		},
+		{
+			MethodName: "CancelMeeting",
+			Handler:    _Meetings_CancelMeeting_Handler,
+		},
+		{
+			MethodName: "GetMeetings",
+			Handler:    _Meetings_GetMeetings_Handler,
+		},
+		{
+			MethodName: "GetMeetingAttendeesByID",
+			Handler:    _Meetings_GetMeetingAttendeesByID_Handler,
+		},
+		{
+			MethodName: "GetActiveMeetings",
+			Handler:    _Meetings_GetActiveMeetings_Handler,
+		},
+		{
+			MethodName: "SetMeetingRecurrence",
+			Handler:    _Meetings_SetMeetingRecurrence_Handler,
+		},
+		{
+			MethodName: "SetMeetingRSVPCutoff",
+			Handler:    _Meetings_SetMeetingRSVPCutoff_Handler,
+		},
+		{
+			MethodName: "SetMeetingMaxGuests",
+			Handler:    _Meetings_SetMeetingMaxGuests_Handler,
+		},
+		{
+			MethodName: "SetAttendeeGuests",
+			Handler:    _Meetings_SetAttendeeGuests_Handler,
+		},
+		{
+			MethodName: "CheckIn",
+			Handler:    _Meetings_CheckIn_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRSVPs",
+			Handler:       _Meetings_WatchRSVPs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "organizer.proto",
+}
+
+// UsersClient is the client API for Users service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type UsersClient interface {
+	GetOrCreateUser(ctx context.Context, in *ExternalUser, opts ...grpc.CallOption) (*IDResponse, error)
+	GetExternalUser(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ExternalUser, error)
+	GetOrCreateGroup(ctx context.Context, in *ExternalGroup, opts ...grpc.CallOption) (*IDResponse, error)
+	GetExternalGroup(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ExternalGroup, error)
+	GetUsers(ctx context.Context, in *UsersRequest, opts ...grpc.CallOption) (*UsersResponse, error)
+	SetGroupTimezone(ctx context.Context, in *GroupTimezoneRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type usersClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUsersClient(cc *grpc.ClientConn) UsersClient {
+	return &usersClient{cc}
+}
+
+func (c *usersClient) GetOrCreateUser(ctx context.Context, in *ExternalUser, opts ...grpc.CallOption) (*IDResponse, error) {
+	out := new(IDResponse)
+	err := c.cc.Invoke(ctx, "/organizer.Users/GetOrCreateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) GetExternalUser(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ExternalUser, error) {
+	out := new(ExternalUser)
+	err := c.cc.Invoke(ctx, "/organizer.Users/GetExternalUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) GetOrCreateGroup(ctx context.Context, in *ExternalGroup, opts ...grpc.CallOption) (*IDResponse, error) {
+	out := new(IDResponse)
+	err := c.cc.Invoke(ctx, "/organizer.Users/GetOrCreateGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) GetExternalGroup(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ExternalGroup, error) {
+	out := new(ExternalGroup)
+	err := c.cc.Invoke(ctx, "/organizer.Users/GetExternalGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) GetUsers(ctx context.Context, in *UsersRequest, opts ...grpc.CallOption) (*UsersResponse, error) {
+	out := new(UsersResponse)
+	err := c.cc.Invoke(ctx, "/organizer.Users/GetUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersClient) SetGroupTimezone(ctx context.Context, in *GroupTimezoneRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/organizer.Users/SetGroupTimezone", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UsersServer is the server API for Users service.
+type UsersServer interface {
+	GetOrCreateUser(context.Context, *ExternalUser) (*IDResponse, error)
+	GetExternalUser(context.Context, *IDRequest) (*ExternalUser, error)
+	GetOrCreateGroup(context.Context, *ExternalGroup) (*IDResponse, error)
+	GetExternalGroup(context.Context, *IDRequest) (*ExternalGroup, error)
+	GetUsers(context.Context, *UsersRequest) (*UsersResponse, error)
+	SetGroupTimezone(context.Context, *GroupTimezoneRequest) (*Empty, error)
+}
+
+func RegisterUsersServer(s *grpc.Server, srv UsersServer) {
+	s.RegisterService(&_Users_serviceDesc, srv)
+}
+
+func _Users_GetOrCreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExternalUser)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).GetOrCreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Users/GetOrCreateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).GetOrCreateUser(ctx, req.(*ExternalUser))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_GetExternalUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).GetExternalUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Users/GetExternalUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).GetExternalUser(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_GetOrCreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExternalGroup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).GetOrCreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Users/GetOrCreateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).GetOrCreateGroup(ctx, req.(*ExternalGroup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_GetExternalGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).GetExternalGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Users/GetExternalGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).GetExternalGroup(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_GetUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).GetUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Users/GetUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).GetUsers(ctx, req.(*UsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Users_SetGroupTimezone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupTimezoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServer).SetGroupTimezone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/organizer.Users/SetGroupTimezone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServer).SetGroupTimezone(ctx, req.(*GroupTimezoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Users_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "organizer.Users",
+	HandlerType: (*UsersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetOrCreateUser",
+			Handler:    _Users_GetOrCreateUser_Handler,
+		},
+		{
+			MethodName: "GetExternalUser",
+			Handler:    _Users_GetExternalUser_Handler,
+		},
+		{
+			MethodName: "GetOrCreateGroup",
+			Handler:    _Users_GetOrCreateGroup_Handler,
+		},
+		{
+			MethodName: "GetExternalGroup",
+			Handler:    _Users_GetExternalGroup_Handler,
+		},
+		{
+			MethodName: "GetUsers",
+			Handler:    _Users_GetUsers_Handler,
+		},
+		{
+			MethodName: "SetGroupTimezone",
+			Handler:    _Users_SetGroupTimezone_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "organizer.proto",
+}
@@ -0,0 +1,217 @@
+package rpc
+
+//go:generate protoc --go_out=plugins=grpc,paths=source_relative:. organizer.proto
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+var knownErrors = map[error]codes.Code{
+	meetings.NoActiveMeeting:           codes.NotFound,
+	meetings.MeetingAlreadyActive:      codes.AlreadyExists,
+	meetings.MeetingIsInThePast:        codes.InvalidArgument,
+	meetings.UserAlreadyAttendsMeeting: codes.AlreadyExists,
+	meetings.UserDoesNotAttendMeeting:  codes.NotFound,
+	meetings.MeetingIsFull:             codes.ResourceExhausted,
+	meetings.MeetingsOverlap:           codes.FailedPrecondition,
+	meetings.RSVPClosed:                codes.FailedPrecondition,
+	meetings.TooManyGuests:             codes.InvalidArgument,
+	meetings.SignUpNotOpen:             codes.FailedPrecondition,
+	meetings.UnexpectedError:           codes.Internal,
+	users.UserNotFound:                 codes.NotFound,
+	users.GroupNotFound:                codes.NotFound,
+}
+
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if code, found := knownErrors[err]; found {
+		return status.Error(code, err.Error())
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+func fromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for known, code := range knownErrors {
+		if s.Code() == code && s.Message() == known.Error() {
+			return known
+		}
+	}
+	return err
+}
+
+func meetingToProto(meeting *meetings.Meeting) (*Meeting, error) {
+	t, err := ptypes.TimestampProto(meeting.Time)
+	if err != nil {
+		return nil, err
+	}
+	return &Meeting{
+		Id:         meeting.ID,
+		Time:       t,
+		Location:   meeting.Location,
+		Capacity:   int32(meeting.Capacity),
+		Closed:     meeting.Closed,
+		Cancelled:  meeting.Cancelled,
+		Recurrence: meeting.Recurrence,
+		Duration:   ptypes.DurationProto(meeting.Duration),
+		RsvpCutoff: ptypes.DurationProto(meeting.RSVPCutoff),
+		MaxGuests:  int32(meeting.MaxGuests),
+		CreatedAt:  timestampToProto(meeting.CreatedAt),
+		HostId:     meeting.HostID,
+		LocationId: meeting.LocationID,
+	}, nil
+}
+
+func meetingFromProto(meeting *Meeting) (*meetings.Meeting, error) {
+	t, err := ptypes.Timestamp(meeting.GetTime())
+	if err != nil {
+		return nil, err
+	}
+	duration, err := durationFromProto(meeting.GetDuration())
+	if err != nil {
+		return nil, err
+	}
+	rsvpCutoff, err := durationFromProto(meeting.GetRsvpCutoff())
+	if err != nil {
+		return nil, err
+	}
+	return &meetings.Meeting{
+		ID:         meeting.GetId(),
+		Time:       t.In(time.UTC),
+		Location:   meeting.GetLocation(),
+		Capacity:   int(meeting.GetCapacity()),
+		Closed:     meeting.GetClosed(),
+		Cancelled:  meeting.GetCancelled(),
+		Recurrence: meeting.GetRecurrence(),
+		Duration:   duration,
+		RSVPCutoff: rsvpCutoff,
+		MaxGuests:  int(meeting.GetMaxGuests()),
+		CreatedAt:  timestampFromProto(meeting.GetCreatedAt()),
+		HostID:     meeting.GetHostId(),
+		LocationID: meeting.GetLocationId(),
+	}, nil
+}
+
+// timestampToProto leaves zero times unset, so they are zero again once
+// read back.
+func timestampToProto(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
+
+func timestampFromProto(ts *timestamp.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return time.Time{}
+	}
+	return t.In(time.UTC)
+}
+
+func durationFromProto(d *duration.Duration) (time.Duration, error) {
+	if d == nil {
+		return 0, nil
+	}
+	return ptypes.Duration(d)
+}
+
+func attendeesToProto(attendees []*meetings.Attendee) *AttendeesResponse {
+	response := &AttendeesResponse{Attendees: make([]*Attendee, len(attendees))}
+	for i, attendee := range attendees {
+		response.Attendees[i] = attendeeToProto(attendee)
+	}
+	return response
+}
+
+func attendeesFromProto(response *AttendeesResponse) []*meetings.Attendee {
+	attendees := make([]*meetings.Attendee, len(response.GetAttendees()))
+	for i, attendee := range response.GetAttendees() {
+		attendees[i] = attendeeFromProto(attendee)
+	}
+	return attendees
+}
+
+func attendeeToProto(attendee *meetings.Attendee) *Attendee {
+	return &Attendee{
+		UserId:          attendee.UserID,
+		Amount:          int32(attendee.Amount),
+		Guests:          guestsToProto(attendee.Guests),
+		Maybe:           attendee.Maybe,
+		RsvpTime:        timestampToProto(attendee.RSVPTime),
+		CheckedIn:       attendee.CheckedIn,
+		GuestsCheckedIn: int32(attendee.GuestsCheckedIn),
+	}
+}
+
+func attendeeFromProto(attendee *Attendee) *meetings.Attendee {
+	return &meetings.Attendee{
+		UserID:          attendee.GetUserId(),
+		Amount:          int(attendee.GetAmount()),
+		Guests:          guestsFromProto(attendee.GetGuests()),
+		Maybe:           attendee.GetMaybe(),
+		RSVPTime:        timestampFromProto(attendee.GetRsvpTime()),
+		CheckedIn:       attendee.GetCheckedIn(),
+		GuestsCheckedIn: int(attendee.GetGuestsCheckedIn()),
+	}
+}
+
+func guestsToProto(guests []*meetings.Guest) []*Guest {
+	if len(guests) == 0 {
+		return nil
+	}
+	retval := make([]*Guest, len(guests))
+	for i, guest := range guests {
+		retval[i] = &Guest{Name: guest.Name, Handle: guest.Handle, UserId: guest.UserID}
+	}
+	return retval
+}
+
+func guestsFromProto(guests []*Guest) []*meetings.Guest {
+	if len(guests) == 0 {
+		return nil
+	}
+	retval := make([]*meetings.Guest, len(guests))
+	for i, guest := range guests {
+		retval[i] = &meetings.Guest{Name: guest.GetName(), Handle: guest.GetHandle(), UserID: guest.GetUserId()}
+	}
+	return retval
+}
+
+func userToProto(user *users.ExternalUser) *ExternalUser {
+	return &ExternalUser{Id: user.ID, Source: int32(user.Source), DisplayName: user.DisplayName}
+}
+
+func userFromProto(user *ExternalUser) *users.ExternalUser {
+	return &users.ExternalUser{ID: user.GetId(), Source: users.Source(user.GetSource()), DisplayName: user.GetDisplayName()}
+}
+
+func groupToProto(group *users.ExternalGroup) *ExternalGroup {
+	return &ExternalGroup{Id: group.ID, Source: int32(group.Source), Timezone: group.Timezone}
+}
+
+func groupFromProto(group *ExternalGroup) *users.ExternalGroup {
+	return &users.ExternalGroup{ID: group.GetId(), Source: users.Source(group.GetSource()), Timezone: group.GetTimezone()}
+}
@@ -0,0 +1,337 @@
+package rpc
+
+import (
+	"context"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	ftime "github.com/seppo0010/boardgamesorganizer/time"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC)
+
+func newTestClient(t *testing.T) (*Client, *Server, func()) {
+	return newTestClientWithOptions(t, nil, []grpc.DialOption{grpc.WithInsecure()})
+}
+
+func newTestClientWithOptions(t *testing.T, serverOpts []grpc.ServerOption, dialOpts []grpc.DialOption) (*Client, *Server, func()) {
+	s := &Server{Users: users.NewMemory()}
+	mf := meetings.NewFactory(NewWatchedMeetings(meetings.NewMemory().Inner, s))
+	mf.SetTimeFactory(&ftime.Fake{CurrentNow: testNow})
+	s.Meetings = mf
+	listener := bufconn.Listen(1024 * 1024)
+	g := grpc.NewServer(serverOpts...)
+	s.Register(g)
+	go g.Serve(listener)
+
+	c, err := Dial("bufnet", append(dialOpts, grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return listener.Dial()
+	}))...)
+	if err != nil {
+		t.Fatalf("cannot dial: %#v", err)
+	}
+	return c, s, func() {
+		c.Close()
+		g.Stop()
+	}
+}
+
+func TestMeetings(t *testing.T) {
+	assert := assert.New(t)
+	c, _, stop := newTestClient(t)
+	defer stop()
+	groupID := "ashf"
+
+	_, err := c.GetMeeting(groupID)
+	assert.Equal(err, meetings.NoActiveMeeting)
+
+	m := &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home", Capacity: 2, Recurrence: "FREQ=WEEKLY"}
+	assert.NoError(c.CreateMeeting(groupID, m))
+	assert.Equal(c.CreateMeeting(groupID, m), meetings.MeetingAlreadyActive)
+	m.CreatedAt = testNow
+
+	m2, err := c.GetMeeting(groupID)
+	assert.NoError(err)
+	assert.Equal(m, m2)
+
+	active, err := c.GetActiveMeetings()
+	assert.NoError(err)
+	assert.Equal(active, map[string]*meetings.Meeting{groupID: m})
+
+	assert.NoError(c.SetMeetingRecurrence(groupID, ""))
+	m.Recurrence = ""
+	m2, err = c.GetMeeting(groupID)
+	assert.NoError(err)
+	assert.Equal(m, m2)
+	assert.Equal(c.SetMeetingRecurrence("other", ""), meetings.NoActiveMeeting)
+
+	err = c.CreateMeeting("other", &meetings.Meeting{Time: time.Date(2019, 4, 2, 20, 3, 7, 0, time.UTC)})
+	assert.Equal(err, meetings.MeetingIsInThePast)
+
+	assert.NoError(c.CloseMeeting(groupID))
+	_, err = c.GetMeeting(groupID)
+	assert.Equal(err, meetings.NoActiveMeeting)
+	assert.Equal(c.DeleteMeeting(groupID), meetings.NoActiveMeeting)
+}
+
+func TestCancelMeeting(t *testing.T) {
+	assert := assert.New(t)
+	c, _, stop := newTestClient(t)
+	defer stop()
+	groupID := "ashf"
+
+	m := &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home"}
+	assert.NoError(c.CreateMeeting(groupID, m))
+	assert.NoError(c.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: "1", Amount: 1}))
+	assert.NoError(c.CancelMeeting(groupID))
+	assert.Equal(c.CancelMeeting(groupID), meetings.NoActiveMeeting)
+
+	all, err := c.GetMeetings(groupID)
+	assert.NoError(err)
+	assert.Equal(len(all), 1)
+	assert.Equal(all[0].ID, m.ID)
+	assert.True(all[0].Cancelled)
+
+	attendees, err := c.GetMeetingAttendeesByID(m.ID)
+	assert.NoError(err)
+	assert.Equal(attendees, []*meetings.Attendee{&meetings.Attendee{UserID: "1", Amount: 1, RSVPTime: testNow}})
+}
+
+func TestAttendees(t *testing.T) {
+	assert := assert.New(t)
+	c, _, stop := newTestClient(t)
+	defer stop()
+	groupID := "ashf"
+
+	m := &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home", Capacity: 2}
+	assert.NoError(c.CreateMeeting(groupID, m))
+
+	assert.NoError(c.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: "1", Amount: 1}))
+	assert.Equal(c.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: "1", Amount: 1}), meetings.UserAlreadyAttendsMeeting)
+	assert.Equal(c.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: "2", Amount: 2}), meetings.MeetingIsFull)
+
+	attendees, err := c.GetMeetingAttendees(groupID)
+	assert.NoError(err)
+	assert.Equal(attendees, []*meetings.Attendee{&meetings.Attendee{UserID: "1", Amount: 1, RSVPTime: testNow}})
+
+	assert.NoError(c.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: "2", Amount: 2, Maybe: true}))
+	attendees, err = c.GetMeetingAttendees(groupID)
+	assert.NoError(err)
+	assert.Equal(attendees, []*meetings.Attendee{&meetings.Attendee{UserID: "1", Amount: 1, RSVPTime: testNow}, &meetings.Attendee{UserID: "2", Amount: 2, Maybe: true, RSVPTime: testNow}})
+}
+
+func TestAttendeesData(t *testing.T) {
+	assert := assert.New(t)
+	c, _, stop := newTestClient(t)
+	defer stop()
+	groupID := "ashf"
+
+	assert.Equal(c.SetMeetingAttendeesData(groupID, []string{"a"}), meetings.NoActiveMeeting)
+	assert.NoError(c.CreateMeeting(groupID, &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC)}))
+
+	data := []string{}
+	assert.NoError(c.GetMeetingAttendeesData(groupID, &data))
+	assert.Equal(data, []string{})
+
+	assert.NoError(c.SetMeetingAttendeesData(groupID, []string{"hello", "world"}))
+	assert.NoError(c.GetMeetingAttendeesData(groupID, &data))
+	assert.Equal(data, []string{"hello", "world"})
+}
+
+func TestWatchRSVPs(t *testing.T) {
+	assert := assert.New(t)
+	c, s, stop := newTestClient(t)
+	defer stop()
+	groupID := "ashf"
+	rsvps := make(chan string, 2)
+	s.OnRSVP = func(groupID string) {
+		rsvps <- groupID
+	}
+	assert.NoError(c.CreateMeeting(groupID, &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC)}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates, err := c.WatchRSVPs(ctx, groupID)
+	assert.NoError(err)
+	for {
+		s.mutex.Lock()
+		watching := len(s.watchers[groupID]) > 0
+		s.mutex.Unlock()
+		if watching {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	assert.NoError(c.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: "1", Amount: 2}))
+	assert.NoError(c.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: "1", Amount: 0}))
+	assert.Equal(<-updates, &meetings.Attendee{UserID: "1", Amount: 2, RSVPTime: testNow})
+	assert.Equal(<-updates, &meetings.Attendee{UserID: "1", Amount: 0, RSVPTime: testNow})
+	assert.Equal(<-rsvps, groupID)
+	assert.Equal(<-rsvps, groupID)
+
+	// RSVPs made elsewhere through the same backend reach the stream too
+	mf := s.Meetings.(*meetings.Factory)
+	assert.NoError(mf.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: "2", Amount: 1, Maybe: true}))
+	assert.Equal(<-updates, &meetings.Attendee{UserID: "2", Amount: 1, Maybe: true, RSVPTime: testNow})
+	select {
+	case <-rsvps:
+		t.Fatal("OnRSVP is only for RSVPs made through the server")
+	default:
+	}
+}
+
+func TestUsers(t *testing.T) {
+	assert := assert.New(t)
+	c, _, stop := newTestClient(t)
+	defer stop()
+
+	user := &users.ExternalUser{ID: "ABC", Source: users.SourceTelegram, DisplayName: "alice"}
+	userID, err := c.GetOrCreateUser(user)
+	assert.NoError(err)
+	userID2, err := c.GetOrCreateUser(user)
+	assert.NoError(err)
+	assert.Equal(userID, userID2)
+
+	user2, err := c.GetExternalUser(userID)
+	assert.NoError(err)
+	assert.Equal(user, user2)
+	_, err = c.GetExternalUser("999")
+	assert.Equal(err, users.UserNotFound)
+
+	usersMap, err := c.GetUsers([]string{userID, "999"})
+	assert.NoError(err)
+	assert.Equal(usersMap, map[string]*users.ExternalUser{userID: user})
+
+	group := &users.ExternalGroup{ID: "-100", Source: users.SourceTelegram}
+	groupID, err := c.GetOrCreateGroup(group)
+	assert.NoError(err)
+	group2, err := c.GetExternalGroup(groupID)
+	assert.NoError(err)
+	assert.Equal(group, group2)
+	_, err = c.GetExternalGroup("999")
+	assert.Equal(err, users.GroupNotFound)
+
+	assert.NoError(c.SetGroupTimezone(groupID, "Europe/Madrid"))
+	group2, err = c.GetExternalGroup(groupID)
+	assert.NoError(err)
+	assert.Equal(group2.Timezone, "Europe/Madrid")
+	assert.Equal(c.SetGroupTimezone("999", "Europe/Madrid"), users.GroupNotFound)
+}
+
+func TestClientAsFactoryBackend(t *testing.T) {
+	assert := assert.New(t)
+	c, _, stop := newTestClient(t)
+	defer stop()
+	var _ users.Factory = c
+	f := meetings.NewFactory(c)
+	f.SetTimeFactory(&ftime.Fake{CurrentNow: time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC)})
+	groupID := "ashf"
+
+	assert.NoError(f.CreateMeeting(groupID, &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Capacity: 1}))
+	assert.NoError(f.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: "1", Amount: 1}))
+	assert.Equal(f.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: "2", Amount: 1}), meetings.MeetingIsFull)
+}
+
+func TestAttendeeGuests(t *testing.T) {
+	assert := assert.New(t)
+	c, _, stop := newTestClient(t)
+	defer stop()
+	groupID := "ashf"
+
+	assert.NoError(c.CreateMeeting(groupID, &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC)}))
+	assert.NoError(c.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: "1", Amount: 3}))
+	assert.Equal(c.SetAttendeeGuests(groupID, "2", []*meetings.Guest{&meetings.Guest{Name: "Bob"}}), meetings.UserDoesNotAttendMeeting)
+
+	guests := []*meetings.Guest{&meetings.Guest{Name: "Bob"}, &meetings.Guest{Name: "Carol", Handle: "carol", UserID: "5"}}
+	assert.NoError(c.SetAttendeeGuests(groupID, "1", guests))
+	attendees, err := c.GetMeetingAttendees(groupID)
+	assert.NoError(err)
+	assert.Equal(attendees, []*meetings.Attendee{&meetings.Attendee{UserID: "1", Amount: 3, Guests: guests, RSVPTime: testNow}})
+}
+
+func TestCheckIn(t *testing.T) {
+	assert := assert.New(t)
+	c, _, stop := newTestClient(t)
+	defer stop()
+	groupID := "ashf"
+
+	m := &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), HostID: "1"}
+	assert.NoError(c.CreateMeeting(groupID, m))
+	assert.NoError(c.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: "1", Amount: 3}))
+	assert.NoError(c.CloseMeeting(groupID))
+	assert.NoError(c.CheckIn(m.ID, "1", true, 2))
+	assert.Equal(c.CheckIn(m.ID, "2", true, 0), meetings.UserDoesNotAttendMeeting)
+
+	all, err := c.GetMeetings(groupID)
+	assert.NoError(err)
+	assert.Equal(all[0].HostID, "1")
+	attendees, err := c.GetMeetingAttendeesByID(m.ID)
+	assert.NoError(err)
+	assert.Equal(attendees, []*meetings.Attendee{&meetings.Attendee{UserID: "1", Amount: 3, RSVPTime: testNow, CheckedIn: true, GuestsCheckedIn: 2}})
+}
+
+func TestIsLoopback(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(IsLoopback("127.0.0.1:8080"))
+	assert.True(IsLoopback("[::1]:8080"))
+	assert.True(IsLoopback("localhost:8080"))
+	assert.False(IsLoopback(":8080"))
+	assert.False(IsLoopback("0.0.0.0:8080"))
+	assert.False(IsLoopback("10.0.0.1:8080"))
+	assert.False(IsLoopback("example.com:8080"))
+}
+
+func TestInsecureAddresses(t *testing.T) {
+	assert := assert.New(t)
+	_, err := ServerOptions(":8080", "", "", "")
+	assert.Equal(err, ErrInsecureListener)
+	_, err = ServerOptions(":8080", "s3cr3t", "", "")
+	assert.Equal(err, ErrInsecureListener)
+	_, err = ServerOptions("127.0.0.1:8080", "", "", "")
+	assert.NoError(err)
+
+	_, err = DialOptions("10.0.0.1:8080", "s3cr3t", "")
+	assert.Equal(err, ErrInsecureBackend)
+	_, err = Dial("10.0.0.1:8080")
+	assert.Equal(err, ErrInsecureBackend)
+	_, err = DialOptions("127.0.0.1:8080", "s3cr3t", "")
+	assert.NoError(err)
+}
+
+func TestToken(t *testing.T) {
+	assert := assert.New(t)
+	serverOpts, err := ServerOptions("127.0.0.1:8080", "s3cr3t", "", "")
+	assert.NoError(err)
+	groupID := "ashf"
+
+	for _, token := range []string{"", "wrong"} {
+		dialOpts, err := DialOptions("127.0.0.1:8080", token, "")
+		assert.NoError(err)
+		c, _, stop := newTestClientWithOptions(t, serverOpts, dialOpts)
+		_, err = c.GetMeeting(groupID)
+		assert.Equal(status.Code(err), codes.Unauthenticated)
+		updates, err := c.WatchRSVPs(context.Background(), groupID)
+		if err == nil {
+			_, open := <-updates
+			assert.False(open)
+		} else {
+			assert.Equal(status.Code(err), codes.Unauthenticated)
+		}
+		stop()
+	}
+
+	dialOpts, err := DialOptions("127.0.0.1:8080", "s3cr3t", "")
+	assert.NoError(err)
+	c, _, stop := newTestClientWithOptions(t, serverOpts, dialOpts)
+	defer stop()
+	_, err = c.GetMeeting(groupID)
+	assert.Equal(err, meetings.NoActiveMeeting)
+}
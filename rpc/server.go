@@ -0,0 +1,253 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"google.golang.org/grpc"
+	"sync"
+)
+
+// Server serves a meetings and users backend. WatchRSVPs only sees the
+// RSVPs made through Meetings when it is wrapped in WatchedMeetings.
+type Server struct {
+	Meetings meetings.Inner
+	Users    users.Factory
+	OnRSVP   func(groupID string)
+
+	mutex    sync.Mutex
+	watchers map[string][]chan *RSVPUpdate
+}
+
+func (s *Server) Register(g *grpc.Server) {
+	RegisterMeetingsServer(g, s)
+	RegisterUsersServer(g, s)
+}
+
+func (s *Server) publish(update *RSVPUpdate) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, watcher := range s.watchers[update.GroupId] {
+		select {
+		case watcher <- update:
+		default:
+		}
+	}
+}
+
+func (s *Server) watch(groupID string) chan *RSVPUpdate {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.watchers == nil {
+		s.watchers = map[string][]chan *RSVPUpdate{}
+	}
+	watcher := make(chan *RSVPUpdate, 16)
+	s.watchers[groupID] = append(s.watchers[groupID], watcher)
+	return watcher
+}
+
+func (s *Server) unwatch(groupID string, watcher chan *RSVPUpdate) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	watchers := s.watchers[groupID]
+	for i, w := range watchers {
+		if w == watcher {
+			s.watchers[groupID] = append(watchers[:i], watchers[i+1:]...)
+			break
+		}
+	}
+	if len(s.watchers[groupID]) == 0 {
+		delete(s.watchers, groupID)
+	}
+}
+
+func (s *Server) CreateMeeting(ctx context.Context, req *CreateMeetingRequest) (*IDResponse, error) {
+	meeting, err := meetingFromProto(req.GetMeeting())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	err = s.Meetings.CreateMeeting(req.GetGroupId(), meeting)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &IDResponse{Id: meeting.ID}, nil
+}
+
+func (s *Server) DeleteMeeting(ctx context.Context, req *GroupRequest) (*Empty, error) {
+	return &Empty{}, toStatus(s.Meetings.DeleteMeeting(req.GetGroupId()))
+}
+
+func (s *Server) GetMeeting(ctx context.Context, req *GroupRequest) (*Meeting, error) {
+	meeting, err := s.Meetings.GetMeeting(req.GetGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	m, err := meetingToProto(meeting)
+	return m, toStatus(err)
+}
+
+func (s *Server) SetMeetingAttendeesData(ctx context.Context, req *MeetingAttendeesData) (*Empty, error) {
+	return &Empty{}, toStatus(s.Meetings.SetMeetingAttendeesData(req.GetGroupId(), json.RawMessage(req.GetData())))
+}
+
+func (s *Server) GetMeetingAttendeesData(ctx context.Context, req *GroupRequest) (*MeetingAttendeesData, error) {
+	data := json.RawMessage{}
+	err := s.Meetings.GetMeetingAttendeesData(req.GetGroupId(), &data)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &MeetingAttendeesData{GroupId: req.GetGroupId(), Data: data}, nil
+}
+
+func (s *Server) UserRSVPMeeting(ctx context.Context, req *RSVPRequest) (*Empty, error) {
+	err := s.Meetings.UserRSVPMeeting(req.GetGroupId(), attendeeFromProto(req.GetAttendee()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if s.OnRSVP != nil {
+		s.OnRSVP(req.GetGroupId())
+	}
+	return &Empty{}, nil
+}
+
+func (s *Server) GetMeetingAttendees(ctx context.Context, req *GroupRequest) (*AttendeesResponse, error) {
+	attendees, err := s.Meetings.GetMeetingAttendees(req.GetGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return attendeesToProto(attendees), nil
+}
+
+func (s *Server) GetMeetingAttendeesByID(ctx context.Context, req *IDRequest) (*AttendeesResponse, error) {
+	attendees, err := s.Meetings.GetMeetingAttendeesByID(req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return attendeesToProto(attendees), nil
+}
+
+func (s *Server) CloseMeeting(ctx context.Context, req *GroupRequest) (*Empty, error) {
+	return &Empty{}, toStatus(s.Meetings.CloseMeeting(req.GetGroupId()))
+}
+
+func (s *Server) CancelMeeting(ctx context.Context, req *GroupRequest) (*Empty, error) {
+	return &Empty{}, toStatus(s.Meetings.CancelMeeting(req.GetGroupId()))
+}
+
+func (s *Server) GetMeetings(ctx context.Context, req *GroupRequest) (*MeetingsResponse, error) {
+	meetings, err := s.Meetings.GetMeetings(req.GetGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	response := &MeetingsResponse{Meetings: make([]*Meeting, len(meetings))}
+	for i, meeting := range meetings {
+		response.Meetings[i], err = meetingToProto(meeting)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+	}
+	return response, nil
+}
+
+func (s *Server) GetActiveMeetings(ctx context.Context, req *Empty) (*ActiveMeetingsResponse, error) {
+	meetings, err := s.Meetings.GetActiveMeetings()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	response := &ActiveMeetingsResponse{Meetings: map[string]*Meeting{}}
+	for groupID, meeting := range meetings {
+		response.Meetings[groupID], err = meetingToProto(meeting)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+	}
+	return response, nil
+}
+
+func (s *Server) SetMeetingRecurrence(ctx context.Context, req *MeetingRecurrenceRequest) (*Empty, error) {
+	return &Empty{}, toStatus(s.Meetings.SetMeetingRecurrence(req.GetGroupId(), req.GetRecurrence()))
+}
+
+func (s *Server) SetMeetingRSVPCutoff(ctx context.Context, req *MeetingRSVPCutoffRequest) (*Empty, error) {
+	cutoff, err := durationFromProto(req.GetRsvpCutoff())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &Empty{}, toStatus(s.Meetings.SetMeetingRSVPCutoff(req.GetGroupId(), cutoff))
+}
+
+func (s *Server) SetMeetingMaxGuests(ctx context.Context, req *MeetingMaxGuestsRequest) (*Empty, error) {
+	return &Empty{}, toStatus(s.Meetings.SetMeetingMaxGuests(req.GetGroupId(), int(req.GetMaxGuests())))
+}
+
+func (s *Server) SetAttendeeGuests(ctx context.Context, req *AttendeeGuestsRequest) (*Empty, error) {
+	return &Empty{}, toStatus(s.Meetings.SetAttendeeGuests(req.GetGroupId(), req.GetUserId(), guestsFromProto(req.GetGuests())))
+}
+
+func (s *Server) CheckIn(ctx context.Context, req *CheckInRequest) (*Empty, error) {
+	return &Empty{}, toStatus(s.Meetings.CheckIn(req.GetMeetingId(), req.GetUserId(), req.GetCheckedIn(), int(req.GetGuests())))
+}
+
+func (s *Server) WatchRSVPs(req *GroupRequest, stream Meetings_WatchRSVPsServer) error {
+	watcher := s.watch(req.GetGroupId())
+	defer s.unwatch(req.GetGroupId(), watcher)
+	for {
+		select {
+		case update := <-watcher:
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (s *Server) GetOrCreateUser(ctx context.Context, req *ExternalUser) (*IDResponse, error) {
+	id, err := s.Users.GetOrCreateUser(userFromProto(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &IDResponse{Id: id}, nil
+}
+
+func (s *Server) GetExternalUser(ctx context.Context, req *IDRequest) (*ExternalUser, error) {
+	user, err := s.Users.GetExternalUser(req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return userToProto(user), nil
+}
+
+func (s *Server) GetOrCreateGroup(ctx context.Context, req *ExternalGroup) (*IDResponse, error) {
+	id, err := s.Users.GetOrCreateGroup(groupFromProto(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &IDResponse{Id: id}, nil
+}
+
+func (s *Server) GetExternalGroup(ctx context.Context, req *IDRequest) (*ExternalGroup, error) {
+	group, err := s.Users.GetExternalGroup(req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return groupToProto(group), nil
+}
+
+func (s *Server) GetUsers(ctx context.Context, req *UsersRequest) (*UsersResponse, error) {
+	usersMap, err := s.Users.GetUsers(req.GetUserIds())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	response := &UsersResponse{Users: map[string]*ExternalUser{}}
+	for id, user := range usersMap {
+		response.Users[id] = userToProto(user)
+	}
+	return response, nil
+}
+
+func (s *Server) SetGroupTimezone(ctx context.Context, req *GroupTimezoneRequest) (*Empty, error) {
+	return &Empty{}, toStatus(s.Users.SetGroupTimezone(req.GetGroupId(), req.GetTimezone()))
+}
@@ -0,0 +1,25 @@
+package rpc
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+)
+
+// WatchedMeetings wraps a meetings backend and publishes every RSVP that
+// goes through it to the server's WatchRSVPs streams, wherever it was made.
+type WatchedMeetings struct {
+	meetings.Inner
+	Server *Server
+}
+
+func NewWatchedMeetings(inner meetings.Inner, server *Server) *WatchedMeetings {
+	return &WatchedMeetings{Inner: inner, Server: server}
+}
+
+func (m *WatchedMeetings) UserRSVPMeeting(groupID string, attendee *meetings.Attendee) error {
+	err := m.Inner.UserRSVPMeeting(groupID, attendee)
+	if err != nil {
+		return err
+	}
+	m.Server.publish(&RSVPUpdate{GroupId: groupID, Attendee: attendeeToProto(attendee)})
+	return nil
+}
@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	ftime "github.com/seppo0010/boardgamesorganizer/time"
+	"log"
+	"sync"
+	"time"
+)
+
+var DefaultReminders = []time.Duration{24 * time.Hour, 2 * time.Hour}
+var DefaultMaybeNudge = 24 * time.Hour
+
+// Scheduler closes meetings once they start, sends reminders ahead of them,
+// nudges the maybes and lets know when RSVPs close. It keeps no state of its
+// own besides which reminders it already sent, so after a restart the
+// schedule is rebuilt from the active meetings in storage. Reminders and
+// nudges that were due while it was not running, or before the meeting was
+// created, are skipped rather than risking a duplicate or a late one.
+type Scheduler struct {
+	Meetings   *meetings.Factory
+	Reminders  []time.Duration
+	OnReminder func(groupID string, meeting *meetings.Meeting, before time.Duration)
+	// OnRSVPClosed runs once per meeting with an RSVP cutoff after its
+	// deadline, including after a restart.
+	OnRSVPClosed func(groupID string, meeting *meetings.Meeting)
+	// MaybeNudge is how long before the RSVP deadline OnMaybeNudge runs, so
+	// people who answered maybe are asked to confirm.
+	MaybeNudge   time.Duration
+	OnMaybeNudge func(groupID string, meeting *meetings.Meeting)
+
+	mutex       sync.Mutex
+	timeFactory ftime.Factory
+	started     time.Time
+	sent        map[string]map[time.Duration]bool
+	rsvpClosed  map[string]bool
+	nudged      map[string]bool
+}
+
+func New(mf *meetings.Factory, reminders []time.Duration) *Scheduler {
+	return &Scheduler{
+		Meetings:    mf,
+		Reminders:   reminders,
+		MaybeNudge:  DefaultMaybeNudge,
+		timeFactory: ftime.NewReal(),
+		sent:        map[string]map[time.Duration]bool{},
+		rsvpClosed:  map[string]bool{},
+		nudged:      map[string]bool{},
+	}
+}
+
+func (s *Scheduler) SetTimeFactory(tf ftime.Factory) {
+	s.timeFactory = tf
+}
+
+func (s *Scheduler) Tick() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := s.timeFactory.Now()
+	if s.started.IsZero() {
+		s.started = now
+	}
+	active, err := s.Meetings.GetActiveMeetings()
+	if err != nil {
+		log.Printf("failed to get active meetings: %#v", err)
+		return
+	}
+	sent := map[string]map[time.Duration]bool{}
+	rsvpClosed := map[string]bool{}
+	nudged := map[string]bool{}
+	for groupID, meeting := range active {
+		if meeting.Time.Before(now) {
+			// closing goes through the factory so its hooks run
+			_, err := s.Meetings.GetMeeting(groupID)
+			if err != nil && err != meetings.NoActiveMeeting {
+				log.Printf("failed to close meeting: %#v", err)
+			}
+			continue
+		}
+		rsvpClosed[meeting.ID] = s.rsvpClosed[meeting.ID]
+		if meeting.RSVPCutoff > 0 && !now.Before(meeting.RSVPDeadline()) && !rsvpClosed[meeting.ID] {
+			rsvpClosed[meeting.ID] = true
+			if s.OnRSVPClosed != nil {
+				s.OnRSVPClosed(groupID, meeting)
+			}
+		}
+		// meetings created while running were seen since they were created,
+		// the rest since the scheduler started
+		since := s.started
+		if meeting.CreatedAt.After(since) {
+			since = meeting.CreatedAt
+		}
+		nudged[meeting.ID] = s.nudged[meeting.ID]
+		deadline := meeting.RSVPDeadline()
+		nudgeDue := deadline.Add(-s.MaybeNudge)
+		if s.MaybeNudge > 0 && !now.Before(nudgeDue) && now.Before(deadline) && nudgeDue.After(since) && !nudged[meeting.ID] {
+			nudged[meeting.ID] = true
+			if s.OnMaybeNudge != nil {
+				s.OnMaybeNudge(groupID, meeting)
+			}
+		}
+		sent[meeting.ID] = s.sent[meeting.ID]
+		if sent[meeting.ID] == nil {
+			sent[meeting.ID] = map[time.Duration]bool{}
+		}
+		for _, before := range s.Reminders {
+			due := meeting.Time.Add(-before)
+			if now.Before(due) || !due.After(since) || sent[meeting.ID][before] {
+				continue
+			}
+			sent[meeting.ID][before] = true
+			if s.OnReminder != nil {
+				s.OnReminder(groupID, meeting, before)
+			}
+		}
+	}
+	s.sent = sent
+	s.rsvpClosed = rsvpClosed
+	s.nudged = nudged
+}
+
+func (s *Scheduler) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := s.timeFactory.NewTicker(interval)
+	defer ticker.Stop()
+	s.Tick()
+	for {
+		select {
+		case <-ticker.C():
+			s.Tick()
+		case <-stop:
+			return
+		}
+	}
+}
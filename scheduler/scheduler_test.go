@@ -0,0 +1,200 @@
+package scheduler
+
+import (
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	ftime "github.com/seppo0010/boardgamesorganizer/time"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newTestScheduler(t *testing.T) (*Scheduler, *meetings.Factory, *ftime.Fake, *[]string) {
+	mf := meetings.NewMemory()
+	tf := &ftime.Fake{CurrentNow: time.Date(2019, 5, 1, 17, 0, 0, 0, time.UTC)}
+	mf.SetTimeFactory(tf)
+	s := New(mf, DefaultReminders)
+	s.SetTimeFactory(tf)
+	events := []string{}
+	s.OnReminder = func(groupID string, meeting *meetings.Meeting, before time.Duration) {
+		events = append(events, fmt.Sprintf("reminder %s %s %s", groupID, meeting.Location, before))
+	}
+	s.OnRSVPClosed = func(groupID string, meeting *meetings.Meeting) {
+		events = append(events, fmt.Sprintf("rsvp closed %s %s", groupID, meeting.Location))
+	}
+	mf.OnClose = func(groupID string, meeting *meetings.Meeting) {
+		events = append(events, fmt.Sprintf("close %s %s", groupID, meeting.Location))
+	}
+	return s, mf, tf, &events
+}
+
+func TestRemindersAndClose(t *testing.T) {
+	assert := assert.New(t)
+	s, mf, tf, events := newTestScheduler(t)
+	s.Tick()
+
+	assert.NoError(mf.CreateMeeting("1", &meetings.Meeting{Time: time.Date(2019, 5, 3, 20, 0, 0, 0, time.UTC), Location: "Home"}))
+	assert.NoError(mf.CreateMeeting("2", &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Bar"}))
+
+	tf.CurrentNow = time.Date(2019, 5, 1, 20, 0, 0, 0, time.UTC)
+	s.Tick()
+	assert.Equal(*events, []string{"reminder 2 Bar 24h0m0s"})
+
+	s.Tick()
+	assert.Equal(len(*events), 1)
+
+	tf.CurrentNow = time.Date(2019, 5, 2, 18, 30, 0, 0, time.UTC)
+	s.Tick()
+	assert.Equal((*events)[1:], []string{"reminder 2 Bar 2h0m0s"})
+
+	tf.CurrentNow = time.Date(2019, 5, 2, 20, 0, 1, 0, time.UTC)
+	s.Tick()
+	assert.ElementsMatch((*events)[2:], []string{"close 2 Bar", "reminder 1 Home 24h0m0s"})
+	_, err := mf.GetMeeting("2")
+	assert.Equal(err, meetings.NoActiveMeeting)
+
+	tf.CurrentNow = time.Date(2019, 5, 3, 21, 0, 0, 0, time.UTC)
+	s.Tick()
+	assert.Equal((*events)[4:], []string{"close 1 Home"})
+	active, err := mf.GetActiveMeetings()
+	assert.NoError(err)
+	assert.Equal(len(active), 0)
+}
+
+func TestRestartSkipsPastReminders(t *testing.T) {
+	assert := assert.New(t)
+	s, mf, tf, events := newTestScheduler(t)
+	assert.NoError(mf.CreateMeeting("1", &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home"}))
+
+	tf.CurrentNow = time.Date(2019, 5, 2, 10, 0, 0, 0, time.UTC)
+	s.Tick()
+	assert.Equal(len(*events), 0)
+
+	tf.CurrentNow = time.Date(2019, 5, 2, 18, 0, 0, 0, time.UTC)
+	s.Tick()
+	assert.Equal(*events, []string{"reminder 1 Home 2h0m0s"})
+
+	restarted := New(mf, DefaultReminders)
+	restarted.SetTimeFactory(tf)
+	restarted.OnReminder = s.OnReminder
+	restarted.Tick()
+	assert.Equal(len(*events), 1)
+
+	tf.CurrentNow = time.Date(2019, 5, 2, 20, 0, 1, 0, time.UTC)
+	restarted.Tick()
+	assert.Equal((*events)[1:], []string{"close 1 Home"})
+}
+
+func TestRSVPClosed(t *testing.T) {
+	assert := assert.New(t)
+	s, mf, tf, events := newTestScheduler(t)
+	s.Reminders = nil
+	assert.NoError(mf.CreateMeeting("1", &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home", RSVPCutoff: 6 * time.Hour}))
+	assert.NoError(mf.CreateMeeting("2", &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Bar"}))
+
+	tf.CurrentNow = time.Date(2019, 5, 2, 13, 59, 0, 0, time.UTC)
+	s.Tick()
+	assert.Equal(len(*events), 0)
+
+	tf.CurrentNow = time.Date(2019, 5, 2, 14, 0, 0, 0, time.UTC)
+	s.Tick()
+	s.Tick()
+	assert.Equal(*events, []string{"rsvp closed 1 Home"})
+
+	// the message still has to lose its buttons if the deadline passed
+	// while the scheduler was not running
+	restarted := New(mf, nil)
+	restarted.SetTimeFactory(tf)
+	restarted.OnRSVPClosed = s.OnRSVPClosed
+	restarted.Tick()
+	assert.Equal((*events)[1:], []string{"rsvp closed 1 Home"})
+}
+
+func TestRun(t *testing.T) {
+	assert := assert.New(t)
+	mf := meetings.NewMemory()
+	tf := &ftime.Fake{CurrentNow: time.Date(2019, 5, 1, 17, 0, 0, 0, time.UTC)}
+	mf.SetTimeFactory(tf)
+	s := New(mf, []time.Duration{time.Hour})
+	s.SetTimeFactory(tf)
+	reminders := make(chan string, 1)
+	s.OnReminder = func(groupID string, meeting *meetings.Meeting, before time.Duration) {
+		reminders <- groupID
+	}
+	assert.NoError(mf.CreateMeeting("1", &meetings.Meeting{Time: time.Date(2019, 5, 1, 19, 0, 0, 0, time.UTC), Location: "Home"}))
+
+	stop := make(chan struct{})
+	done := make(chan bool)
+	go func() {
+		s.Run(time.Minute, stop)
+		done <- true
+	}()
+	tf.BlockUntil(1)
+	for i := 0; i < 59; i++ {
+		tf.AdvaceTime(time.Minute)
+	}
+	select {
+	case <-reminders:
+		t.Fatal("reminder sent too early")
+	default:
+	}
+	tf.AdvaceTime(time.Minute)
+	assert.Equal(<-reminders, "1")
+	close(stop)
+	<-done
+}
+
+func TestMaybeNudge(t *testing.T) {
+	assert := assert.New(t)
+	s, mf, tf, events := newTestScheduler(t)
+	s.Reminders = nil
+	s.OnRSVPClosed = nil
+	s.OnMaybeNudge = func(groupID string, meeting *meetings.Meeting) {
+		*events = append(*events, fmt.Sprintf("nudge %s %s", groupID, meeting.Location))
+	}
+	s.Tick()
+	assert.NoError(mf.CreateMeeting("1", &meetings.Meeting{Time: time.Date(2019, 5, 3, 20, 0, 0, 0, time.UTC), Location: "Home", RSVPCutoff: 6 * time.Hour}))
+	assert.NoError(mf.CreateMeeting("2", &meetings.Meeting{Time: time.Date(2019, 5, 3, 20, 0, 0, 0, time.UTC), Location: "Bar"}))
+
+	tf.CurrentNow = time.Date(2019, 5, 2, 13, 59, 0, 0, time.UTC)
+	s.Tick()
+	assert.Equal(len(*events), 0)
+
+	tf.CurrentNow = time.Date(2019, 5, 2, 14, 0, 0, 0, time.UTC)
+	s.Tick()
+	s.Tick()
+	assert.Equal(*events, []string{"nudge 1 Home"})
+
+	tf.CurrentNow = time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC)
+	s.Tick()
+	assert.Equal((*events)[1:], []string{"nudge 2 Bar"})
+
+	// nothing to confirm once RSVPs are closed
+	restarted := New(mf, nil)
+	restarted.SetTimeFactory(tf)
+	restarted.OnMaybeNudge = s.OnMaybeNudge
+	restarted.started = time.Date(2019, 5, 1, 17, 0, 0, 0, time.UTC)
+	tf.CurrentNow = time.Date(2019, 5, 3, 15, 0, 0, 0, time.UTC)
+	restarted.Tick()
+	assert.Equal((*events)[2:], []string{"nudge 2 Bar"})
+}
+
+func TestMeetingCreatedInsideWindow(t *testing.T) {
+	assert := assert.New(t)
+	s, mf, tf, events := newTestScheduler(t)
+	s.OnRSVPClosed = nil
+	s.OnMaybeNudge = func(groupID string, meeting *meetings.Meeting) {
+		*events = append(*events, fmt.Sprintf("nudge %s %s", groupID, meeting.Location))
+	}
+	s.Tick()
+
+	// a day ahead is already past the 24h reminder and the nudge
+	tf.CurrentNow = time.Date(2019, 5, 1, 21, 0, 0, 0, time.UTC)
+	assert.NoError(mf.CreateMeeting("1", &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 0, 0, 0, time.UTC), Location: "Home", RSVPCutoff: 2 * time.Hour}))
+	s.Tick()
+	assert.Equal(len(*events), 0)
+
+	tf.CurrentNow = time.Date(2019, 5, 2, 18, 0, 0, 0, time.UTC)
+	s.Tick()
+	assert.Equal(*events, []string{"reminder 1 Home 2h0m0s"})
+}
@@ -0,0 +1,203 @@
+package stats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Meeting is a meeting of the group along with who said they would go.
+type Meeting struct {
+	Meeting   *meetings.Meeting
+	Attendees []*meetings.Attendee
+	// Present holds the user IDs of who actually came, nil when it is not
+	// known
+	Present map[string]bool
+}
+
+type Member struct {
+	UserID string
+	// RSVPs counts the held meetings the member said they would go to
+	RSVPs int
+	// Attended and NoShows only count meetings where presence is known
+	Attended int
+	NoShows  int
+}
+
+// NoShowRate is the fraction of known RSVPs the member did not show up to.
+func (m *Member) NoShowRate() float64 {
+	if m.Attended+m.NoShows == 0 {
+		return 0
+	}
+	return float64(m.NoShows) / float64(m.Attended+m.NoShows)
+}
+
+type Count struct {
+	Name     string
+	Meetings int
+}
+
+type Report struct {
+	// Meetings is how many meetings were held, Cancelled how many were not
+	Meetings  int
+	Cancelled int
+	// Members is sorted by RSVPs, the most first
+	Members []*Member
+	// AverageSize counts the people going, guests included, per meeting
+	AverageSize float64
+	// CapacityUse is the average fraction of the capacity taken, among
+	// meetings with one
+	CapacityUse float64
+	// Locations and Weekdays are sorted by meetings, the most first
+	Locations []*Count
+	Weekdays  []*Count
+	// Filled is how many meetings got full, and AverageFillTime how long
+	// they took to since they were created, when known
+	Filled          int
+	AverageFillTime time.Duration
+}
+
+// fillTime returns how long the meeting took to get full, or false if it
+// did not or it is not known.
+func fillTime(m *Meeting) (time.Duration, bool) {
+	if m.Meeting.Capacity <= 0 || m.Meeting.CreatedAt.IsZero() {
+		return 0, false
+	}
+	going := []*meetings.Attendee{}
+	for _, attendee := range m.Attendees {
+		if attendee.Going() {
+			going = append(going, attendee)
+		}
+	}
+	sort.SliceStable(going, func(i, j int) bool {
+		return going[i].RSVPTime.Before(going[j].RSVPTime)
+	})
+	taken := 0
+	for _, attendee := range going {
+		taken += attendee.Amount
+		if taken >= m.Meeting.Capacity {
+			if attendee.RSVPTime.IsZero() {
+				return 0, false
+			}
+			return attendee.RSVPTime.Sub(m.Meeting.CreatedAt), true
+		}
+	}
+	return 0, false
+}
+
+func sortedCounts(counts map[string]int) []*Count {
+	list := []*Count{}
+	for name, meetings := range counts {
+		list = append(list, &Count{Name: name, Meetings: meetings})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Meetings != list[j].Meetings {
+			return list[i].Meetings > list[j].Meetings
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// Compute reports on the closed meetings, with weekdays in the given
+// location. Meetings that are not closed yet are left out.
+func Compute(list []*Meeting, location *time.Location) *Report {
+	report := &Report{Members: []*Member{}}
+	members := map[string]*Member{}
+	locations := map[string]int{}
+	weekdays := map[string]int{}
+	people, withCapacity := 0, 0
+	capacityUse := 0.0
+	var fillTimes time.Duration
+	knownFillTimes := 0
+	for _, m := range list {
+		if !m.Meeting.Closed {
+			continue
+		}
+		if m.Meeting.Cancelled {
+			report.Cancelled++
+			continue
+		}
+		report.Meetings++
+		locations[m.Meeting.Location]++
+		weekdays[m.Meeting.Time.In(location).Weekday().String()]++
+		size := 0
+		for _, attendee := range m.Attendees {
+			if !attendee.Going() {
+				continue
+			}
+			size += attendee.Amount
+			member, found := members[attendee.UserID]
+			if !found {
+				member = &Member{UserID: attendee.UserID}
+				members[attendee.UserID] = member
+				report.Members = append(report.Members, member)
+			}
+			member.RSVPs++
+			if m.Present == nil {
+				continue
+			}
+			if m.Present[attendee.UserID] {
+				member.Attended++
+			} else {
+				member.NoShows++
+			}
+		}
+		people += size
+		if m.Meeting.Capacity > 0 {
+			withCapacity++
+			capacityUse += float64(size) / float64(m.Meeting.Capacity)
+			if size >= m.Meeting.Capacity {
+				report.Filled++
+			}
+			if d, ok := fillTime(m); ok {
+				fillTimes += d
+				knownFillTimes++
+			}
+		}
+	}
+	sort.SliceStable(report.Members, func(i, j int) bool {
+		if report.Members[i].RSVPs != report.Members[j].RSVPs {
+			return report.Members[i].RSVPs > report.Members[j].RSVPs
+		}
+		return report.Members[i].UserID < report.Members[j].UserID
+	})
+	if report.Meetings > 0 {
+		report.AverageSize = float64(people) / float64(report.Meetings)
+	}
+	if withCapacity > 0 {
+		report.CapacityUse = capacityUse / float64(withCapacity)
+	}
+	if knownFillTimes > 0 {
+		report.AverageFillTime = fillTimes / time.Duration(knownFillTimes)
+	}
+	report.Locations = sortedCounts(locations)
+	report.Weekdays = sortedCounts(weekdays)
+	return report
+}
+
+// WriteCSV writes one row per member, named after the given names or by
+// their user ID.
+func (r *Report) WriteCSV(w io.Writer, names map[string]string) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"member", "rsvps", "attended", "no_shows", "no_show_rate"})
+	for _, member := range r.Members {
+		name, found := names[member.UserID]
+		if !found {
+			name = member.UserID
+		}
+		writer.Write([]string{
+			name,
+			strconv.Itoa(member.RSVPs),
+			strconv.Itoa(member.Attended),
+			strconv.Itoa(member.NoShows),
+			fmt.Sprintf("%.2f", member.NoShowRate()),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,72 @@
+package stats
+
+import (
+	"bytes"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCompute(t *testing.T) {
+	assert := assert.New(t)
+	created := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	list := []*Meeting{
+		&Meeting{
+			// a Thursday evening, Friday in UTC
+			Meeting: &meetings.Meeting{Time: time.Date(2019, 6, 7, 1, 0, 0, 0, time.UTC), Location: "Home", Capacity: 4, Closed: true, CreatedAt: created},
+			Attendees: []*meetings.Attendee{
+				&meetings.Attendee{UserID: "1", Amount: 2, RSVPTime: created.Add(3 * time.Hour)},
+				&meetings.Attendee{UserID: "2", Amount: 1, RSVPTime: created.Add(time.Hour)},
+				&meetings.Attendee{UserID: "3", Amount: 1, RSVPTime: created.Add(5 * time.Hour)},
+				&meetings.Attendee{UserID: "4", Amount: 1, Maybe: true, RSVPTime: created.Add(2 * time.Hour)},
+			},
+			Present: map[string]bool{"1": true, "2": true},
+		},
+		&Meeting{
+			Meeting: &meetings.Meeting{Time: time.Date(2019, 6, 14, 1, 0, 0, 0, time.UTC), Location: "Bar", Capacity: 8, Closed: true},
+			Attendees: []*meetings.Attendee{
+				&meetings.Attendee{UserID: "1", Amount: 1},
+				&meetings.Attendee{UserID: "3", Amount: 1},
+			},
+		},
+		&Meeting{
+			Meeting:   &meetings.Meeting{Time: time.Date(2019, 6, 16, 18, 0, 0, 0, time.UTC), Location: "Home", Closed: true},
+			Attendees: []*meetings.Attendee{&meetings.Attendee{UserID: "1", Amount: 2}},
+		},
+		&Meeting{
+			Meeting:   &meetings.Meeting{Time: time.Date(2019, 6, 21, 1, 0, 0, 0, time.UTC), Location: "Bar", Closed: true, Cancelled: true},
+			Attendees: []*meetings.Attendee{&meetings.Attendee{UserID: "2", Amount: 1}},
+		},
+		&Meeting{
+			Meeting:   &meetings.Meeting{Time: time.Date(2019, 6, 28, 1, 0, 0, 0, time.UTC), Location: "Bar"},
+			Attendees: []*meetings.Attendee{&meetings.Attendee{UserID: "2", Amount: 1}},
+		},
+	}
+	report := Compute(list, time.FixedZone("UTC-3", -3*60*60))
+	assert.Equal(report.Meetings, 3)
+	assert.Equal(report.Cancelled, 1)
+	assert.Equal(report.Members, []*Member{
+		&Member{UserID: "1", RSVPs: 3, Attended: 1},
+		&Member{UserID: "3", RSVPs: 2, NoShows: 1},
+		&Member{UserID: "2", RSVPs: 1, Attended: 1},
+	})
+	assert.Equal(report.Members[1].NoShowRate(), 1.0)
+	assert.Equal(report.Members[0].NoShowRate(), 0.0)
+	assert.InDelta(report.AverageSize, 8.0/3, 0.001)
+	assert.InDelta(report.CapacityUse, (4.0/4+2.0/8)/2, 0.001)
+	assert.Equal(report.Locations, []*Count{&Count{Name: "Home", Meetings: 2}, &Count{Name: "Bar", Meetings: 1}})
+	assert.Equal(report.Weekdays, []*Count{&Count{Name: "Thursday", Meetings: 2}, &Count{Name: "Sunday", Meetings: 1}})
+	assert.Equal(report.Filled, 1)
+	assert.Equal(report.AverageFillTime, 5*time.Hour)
+
+	buffer := &bytes.Buffer{}
+	assert.NoError(report.WriteCSV(buffer, map[string]string{"1": "alice", "2": "bob, jr"}))
+	assert.Equal(buffer.String(), "member,rsvps,attended,no_shows,no_show_rate\nalice,3,1,0,0.00\n3,2,0,1,1.00\n\"bob, jr\",1,1,0,0.00\n")
+}
+
+func TestComputeEmpty(t *testing.T) {
+	assert := assert.New(t)
+	report := Compute([]*Meeting{}, time.UTC)
+	assert.Equal(report, &Report{Members: []*Member{}, Locations: []*Count{}, Weekdays: []*Count{}})
+}
@@ -0,0 +1,273 @@
+package tables
+
+import (
+	"errors"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"sort"
+)
+
+var NoSplit = errors.New("The attendees do not fit in the nominated games")
+
+// maxGames bounds how many of the most voted games are tried, since every
+// combination of them is. Each combination only takes two matchings.
+const maxGames = 10
+
+type Game struct {
+	ID         string
+	Title      string
+	MinPlayers int
+	MaxPlayers int
+	// Votes holds the user IDs of the voters
+	Votes []string
+}
+
+func (g *Game) hasVote(userID string) bool {
+	for _, vote := range g.Votes {
+		if vote == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Seat is taken by an attendee or, with Guest set, by one of their guests.
+type Seat struct {
+	UserID string
+	Guest  bool
+}
+
+type Table struct {
+	Game  *Game
+	Seats []*Seat
+}
+
+type split struct {
+	games     []*Game
+	sizes     []int
+	satisfied int
+	votes     int
+}
+
+func (s *split) betterThan(other *split) bool {
+	if other == nil {
+		return true
+	}
+	if s.satisfied != other.satisfied {
+		return s.satisfied > other.satisfied
+	}
+	if s.votes != other.votes {
+		return s.votes > other.votes
+	}
+	return len(s.games) < len(other.games)
+}
+
+// Split proposes tables for the attendees that are going, one game each and
+// every one of them within the game's player count. Among the possible
+// splits it picks the one that seats the most people at a game they voted
+// for, then the one with the most voted games, then the one with fewer
+// tables. Games with no known player count are left out. The result only
+// depends on the order of its arguments.
+func Split(attendees []*meetings.Attendee, games []*Game) ([]*Table, error) {
+	players := 0
+	voters := []string{}
+	for _, attendee := range attendees {
+		if !attendee.Going() {
+			continue
+		}
+		players += attendee.Amount
+		voters = append(voters, attendee.UserID)
+	}
+	candidates := []*Game{}
+	for _, game := range games {
+		if game.MinPlayers > 0 && game.MaxPlayers >= game.MinPlayers {
+			candidates = append(candidates, game)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return len(candidates[i].Votes) > len(candidates[j].Votes)
+	})
+	if len(candidates) > maxGames {
+		candidates = candidates[:maxGames]
+	}
+
+	var best *split
+	for mask := 1; mask < 1<<uint(len(candidates)); mask++ {
+		chosen := []*Game{}
+		minPlayers, maxPlayers, votes := 0, 0, 0
+		for i, game := range candidates {
+			if mask&(1<<uint(i)) != 0 {
+				chosen = append(chosen, game)
+				minPlayers += game.MinPlayers
+				maxPlayers += game.MaxPlayers
+				votes += len(game.Votes)
+			}
+		}
+		if players < minPlayers || players > maxPlayers {
+			continue
+		}
+		s := &split{games: chosen, votes: votes}
+		s.sizes, s.satisfied = bestSizes(voters, chosen, players-minPlayers)
+		if s.betterThan(best) {
+			best = s
+		}
+	}
+	if best == nil {
+		return nil, NoSplit
+	}
+	return seat(attendees, best), nil
+}
+
+// bestSizes picks how many players sit at each game so that the most voters
+// get a game they voted for, given that spare players can be added to the
+// tables beyond their minimum. Voters are first matched filling only the
+// minimum seats, which costs nothing, and then up to spare more are matched
+// anywhere there is room. Since every maximal matching extends any smaller
+// one, this reaches the best possible.
+func bestSizes(voters []string, games []*Game, spare int) ([]int, int) {
+	m := newMatcher(games)
+	minSizes := make([]int, len(games))
+	maxSizes := make([]int, len(games))
+	for i, game := range games {
+		minSizes[i] = game.MinPlayers
+		maxSizes[i] = game.MaxPlayers
+	}
+	m.fill(voters, minSizes, -1)
+	m.fill(voters, maxSizes, spare)
+	sizes := make([]int, len(games))
+	for i, game := range games {
+		sizes[i] = game.MinPlayers
+		if len(m.atTable[i]) > sizes[i] {
+			spare -= len(m.atTable[i]) - sizes[i]
+			sizes[i] = len(m.atTable[i])
+		}
+	}
+	// the rest go to the last tables, so earlier ones stay small
+	for i := len(games) - 1; i >= 0 && spare > 0; i-- {
+		room := games[i].MaxPlayers - sizes[i]
+		if room > spare {
+			room = spare
+		}
+		sizes[i] += room
+		spare -= room
+	}
+	return sizes, len(m.seated)
+}
+
+// matcher seats voters at a game they voted for, moving others to another
+// game they voted for to make room when needed.
+type matcher struct {
+	games   []*Game
+	atTable [][]string
+	seated  map[string]int
+}
+
+func newMatcher(games []*Game) *matcher {
+	return &matcher{games: games, atTable: make([][]string, len(games)), seated: map[string]int{}}
+}
+
+func (m *matcher) augment(voter string, sizes []int, visited []bool) bool {
+	for i, game := range m.games {
+		if visited[i] || !game.hasVote(voter) {
+			continue
+		}
+		visited[i] = true
+		if len(m.atTable[i]) < sizes[i] {
+			m.atTable[i] = append(m.atTable[i], voter)
+			m.seated[voter] = i
+			return true
+		}
+		for j, other := range m.atTable[i] {
+			if m.augment(other, sizes, visited) {
+				m.atTable[i][j] = voter
+				m.seated[voter] = i
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fill seats up to limit more voters, or as many as possible when limit is
+// negative, without going over the sizes
+func (m *matcher) fill(voters []string, sizes []int, limit int) {
+	for _, voter := range voters {
+		if limit == 0 {
+			return
+		}
+		if _, found := m.seated[voter]; found {
+			continue
+		}
+		if m.augment(voter, sizes, make([]bool, len(m.games))) {
+			limit--
+		}
+	}
+}
+
+// match seats as many voters as possible at a game they voted for, without
+// going over the sizes. It returns the table of each matched voter.
+func match(voters []string, games []*Game, sizes []int) map[string]int {
+	m := newMatcher(games)
+	m.fill(voters, sizes, -1)
+	return m.seated
+}
+
+func seat(attendees []*meetings.Attendee, s *split) []*Table {
+	tables := make([]*Table, len(s.games))
+	for i, game := range s.games {
+		tables[i] = &Table{Game: game, Seats: []*Seat{}}
+	}
+	freeSeats := func(i int) int {
+		return s.sizes[i] - len(tables[i].Seats)
+	}
+	// the first table with room for the given people, or at least for one
+	tableFor := func(people int) int {
+		first := -1
+		for i := range tables {
+			if freeSeats(i) >= people {
+				return i
+			}
+			if first == -1 && freeSeats(i) > 0 {
+				first = i
+			}
+		}
+		return first
+	}
+	sit := func(i int, attendee *meetings.Attendee, guest bool) {
+		tables[i].Seats = append(tables[i].Seats, &Seat{UserID: attendee.UserID, Guest: guest})
+	}
+	// guests sit with whoever brought them when there is room
+	sitGuests := func(i int, attendee *meetings.Attendee) {
+		for guest := 1; guest < attendee.Amount; guest++ {
+			if freeSeats(i) == 0 {
+				i = tableFor(1)
+			}
+			sit(i, attendee, true)
+		}
+	}
+	voters := []string{}
+	for _, attendee := range attendees {
+		if attendee.Going() {
+			voters = append(voters, attendee.UserID)
+		}
+	}
+	matched := match(voters, s.games, s.sizes)
+	for _, attendee := range attendees {
+		if i, found := matched[attendee.UserID]; found && attendee.Going() {
+			sit(i, attendee, false)
+		}
+	}
+	for _, attendee := range attendees {
+		if i, found := matched[attendee.UserID]; found && attendee.Going() {
+			sitGuests(i, attendee)
+		}
+	}
+	for _, attendee := range attendees {
+		if _, found := matched[attendee.UserID]; found || !attendee.Going() {
+			continue
+		}
+		i := tableFor(attendee.Amount)
+		sit(i, attendee, false)
+		sitGuests(i, attendee)
+	}
+	return tables
+}
@@ -0,0 +1,139 @@
+package tables
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func attendees(amounts ...int) []*meetings.Attendee {
+	list := make([]*meetings.Attendee, len(amounts))
+	for i, amount := range amounts {
+		list[i] = &meetings.Attendee{UserID: string(rune('a' + i)), Amount: amount}
+	}
+	return list
+}
+
+func seats(table *Table) []string {
+	list := make([]string, len(table.Seats))
+	for i, seat := range table.Seats {
+		list[i] = seat.UserID
+		if seat.Guest {
+			list[i] += "+"
+		}
+	}
+	return list
+}
+
+func TestSplitFollowsVotes(t *testing.T) {
+	assert := assert.New(t)
+	catan := &Game{ID: "1", Title: "Catan", MinPlayers: 3, MaxPlayers: 4, Votes: []string{"a", "b", "c"}}
+	azul := &Game{ID: "2", Title: "Azul", MinPlayers: 2, MaxPlayers: 4, Votes: []string{"d", "e", "a"}}
+	chess := &Game{ID: "3", Title: "Chess", MinPlayers: 2, MaxPlayers: 2}
+	tables, err := Split(attendees(1, 1, 1, 1, 1, 1), []*Game{chess, catan, azul})
+	assert.NoError(err)
+	assert.Equal(len(tables), 2)
+	assert.Equal(tables[0].Game, catan)
+	assert.Equal(seats(tables[0]), []string{"a", "b", "c"})
+	assert.Equal(tables[1].Game, azul)
+	assert.Equal(seats(tables[1]), []string{"d", "e", "f"})
+}
+
+func TestSplitEleven(t *testing.T) {
+	assert := assert.New(t)
+	games := []*Game{
+		&Game{ID: "1", Title: "Catan", MinPlayers: 3, MaxPlayers: 4, Votes: []string{"a", "b", "c", "d", "e"}},
+		&Game{ID: "2", Title: "Wingspan", MinPlayers: 1, MaxPlayers: 5, Votes: []string{"e", "f", "g"}},
+		&Game{ID: "3", Title: "Codenames", MinPlayers: 4, MaxPlayers: 8, Votes: []string{"h"}},
+		&Game{ID: "4", Title: "Free text"},
+	}
+	// three people bring guests: 8 attendees and 3 guests
+	tables, err := Split(attendees(1, 1, 2, 1, 1, 1, 2, 2), games)
+	assert.NoError(err)
+	total := 0
+	satisfied := 0
+	for _, table := range tables {
+		assert.True(len(table.Seats) >= table.Game.MinPlayers && len(table.Seats) <= table.Game.MaxPlayers)
+		total += len(table.Seats)
+		for _, seat := range table.Seats {
+			if !seat.Guest && table.Game.hasVote(seat.UserID) {
+				satisfied++
+			}
+		}
+	}
+	assert.Equal(total, 11)
+	assert.Equal(satisfied, 8)
+	assert.Equal(len(tables), 3)
+	assert.Equal(seats(tables[0]), []string{"a", "b", "c", "d"})
+	assert.Equal(seats(tables[1]), []string{"e", "f", "g"})
+	assert.Equal(seats(tables[2]), []string{"h", "c+", "g+", "h+"})
+
+	again, err := Split(attendees(1, 1, 2, 1, 1, 1, 2, 2), games)
+	assert.NoError(err)
+	assert.Equal(again, tables)
+}
+
+func TestSplitGuestsSitWithHost(t *testing.T) {
+	assert := assert.New(t)
+	azul := &Game{ID: "1", Title: "Azul", MinPlayers: 2, MaxPlayers: 4}
+	patchwork := &Game{ID: "2", Title: "Patchwork", MinPlayers: 2, MaxPlayers: 2}
+	tables, err := Split(attendees(1, 1, 1, 3), []*Game{azul, patchwork})
+	assert.NoError(err)
+	assert.Equal(seats(tables[0]), []string{"a", "b", "c", "d"})
+	assert.Equal(seats(tables[1]), []string{"d+", "d+"})
+}
+
+func TestSplitIgnoresMaybes(t *testing.T) {
+	assert := assert.New(t)
+	chess := &Game{ID: "1", Title: "Chess", MinPlayers: 2, MaxPlayers: 2}
+	list := attendees(1, 1, 1)
+	list[2].Maybe = true
+	tables, err := Split(list, []*Game{chess})
+	assert.NoError(err)
+	assert.Equal(seats(tables[0]), []string{"a", "b"})
+}
+
+func TestSplitDoesNotFit(t *testing.T) {
+	assert := assert.New(t)
+	chess := &Game{ID: "1", Title: "Chess", MinPlayers: 2, MaxPlayers: 2}
+	_, err := Split(attendees(1, 1, 1), []*Game{chess})
+	assert.Equal(err, NoSplit)
+	_, err = Split(attendees(), []*Game{chess})
+	assert.Equal(err, NoSplit)
+	_, err = Split(attendees(1, 1), []*Game{&Game{Title: "Free text"}})
+	assert.Equal(err, NoSplit)
+}
+
+func TestSplitManyGames(t *testing.T) {
+	assert := assert.New(t)
+	list := make([]int, 24)
+	for i := range list {
+		list[i] = 1
+	}
+	going := attendees(list...)
+	games := make([]*Game, 10)
+	for i := range games {
+		games[i] = &Game{ID: string(rune('0' + i)), Title: "Game", MinPlayers: 1, MaxPlayers: 8}
+		for j := i; j < len(going); j += 3 {
+			games[i].Votes = append(games[i].Votes, going[j].UserID)
+		}
+	}
+	tables, err := Split(going, games)
+	assert.NoError(err)
+	got := map[string][]string{}
+	for _, table := range tables {
+		got[table.Game.ID] = seats(table)
+	}
+	assert.Equal(got, map[string][]string{
+		"0": {"a", "g", "j", "m", "v"},
+		"1": {"b", "h", "k", "n", "q", "w"},
+		"2": {"c", "i", "l", "o", "r", "x"},
+		"3": {"d"},
+		"4": {"e"},
+		"5": {"f"},
+		"6": {"s"},
+		"7": {"t"},
+		"8": {"u"},
+		"9": {"p"},
+	})
+}
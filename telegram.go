@@ -3,45 +3,135 @@ package main
 import (
 	"errors"
 	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/calendar"
+	"github.com/seppo0010/boardgamesorganizer/dates"
+	"github.com/seppo0010/boardgamesorganizer/email"
+	"github.com/seppo0010/boardgamesorganizer/games"
+	"github.com/seppo0010/boardgamesorganizer/locations"
 	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/ratings"
+	"github.com/seppo0010/boardgamesorganizer/recurrence"
+	ftime "github.com/seppo0010/boardgamesorganizer/time"
 	"github.com/seppo0010/boardgamesorganizer/users"
 	tb "gopkg.in/tucnak/telebot.v2"
 	"log"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
-var ErrNeedsSegments = errors.New("Needs to be location;datetime;capacity. For example 'Home;2019-03-05 20:01:00;8'")
-var ErrInvalidDate = errors.New("Datetime must follow the format YYYY-MM-DD HH:mm:ss. For example 'Home;2019-03-05 20:01:00;3'")
+var ErrNeedsSegments = errors.New("Needs to be location;datetime;capacity[;duration][;recurrence]. For example 'Home;friday 20:00;8' or 'Home;friday 20:00;8;3h;weekly'")
+var ErrInvalidDate = errors.New("Could not understand the date. Try 'Home;friday 20:00;3', 'Home;tomorrow 8pm;3', 'Home;15/06 21:00;3' or 'Home;2019-03-05 20:01:00;3'")
+var ErrNonexistentDate = errors.New("That time does not exist in the group's timezone because of a daylight saving time change")
+var ErrInvalidDuration = errors.New("Duration must be positive. For example 'Home;friday 20:00;8;3h' or 'Home;friday 20:00;8;2h30m'")
+var ErrInvalidGuestLimit = errors.New("Guest limit must be a number or unlimited")
 var ErrInvalidCapacity = errors.New("Capacity must be a number. Use 0 for unlimited. For example 'Home;2019-03-05 20:01:00;3'")
+var ErrUnknownCapacity = errors.New("Capacity can only be left out for saved locations with a capacity, see /locations")
 var defaultLocation *time.Location
 
 const goingResponse = "OK, going!"
+const goingWithGuestsResponse = "OK, going with %d guest(s)!"
 const notGoingResponse = "OK, not going :("
+const noGuestsResponse = "You have no guests to remove"
+const maybeResponse = "OK, maybe. Please confirm before RSVPs close"
 const goingCallbackData = "\fgoing"
+const maybeCallbackData = "\fmaybe"
+
+// goingPlusOneCallbackData is only sent by messages posted before the guest
+// stepper.
 const goingPlusOneCallbackData = "\fgoingPlusOne"
 const notGoingCallbackData = "\fnotGoing"
+const addGuestCallbackData = "\faddGuest"
+const removeGuestCallbackData = "\fremoveGuest"
 const goingIdentifier = "going"
-const goingPlusOneIdentifier = "goingPlusOne"
 const notGoingIdentifier = "notGoing"
+const maybeIdentifier = "maybe"
+const addGuestIdentifier = "addGuest"
+const removeGuestIdentifier = "removeGuest"
 const goingLabel = "Going"
-const goingPlusOneLabel = "Going+1"
 const notGoingLabel = "Not going"
+const maybeLabel = "Maybe"
+const addGuestLabel = "+ guest"
+const removeGuestLabel = "− guest"
 const nextEventTitle = "Next event!"
 const nextEventDescription = "Where: %s, When: %s"
 const meetingCreatedText = "Meeting created for %s at %s!"
 const meetingCreatedDateFormat = "Monday 02 Jan 2006 15:04"
+const meetingEndText = "\nUntil %s"
+const meetingEndTimeFormat = "15:04"
+const rsvpDeadlineText = "\nRSVP until %s"
+const maxGuestsText = "\nUp to %d guest(s) per attendee"
+const noGuestsText = "\nNo guests"
+const guestsText = "Attendees can bring %s. Change it with /guests 2, /guests 0 or /guests unlimited"
+const invalidGuestsText = "Unknown guest limit %q. Use a number like 2, 0 for no guests or unlimited"
+const queryDateFormat = "2006-01-02 15:04:05"
 const invalidInputTitle = "Invalid input"
+const announceUsageText = "Usage: /announce email@example.com [email@example.com ...]"
+const announcedText = "Meeting announced to %d email address(es)"
+const emailDisabledText = "Email is not configured"
+const addToCalendarLabel = "Add to calendar"
+const meetingCancelledText = "Meeting for %s at %s was cancelled"
+const onlyAdminsText = "Only group admins can do that"
+const reminderText = "Reminder: board games at %s on %s"
+const maybeNudgeText = "You said maybe for board games at %s on %s. Please answer Going or Not going in the group before %s"
+const timezoneText = "This group uses the %s timezone. Change it with /timezone Europe/Madrid"
+const timezoneSetText = "Timezone set to %s"
+const notRecurringText = "The current meeting does not repeat"
+const seriesEndedText = "The series ends with this meeting"
+const cutoffText = "RSVP until %s. Change it with /cutoff 6h, or /cutoff 0 to allow RSVPs until the meeting starts"
+const noCutoffText = "RSVPs are open until the meeting starts. Close them earlier with /cutoff 6h"
+const invalidCutoffText = "Unknown cutoff %q. Use a duration like 6h or 90m"
+const nameGuestsLabel = "Name guests"
+const startLinkURL = "https://t.me/%s?start=%s%s"
+const nameGuestsStartPrefix = "guests_"
+const nameGuestsText = "Send me the names of your %d guest(s), one per line or separated by commas. Add their @username if they use Telegram"
+const guestsSavedText = "Saved: %s"
+const noGuestsToNameText = "Add guests with the + guest button first"
+const invalidTimezoneText = "Unknown timezone %q. Use a name like Europe/Madrid or America/New_York"
 
+// editableMessage is what is kept as the attendees data of a meeting, along
+// with what was already posted about it.
 type editableMessage struct {
 	MessageID string
 	ChatID    int64
+	// TablesPosted keeps the tables from being posted again when the bot
+	// restarts after the RSVP deadline
+	TablesPosted bool
+}
+
+type telegram struct {
+	b           *tb.Bot
+	mf          *meetings.Factory
+	uf          users.Factory
+	gs          games.Store
+	ls          locations.Store
+	mailer      *email.Mailer
+	feed        *calendar.Feed
+	timeFactory ftime.Factory
+
+	// guestPrompts and importPrompts map the telegram users asked in a
+	// private chat for their guests' names or their games to the group
+	guestPrompts  map[int]string
+	importPrompts map[int]string
+	promptsMutex  sync.Mutex
+
+	// groupRatings caches the ratings of each group, updated as plays are
+	// logged and computed again when one is deleted
+	groupRatings map[string]*ratings.Ratings
+	ratingsMutex sync.Mutex
+
+	// hostProposals holds the meeting each group is looking for a host for
+	// through the rotation
+	hostProposals  map[string]*hostProposal
+	proposalsMutex sync.Mutex
 }
 
 type attendeeUser struct {
 	user   *users.ExternalUser
 	amount int
+	guests []*meetings.Guest
+	maybe  bool
 }
 
 func (e *editableMessage) MessageSig() (messageID string, chatID int64) {
@@ -52,74 +142,266 @@ func (e *editableMessage) MessageSig() (messageID string, chatID int64) {
 
 func init() {
 	var err error
-	defaultLocation, err = time.LoadLocation("America/Argentina/Buenos_Aires") // FIXME: config timezone?
+	defaultLocation, err = time.LoadLocation("America/Argentina/Buenos_Aires")
 	if err != nil {
 		panic(err)
 	}
 }
 
-func meetingText(meeting *meetings.Meeting, attendeeUsers []*attendeeUser) string {
+// meetingText describes the meeting, along with its saved location when
+// place is not nil.
+func meetingText(meeting *meetings.Meeting, place *locations.Location, attendeeUsers []*attendeeUser, location *time.Location) string {
 	usersText := ""
 	if attendeeUsers != nil && len(attendeeUsers) > 0 {
 		usersText = "\nAttendees:\n"
+		maybeText := ""
 		for _, au := range attendeeUsers {
 			if au.amount <= 0 {
 				continue
 			}
-			if au.amount == 1 {
-				usersText += fmt.Sprintf("* %s\n", au.user.DisplayName)
+			if au.maybe {
+				maybeText += attendeeText(au)
 				continue
 			}
-			usersText += fmt.Sprintf("* %s (+%d)\n", au.user.DisplayName, au.amount-1)
+			usersText += attendeeText(au)
+		}
+		if maybeText != "" {
+			usersText += "\nMaybe:\n" + maybeText
+		}
+	}
+	start := meeting.Time.In(location)
+	text := fmt.Sprintf(meetingCreatedText, start.Format(meetingCreatedDateFormat), meeting.Location)
+	if place != nil {
+		text += placeText(place)
+	}
+	if meeting.Duration > 0 {
+		end := meeting.End().In(location)
+		if end.YearDay() == start.YearDay() && end.Year() == start.Year() {
+			text += fmt.Sprintf(meetingEndText, end.Format(meetingEndTimeFormat))
+		} else {
+			text += fmt.Sprintf(meetingEndText, end.Format(meetingCreatedDateFormat))
+		}
+	}
+	if meeting.MaxGuests > 0 {
+		text += fmt.Sprintf(maxGuestsText, meeting.MaxGuests)
+	} else if meeting.MaxGuests == meetings.NoGuests {
+		text += noGuestsText
+	}
+	if meeting.RSVPCutoff > 0 {
+		text += fmt.Sprintf(rsvpDeadlineText, meeting.RSVPDeadline().In(location).Format(meetingCreatedDateFormat))
+	}
+	if rule, err := recurrence.Parse(meeting.Recurrence); err == nil {
+		text += "\n" + rule.Describe()
+	}
+	return text + usersText
+}
+
+func attendeeText(au *attendeeUser) string {
+	if au.amount == 1 {
+		return fmt.Sprintf("* %s\n", au.user.DisplayName)
+	}
+	names := make([]string, 0, len(au.guests))
+	for _, guest := range au.guests {
+		if len(names) == au.amount-1 {
+			break
 		}
+		names = append(names, guestName(guest))
+	}
+	if len(names) > 0 {
+		return fmt.Sprintf("* %s (+%d: %s)\n", au.user.DisplayName, au.amount-1, strings.Join(names, ", "))
 	}
-	return fmt.Sprintf(meetingCreatedText, meeting.Time.In(defaultLocation).Format(meetingCreatedDateFormat), meeting.Location) + usersText
+	return fmt.Sprintf("* %s (+%d)\n", au.user.DisplayName, au.amount-1)
 }
 
-func meetingOptions() *tb.SendOptions {
+func (t *telegram) meetingOptions(groupID string, meeting *meetings.Meeting) *tb.SendOptions {
 	goingButton := tb.InlineButton{
 		Unique: goingIdentifier,
 		Text:   goingLabel,
 	}
-	goingPlusOneButton := tb.InlineButton{
-		Unique: goingPlusOneIdentifier,
-		Text:   goingPlusOneLabel,
-	}
 	notGoingButton := tb.InlineButton{
 		Unique: notGoingIdentifier,
 		Text:   notGoingLabel,
 	}
-	return &tb.SendOptions{
-		ReplyMarkup: &tb.ReplyMarkup{
-			InlineKeyboard: [][]tb.InlineButton{
-				[]tb.InlineButton{
-					goingButton,
-					goingPlusOneButton,
-					notGoingButton,
+	maybeButton := tb.InlineButton{
+		Unique: maybeIdentifier,
+		Text:   maybeLabel,
+	}
+	removeGuestButton := tb.InlineButton{
+		Unique: removeGuestIdentifier,
+		Text:   removeGuestLabel,
+	}
+	addGuestButton := tb.InlineButton{
+		Unique: addGuestIdentifier,
+		Text:   addGuestLabel,
+	}
+	keyboard := [][]tb.InlineButton{}
+	if t.timeFactory.Now().Before(meeting.RSVPDeadline()) {
+		keyboard = append(keyboard, []tb.InlineButton{
+			goingButton,
+			maybeButton,
+			notGoingButton,
+		})
+		if meeting.MaxGuests != meetings.NoGuests {
+			keyboard = append(keyboard, []tb.InlineButton{
+				removeGuestButton,
+				addGuestButton,
+				tb.InlineButton{
+					Text: nameGuestsLabel,
+					URL:  fmt.Sprintf(startLinkURL, t.b.Me.Username, nameGuestsStartPrefix, groupID),
 				},
+			})
+		}
+	}
+	keyboard = append(keyboard, t.voteButtons(meeting)...)
+	if t.feed != nil {
+		keyboard = append(keyboard, []tb.InlineButton{
+			tb.InlineButton{
+				Text: addToCalendarLabel,
+				URL:  t.feed.URL(groupID),
 			},
+		})
+	}
+	return &tb.SendOptions{
+		ReplyMarkup: &tb.ReplyMarkup{
+			InlineKeyboard: keyboard,
 		},
 	}
 }
 
-func parseQuery(input string) (*meetings.Meeting, error) {
-	data := strings.Split(input, ";")
-	if len(data) != 3 {
+// parseQuery reads a meeting. When its location is one of the saved ones
+// the meeting refers to it, and the capacity can be left empty to use the
+// location's.
+func parseQuery(input string, now time.Time, location *time.Location, saved []*locations.Location) (*meetings.Meeting, error) {
+	// RRULEs have semicolons of their own, so the optional segments after
+	// capacity are split apart below
+	data := strings.SplitN(input, ";", 4)
+	if len(data) < 3 {
 		return nil, ErrNeedsSegments
 	}
-	date, err := time.Parse("2006-01-02 15:04:05", strings.TrimSpace(data[1]))
+	date, err := dates.Parse(data[1], now, location)
 	if err != nil {
+		if err == dates.ErrNonexistentTime {
+			return nil, ErrNonexistentDate
+		}
 		return nil, ErrInvalidDate
 	}
-	capacity, err := strconv.Atoi(strings.TrimSpace(data[2]))
-	if err != nil || capacity < 0 {
-		return nil, ErrInvalidCapacity
-	}
-	return &meetings.Meeting{
+	meeting := &meetings.Meeting{
 		Time:     date,
 		Location: strings.TrimSpace(data[0]),
-		Capacity: capacity,
-	}, nil
+	}
+	place := locations.Find(saved, meeting.Location)
+	if place != nil {
+		meeting.Location = place.Name
+		meeting.LocationID = place.ID
+	}
+	if strings.TrimSpace(data[2]) == "" {
+		if place == nil {
+			return nil, ErrInvalidCapacity
+		}
+		if place.MaxCapacity == 0 {
+			return nil, ErrUnknownCapacity
+		}
+		meeting.Capacity = place.MaxCapacity
+	} else {
+		meeting.Capacity, err = strconv.Atoi(strings.TrimSpace(data[2]))
+		if err != nil || meeting.Capacity < 0 {
+			return nil, ErrInvalidCapacity
+		}
+	}
+	if len(data) < 4 {
+		return meeting, nil
+	}
+	rest := data[3]
+	optional := strings.SplitN(rest, ";", 2)
+	if duration, err := time.ParseDuration(strings.ToLower(strings.TrimSpace(optional[0]))); err == nil {
+		if duration <= 0 {
+			return nil, ErrInvalidDuration
+		}
+		meeting.Duration = duration
+		rest = ""
+		if len(optional) == 2 {
+			rest = optional[1]
+		}
+	}
+	if strings.TrimSpace(rest) != "" {
+		rule, err := recurrence.ParseText(rest)
+		if err != nil {
+			return nil, err
+		}
+		meeting.Recurrence = rule.String()
+	}
+	return meeting, nil
+}
+
+// parseGuestLimit maps what organizers type to meetings.Meeting.MaxGuests,
+// where zero means unlimited.
+func parseGuestLimit(input string) (int, error) {
+	if input == "unlimited" || input == "any" {
+		return 0, nil
+	}
+	maxGuests, err := strconv.Atoi(input)
+	if err != nil || maxGuests < 0 {
+		return 0, ErrInvalidGuestLimit
+	}
+	if maxGuests == 0 {
+		return meetings.NoGuests, nil
+	}
+	return maxGuests, nil
+}
+
+func guestLimitDescription(maxGuests int) string {
+	switch {
+	case maxGuests == meetings.NoGuests:
+		return "no guests"
+	case maxGuests > 0:
+		return fmt.Sprintf("up to %d guest(s)", maxGuests)
+	}
+	return "any number of guests"
+}
+
+// queryText is the canonical form of a meeting that parseQuery understands.
+func queryText(meeting *meetings.Meeting) string {
+	segments := []string{meeting.Location, meeting.Time.Format(queryDateFormat), strconv.Itoa(meeting.Capacity)}
+	if meeting.Duration > 0 {
+		segments = append(segments, meeting.Duration.String())
+	}
+	if meeting.Recurrence != "" {
+		segments = append(segments, meeting.Recurrence)
+	}
+	return strings.Join(segments, ";")
+}
+
+// parseGuests reads guests one per line or comma separated, like
+// "Bob, Carol @carol". A guest with only a handle is named after it.
+func parseGuests(input string) []*meetings.Guest {
+	guests := []*meetings.Guest{}
+	for _, line := range strings.FieldsFunc(input, func(r rune) bool { return r == '\n' || r == ',' }) {
+		guest := &meetings.Guest{}
+		names := []string{}
+		for _, word := range strings.Fields(line) {
+			if strings.HasPrefix(word, "@") && len(word) > 1 && guest.Handle == "" {
+				guest.Handle = strings.TrimPrefix(word, "@")
+				continue
+			}
+			names = append(names, word)
+		}
+		guest.Name = strings.Join(names, " ")
+		if guest.Name == "" && guest.Handle == "" {
+			continue
+		}
+		if guest.Name == "" {
+			guest.Name = "@" + guest.Handle
+		}
+		guests = append(guests, guest)
+	}
+	return guests
+}
+
+func guestName(guest *meetings.Guest) string {
+	if guest.Handle != "" && guest.Name != "@"+guest.Handle {
+		return fmt.Sprintf("%s (@%s)", guest.Name, guest.Handle)
+	}
+	return guest.Name
 }
 
 func formatUserDisplayName(user *tb.User) string {
@@ -139,7 +421,324 @@ func formatUserDisplayName(user *tb.User) string {
 	return user.FirstName
 }
 
-func startTelegram(token string, mf *meetings.Factory, uf users.Factory) error {
+func (t *telegram) getAttendeeUsers(groupID string) ([]*attendeeUser, error) {
+	attendees, err := t.mf.GetMeetingAttendees(groupID)
+	if err != nil {
+		return nil, err
+	}
+	attendeesUserID := make([]string, len(attendees))
+	for i, att := range attendees {
+		attendeesUserID[i] = att.UserID
+	}
+	usersMap, err := t.uf.GetUsers(attendeesUserID)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*attendeeUser, 0, len(usersMap))
+	for _, attendee := range attendees {
+		if user, found := usersMap[attendee.UserID]; found {
+			users = append(users, &attendeeUser{user: user, amount: attendee.Amount, guests: attendee.Guests, maybe: attendee.Maybe})
+		}
+	}
+	return users, nil
+}
+
+// rsvpChange returns the RSVP a button tap asks for given the current one,
+// or why it cannot be done.
+func rsvpChange(data string, attendee *meetings.Attendee) (int, bool, string) {
+	current := attendee.Amount
+	switch data {
+	case goingCallbackData:
+		// confirming a maybe keeps its guests
+		if attendee.Maybe && current > 0 {
+			return current, false, ""
+		}
+		return 1, false, ""
+	case goingPlusOneCallbackData:
+		return 2, false, ""
+	case maybeCallbackData:
+		// the guests stay when switching to maybe
+		if current == 0 {
+			return 1, true, ""
+		}
+		return current, true, ""
+	case addGuestCallbackData:
+		// adding a guest also means going, and the stepper does not
+		// confirm a maybe
+		if current == 0 {
+			return 2, attendee.Maybe, ""
+		}
+		return current + 1, attendee.Maybe, ""
+	case removeGuestCallbackData:
+		if current <= 1 {
+			return 0, false, noGuestsResponse
+		}
+		return current - 1, attendee.Maybe, ""
+	}
+	return 0, false, ""
+}
+
+// currentAttendee returns the user's RSVP, with no amount if they have none
+func (t *telegram) currentAttendee(groupID string, userID string) (*meetings.Attendee, error) {
+	attendees, err := t.mf.GetMeetingAttendees(groupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, attendee := range attendees {
+		if attendee.UserID == userID {
+			return attendee, nil
+		}
+	}
+	return &meetings.Attendee{UserID: userID}, nil
+}
+
+func (t *telegram) refreshMeetingMessage(groupID string) error {
+	meetingMessage := &editableMessage{}
+	err := t.mf.GetMeetingAttendeesData(groupID, meetingMessage)
+	if err != nil {
+		return err
+	}
+	if meetingMessage.MessageID == "" || meetingMessage.ChatID == 0 {
+		return nil
+	}
+	meeting, err := t.mf.GetMeeting(groupID)
+	if err != nil {
+		return err
+	}
+	users, err := t.getAttendeeUsers(groupID)
+	if err != nil {
+		return err
+	}
+	text := meetingText(meeting, t.meetingPlace(groupID, meeting), users, t.groupLocation(groupID)) + t.meetingNominationsText(meeting)
+	_, err = t.b.Edit(meetingMessage, text, t.meetingOptions(groupID, meeting))
+	return err
+}
+
+func (t *telegram) sendMeetingMessage(groupID string, chat *tb.Chat, meeting *meetings.Meeting) error {
+	place := t.meetingPlace(groupID, meeting)
+	message, err := t.b.Send(chat, meetingText(meeting, place, nil, t.groupLocation(groupID)), t.meetingOptions(groupID, meeting))
+	if err != nil {
+		return err
+	}
+	err = t.mf.SetMeetingAttendeesData(groupID, &editableMessage{
+		MessageID: strconv.Itoa(message.ID),
+		ChatID:    chat.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if place != nil && place.HasCoordinates() {
+		_, err = t.b.Send(chat, placeVenue(place))
+	}
+	return err
+}
+
+func (t *telegram) groupChat(groupID string) (*tb.Chat, error) {
+	group, err := t.uf.GetExternalGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Source != users.SourceTelegram {
+		return nil, nil
+	}
+	chatID, err := strconv.ParseInt(group.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &tb.Chat{ID: chatID}, nil
+}
+
+func (t *telegram) groupLocation(groupID string) *time.Location {
+	group, err := t.uf.GetExternalGroup(groupID)
+	if err != nil {
+		log.Print(err)
+		return defaultLocation
+	}
+	return group.Location(defaultLocation)
+}
+
+func (t *telegram) postMeetingMessage(groupID string) error {
+	chat, err := t.groupChat(groupID)
+	if err != nil || chat == nil {
+		return err
+	}
+	meeting, err := t.mf.GetMeeting(groupID)
+	if err != nil {
+		return err
+	}
+	return t.sendMeetingMessage(groupID, chat, meeting)
+}
+
+func (t *telegram) closeMeetingMessage(groupID string, meeting *meetings.Meeting) error {
+	meetingMessage := &editableMessage{}
+	err := t.mf.GetMeetingAttendeesData(groupID, meetingMessage)
+	if err != nil {
+		return err
+	}
+	if meetingMessage.MessageID == "" || meetingMessage.ChatID == 0 {
+		return nil
+	}
+	users, err := t.getAttendeeUsers(groupID)
+	if err != nil {
+		return err
+	}
+	_, err = t.b.Edit(meetingMessage, meetingText(meeting, t.meetingPlace(groupID, meeting), users, t.groupLocation(groupID))+t.meetingNominationsText(meeting))
+	return err
+}
+
+func (t *telegram) remind(groupID string, meeting *meetings.Meeting) error {
+	text := fmt.Sprintf(reminderText, meeting.Location, meeting.Time.In(t.groupLocation(groupID)).Format(meetingCreatedDateFormat))
+	chat, err := t.groupChat(groupID)
+	if err != nil {
+		return err
+	}
+	if chat != nil {
+		_, err = t.b.Send(chat, text)
+		if err != nil {
+			log.Print(err)
+		}
+	}
+	attendees, err := t.getAttendeeUsers(groupID)
+	if err != nil {
+		return err
+	}
+	for _, attendee := range attendees {
+		if attendee.amount <= 0 || attendee.user.Source != users.SourceTelegram {
+			continue
+		}
+		userID, err := strconv.Atoi(attendee.user.ID)
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+		// users that never talked to the bot cannot get private messages
+		_, err = t.b.Send(&tb.User{ID: userID}, text)
+		if err != nil {
+			log.Print(err)
+		}
+	}
+	return nil
+}
+
+// nudgeMaybes asks the users who answered maybe to confirm
+func (t *telegram) nudgeMaybes(groupID string, meeting *meetings.Meeting) error {
+	location := t.groupLocation(groupID)
+	text := fmt.Sprintf(maybeNudgeText, meeting.Location, meeting.Time.In(location).Format(meetingCreatedDateFormat), meeting.RSVPDeadline().In(location).Format(meetingCreatedDateFormat))
+	attendees, err := t.getAttendeeUsers(groupID)
+	if err != nil {
+		return err
+	}
+	for _, attendee := range attendees {
+		if attendee.amount <= 0 || !attendee.maybe || attendee.user.Source != users.SourceTelegram {
+			continue
+		}
+		userID, err := strconv.Atoi(attendee.user.ID)
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+		_, err = t.b.Send(&tb.User{ID: userID}, text)
+		if err != nil {
+			log.Print(err)
+		}
+	}
+	return nil
+}
+
+// cancelMeeting cancels the current meeting. Unless endSeries is set, a
+// recurring meeting is only skipped and its next occurrence gets posted.
+func (t *telegram) cancelMeeting(groupID string, endSeries bool) error {
+	meeting, err := t.mf.GetMeeting(groupID)
+	if err != nil {
+		return err
+	}
+	if endSeries && meeting.Recurrence != "" {
+		err = t.mf.SetMeetingRecurrence(groupID, "")
+		if err != nil {
+			return err
+		}
+	}
+	return t.mf.CancelMeeting(groupID)
+}
+
+// cancelMeetingMessage tells in the meeting message that it was cancelled,
+// wherever the cancellation came from.
+func (t *telegram) cancelMeetingMessage(groupID string, meeting *meetings.Meeting) error {
+	meetingMessage := &editableMessage{}
+	err := t.mf.GetMeetingAttendeesData(groupID, meetingMessage)
+	if err != nil {
+		return err
+	}
+	if meetingMessage.MessageID == "" || meetingMessage.ChatID == 0 {
+		return nil
+	}
+	_, err = t.b.Edit(meetingMessage, fmt.Sprintf(meetingCancelledText, meeting.Time.In(t.groupLocation(groupID)).Format(meetingCreatedDateFormat), meeting.Location))
+	return err
+}
+
+func (t *telegram) isAdmin(chat *tb.Chat, user *tb.User) bool {
+	member, err := t.b.ChatMemberOf(chat, user)
+	if err != nil {
+		log.Print(err)
+		return false
+	}
+	return member.Role == tb.Creator || member.Role == tb.Administrator
+}
+
+func (t *telegram) nameGuests(m *tb.Message, groupID string) {
+	userID, err := t.uf.GetOrCreateUser(&users.ExternalUser{
+		Source:      users.SourceTelegram,
+		ID:          strconv.Itoa(m.Sender.ID),
+		DisplayName: formatUserDisplayName(m.Sender),
+	})
+	if err != nil {
+		return
+	}
+	guests := parseGuests(m.Text)
+	err = t.mf.SetAttendeeGuests(groupID, userID, guests)
+	if err != nil {
+		if err == meetings.NoActiveMeeting || err == meetings.UserDoesNotAttendMeeting || err == meetings.TooManyGuests {
+			t.b.Send(m.Chat, err.Error())
+		}
+		return
+	}
+	names := make([]string, len(guests))
+	for i, guest := range guests {
+		names[i] = guestName(guest)
+	}
+	t.b.Send(m.Chat, fmt.Sprintf(guestsSavedText, strings.Join(names, ", ")))
+	err = t.refreshMeetingMessage(groupID)
+	if err != nil {
+		log.Print(err)
+	}
+}
+
+// linkGuests marks the guests named after the user's handle as the user
+func (t *telegram) linkGuests(groupID string, user *tb.User, userID string) {
+	if user.Username == "" {
+		return
+	}
+	linked, err := t.mf.LinkGuests(groupID, user.Username, userID)
+	if err != nil {
+		if err != meetings.NoActiveMeeting {
+			log.Printf("failed to link guests: %#v", err)
+		}
+		return
+	}
+	if linked > 0 {
+		err = t.refreshMeetingMessage(groupID)
+		if err != nil {
+			log.Print(err)
+		}
+	}
+}
+
+func (t *telegram) Start() {
+	t.b.Start()
+}
+
+func newTelegram(token string, mf *meetings.Factory, uf users.Factory, gs games.Store, ls locations.Store, mailer *email.Mailer, feed *calendar.Feed) (*telegram, error) {
+	t := &telegram{mf: mf, uf: uf, gs: gs, ls: ls, mailer: mailer, feed: feed, timeFactory: ftime.NewReal(), guestPrompts: map[int]string{}, importPrompts: map[int]string{}, groupRatings: map[string]*ratings.Ratings{}, hostProposals: map[string]*hostProposal{}}
 	var b *tb.Bot
 	b, err := tb.NewBot(tb.Settings{
 		Token: token,
@@ -158,17 +757,13 @@ func startTelegram(token string, mf *meetings.Factory, uf users.Factory) error {
 
 				if upd.Callback.Data != goingCallbackData &&
 					upd.Callback.Data != notGoingCallbackData &&
-					upd.Callback.Data != goingPlusOneCallbackData {
+					upd.Callback.Data != maybeCallbackData &&
+					upd.Callback.Data != goingPlusOneCallbackData &&
+					upd.Callback.Data != addGuestCallbackData &&
+					upd.Callback.Data != removeGuestCallbackData {
 					return true
 				}
 
-				amount := 0
-				if upd.Callback.Data == goingCallbackData {
-					amount = 1
-				} else if upd.Callback.Data == goingPlusOneCallbackData {
-					amount = 2
-				}
-
 				userID, err := uf.GetOrCreateUser(&users.ExternalUser{
 					Source:      users.SourceTelegram,
 					ID:          strconv.Itoa(upd.Callback.Sender.ID),
@@ -183,54 +778,41 @@ func startTelegram(token string, mf *meetings.Factory, uf users.Factory) error {
 					return respondEmpty()
 				}
 
-				err = mf.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: userID, Amount: amount})
+				t.linkGuests(groupID, upd.Callback.Sender, userID)
+
+				attendee, err := t.currentAttendee(groupID, userID)
 				if err != nil {
 					if err == meetings.NoActiveMeeting {
 						return respond(err.Error())
 					}
 					return respondEmpty()
 				}
+				amount, maybe, refused := rsvpChange(upd.Callback.Data, attendee)
+				if refused != "" {
+					return respond(refused)
+				}
 
-				if amount > 0 {
+				err = mf.UserRSVPMeeting(groupID, &meetings.Attendee{UserID: userID, Amount: amount, Maybe: maybe})
+				if err != nil {
+					if err == meetings.NoActiveMeeting || err == meetings.RSVPClosed || err == meetings.TooManyGuests || err == meetings.MeetingIsFull || err == meetings.SignUpNotOpen {
+						return respond(err.Error())
+					}
+					return respondEmpty()
+				}
+
+				if maybe {
+					respond(maybeResponse)
+				} else if amount > 1 {
+					respond(fmt.Sprintf(goingWithGuestsResponse, amount-1))
+				} else if amount > 0 {
 					respond(goingResponse)
 				} else {
 					respond(notGoingResponse)
 				}
 
-				meetingMessage := &editableMessage{}
-				err = mf.GetMeetingAttendeesData(groupID, meetingMessage)
+				err = t.refreshMeetingMessage(groupID)
 				if err != nil {
 					log.Print(err)
-					return false
-				}
-
-				if meetingMessage.MessageID != "" && meetingMessage.ChatID != 0 {
-					meeting, err := mf.GetMeeting(groupID)
-					if err != nil {
-						log.Print(err)
-						return false
-					}
-					attendees, err := mf.GetMeetingAttendees(groupID)
-					if err != nil {
-						log.Print(err)
-						return false
-					}
-					attendeesUserID := make([]string, len(attendees))
-					for i, att := range attendees {
-						attendeesUserID[i] = att.UserID
-					}
-					usersMap, err := uf.GetUsers(attendeesUserID)
-					if err != nil {
-						log.Print(err)
-						return false
-					}
-					users := make([]*attendeeUser, 0, len(usersMap))
-					for _, attendee := range attendees {
-						if user, found := usersMap[attendee.UserID]; found {
-							users = append(users, &attendeeUser{user: user, amount: attendee.Amount})
-						}
-					}
-					b.Edit(meetingMessage, meetingText(meeting, users), meetingOptions())
 				}
 				return false
 			}
@@ -239,11 +821,15 @@ func startTelegram(token string, mf *meetings.Factory, uf users.Factory) error {
 	})
 
 	if err != nil {
-		return err
+		return nil, err
 	}
+	t.b = b
 
 	b.Handle(tb.OnQuery, func(q *tb.Query) {
-		m, err := parseQuery(q.Text)
+		// inline queries carry no group, so the date is resolved in the
+		// default timezone and sent as an absolute wall clock time that is
+		// then interpreted in the group's timezone
+		m, err := parseQuery(q.Text, t.timeFactory.Now(), defaultLocation, nil)
 		if err != nil {
 			err = b.Answer(q, &tb.QueryResponse{
 				Results: tb.Results{
@@ -261,12 +847,16 @@ func startTelegram(token string, mf *meetings.Factory, uf users.Factory) error {
 			return
 		}
 
+		description := fmt.Sprintf(nextEventDescription, m.Location, m.Time.Format(meetingCreatedDateFormat))
+		if rule, err := recurrence.Parse(m.Recurrence); err == nil {
+			description += ". " + rule.Describe()
+		}
 		err = b.Answer(q, &tb.QueryResponse{
 			Results: tb.Results{
 				&tb.ArticleResult{
 					Title:       nextEventTitle,
-					Description: fmt.Sprintf(nextEventDescription, m.Location, m.Time.Format(time.RFC1123)),
-					Text:        q.Text,
+					Description: description,
+					Text:        queryText(m),
 				},
 			},
 			CacheTime: 60,
@@ -279,11 +869,22 @@ func startTelegram(token string, mf *meetings.Factory, uf users.Factory) error {
 	})
 
 	b.Handle(tb.OnText, func(m *tb.Message) {
+		if m.Private() {
+			t.promptsMutex.Lock()
+			groupID, ok := t.guestPrompts[m.Sender.ID]
+			delete(t.guestPrompts, m.Sender.ID)
+			t.promptsMutex.Unlock()
+			if ok {
+				t.nameGuests(m, groupID)
+			}
+			return
+		}
 		if !m.FromGroup() {
 			return
 		}
-		meeting, err := parseQuery(m.Text)
-		if err != nil {
+		// without the saved locations at hand a missing capacity looks
+		// invalid, so it is only told apart when there are any
+		if _, err := parseQuery(m.Text, t.timeFactory.Now(), defaultLocation, nil); err != nil && err != ErrNonexistentDate && err != ErrInvalidDuration && err != recurrence.ErrInvalidRule && (err != ErrInvalidCapacity || t.ls == nil) {
 			return
 		}
 		groupID, err := uf.GetOrCreateGroup(&users.ExternalGroup{
@@ -293,28 +894,375 @@ func startTelegram(token string, mf *meetings.Factory, uf users.Factory) error {
 		if err != nil {
 			return
 		}
+		saved, err := t.savedLocations(groupID)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		meeting, err := parseQuery(m.Text, t.timeFactory.Now(), t.groupLocation(groupID), saved)
+		if err != nil {
+			if err == ErrNonexistentDate || err == ErrInvalidDuration || err == recurrence.ErrInvalidRule || err == ErrUnknownCapacity {
+				b.Send(m.Chat, err.Error())
+			}
+			return
+		}
+		meeting.HostID, err = uf.GetOrCreateUser(&users.ExternalUser{
+			Source:      users.SourceTelegram,
+			ID:          strconv.Itoa(m.Sender.ID),
+			DisplayName: formatUserDisplayName(m.Sender),
+		})
+		if err != nil {
+			return
+		}
+		// whoever lives at a saved location hosts there, even when someone
+		// else organizes
+		if place := locations.Find(saved, meeting.Location); place != nil && place.HostID != "" {
+			meeting.HostID = place.HostID
+		}
 		err = mf.CreateMeeting(groupID, meeting)
 		if err != nil {
-			if err == meetings.MeetingAlreadyActive || err == meetings.MeetingIsInThePast {
+			if err == meetings.MeetingAlreadyActive || err == meetings.MeetingIsInThePast || err == meetings.MeetingsOverlap {
 				b.Send(m.Chat, err.Error())
 			}
 			return
 		}
-		message, err := b.Send(m.Chat, meetingText(meeting, nil), meetingOptions())
+		err = t.sendMeetingMessage(groupID, m.Chat, meeting)
 		if err != nil {
 			log.Print(err)
 			return
 		}
-		err = mf.SetMeetingAttendeesData(groupID, &editableMessage{
-			MessageID: strconv.Itoa(message.ID),
-			ChatID:    m.Chat.ID,
+	})
+
+	b.Handle("/start", func(m *tb.Message) {
+		if !m.Private() {
+			return
+		}
+		if strings.HasPrefix(m.Payload, importGamesStartPrefix) {
+			t.promptImport(m, strings.TrimPrefix(m.Payload, importGamesStartPrefix))
+			return
+		}
+		if !strings.HasPrefix(m.Payload, nameGuestsStartPrefix) {
+			return
+		}
+		groupID := strings.TrimPrefix(m.Payload, nameGuestsStartPrefix)
+		userID, err := uf.GetOrCreateUser(&users.ExternalUser{
+			Source:      users.SourceTelegram,
+			ID:          strconv.Itoa(m.Sender.ID),
+			DisplayName: formatUserDisplayName(m.Sender),
+		})
+		if err != nil {
+			return
+		}
+		attendee, err := t.currentAttendee(groupID, userID)
+		if err != nil {
+			if err == meetings.NoActiveMeeting {
+				b.Send(m.Chat, err.Error())
+			}
+			return
+		}
+		amount := attendee.Amount
+		if amount <= 1 {
+			b.Send(m.Chat, noGuestsToNameText)
+			return
+		}
+		t.promptsMutex.Lock()
+		t.guestPrompts[m.Sender.ID] = groupID
+		t.promptsMutex.Unlock()
+		b.Send(m.Chat, fmt.Sprintf(nameGuestsText, amount-1))
+	})
+
+	b.Handle(tb.OnUserJoined, func(m *tb.Message) {
+		user := m.UserJoined
+		if user == nil {
+			return
+		}
+		groupID, err := uf.GetOrCreateGroup(&users.ExternalGroup{
+			Source: users.SourceTelegram,
+			ID:     strconv.FormatInt(m.Chat.ID, 10),
+		})
+		if err != nil {
+			return
+		}
+		userID, err := uf.GetOrCreateUser(&users.ExternalUser{
+			Source:      users.SourceTelegram,
+			ID:          strconv.Itoa(user.ID),
+			DisplayName: formatUserDisplayName(user),
+		})
+		if err != nil {
+			return
+		}
+		t.linkGuests(groupID, user, userID)
+	})
+
+	b.Handle("/announce", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if mailer == nil {
+			b.Send(m.Chat, emailDisabledText)
+			return
+		}
+		addresses := strings.Fields(m.Payload)
+		if len(addresses) == 0 {
+			b.Send(m.Chat, announceUsageText)
+			return
+		}
+		groupID, err := uf.GetOrCreateGroup(&users.ExternalGroup{
+			Source: users.SourceTelegram,
+			ID:     strconv.FormatInt(m.Chat.ID, 10),
+		})
+		if err != nil {
+			return
+		}
+		meeting, err := mf.GetMeeting(groupID)
+		if err != nil {
+			if err == meetings.NoActiveMeeting {
+				b.Send(m.Chat, err.Error())
+			}
+			return
+		}
+		err = mailer.Announce(groupID, meeting, addresses)
+		if err != nil {
+			if _, failed := err.(*email.SendError); failed || err == email.ErrInvalidAddress {
+				b.Send(m.Chat, err.Error())
+			} else {
+				log.Print(err)
+			}
+			return
+		}
+		b.Send(m.Chat, fmt.Sprintf(announcedText, len(addresses)))
+	})
+
+	b.Handle("/cancel", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if !t.isAdmin(m.Chat, m.Sender) {
+			b.Send(m.Chat, onlyAdminsText)
+			return
+		}
+		groupID, err := uf.GetOrCreateGroup(&users.ExternalGroup{
+			Source: users.SourceTelegram,
+			ID:     strconv.FormatInt(m.Chat.ID, 10),
+		})
+		if err != nil {
+			return
+		}
+		err = t.cancelMeeting(groupID, true)
+		if err != nil {
+			if err == meetings.NoActiveMeeting {
+				b.Send(m.Chat, err.Error())
+			} else {
+				log.Print(err)
+			}
+			return
+		}
+	})
+
+	b.Handle("/skip", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if !t.isAdmin(m.Chat, m.Sender) {
+			b.Send(m.Chat, onlyAdminsText)
+			return
+		}
+		groupID, err := uf.GetOrCreateGroup(&users.ExternalGroup{
+			Source: users.SourceTelegram,
+			ID:     strconv.FormatInt(m.Chat.ID, 10),
 		})
+		if err != nil {
+			return
+		}
+		meeting, err := mf.GetMeeting(groupID)
+		if err != nil {
+			if err == meetings.NoActiveMeeting {
+				b.Send(m.Chat, err.Error())
+			}
+			return
+		}
+		if meeting.Recurrence == "" {
+			b.Send(m.Chat, notRecurringText)
+			return
+		}
+		err = t.cancelMeeting(groupID, false)
+		if err != nil {
+			log.Print(err)
+		}
+	})
+
+	b.Handle("/endseries", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if !t.isAdmin(m.Chat, m.Sender) {
+			b.Send(m.Chat, onlyAdminsText)
+			return
+		}
+		groupID, err := uf.GetOrCreateGroup(&users.ExternalGroup{
+			Source: users.SourceTelegram,
+			ID:     strconv.FormatInt(m.Chat.ID, 10),
+		})
+		if err != nil {
+			return
+		}
+		meeting, err := mf.GetMeeting(groupID)
+		if err != nil {
+			if err == meetings.NoActiveMeeting {
+				b.Send(m.Chat, err.Error())
+			}
+			return
+		}
+		if meeting.Recurrence == "" {
+			b.Send(m.Chat, notRecurringText)
+			return
+		}
+		err = mf.SetMeetingRecurrence(groupID, "")
 		if err != nil {
 			log.Print(err)
 			return
 		}
+		b.Send(m.Chat, seriesEndedText)
+		err = t.refreshMeetingMessage(groupID)
+		if err != nil {
+			log.Print(err)
+		}
 	})
 
-	b.Start()
-	return nil
+	b.Handle("/cutoff", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		groupID, err := uf.GetOrCreateGroup(&users.ExternalGroup{
+			Source: users.SourceTelegram,
+			ID:     strconv.FormatInt(m.Chat.ID, 10),
+		})
+		if err != nil {
+			return
+		}
+		meeting, err := mf.GetMeeting(groupID)
+		if err != nil {
+			if err == meetings.NoActiveMeeting {
+				b.Send(m.Chat, err.Error())
+			}
+			return
+		}
+		payload := strings.TrimSpace(m.Payload)
+		if payload == "" {
+			if meeting.RSVPCutoff > 0 {
+				b.Send(m.Chat, fmt.Sprintf(cutoffText, meeting.RSVPDeadline().In(t.groupLocation(groupID)).Format(meetingCreatedDateFormat)))
+			} else {
+				b.Send(m.Chat, noCutoffText)
+			}
+			return
+		}
+		if !t.isAdmin(m.Chat, m.Sender) {
+			b.Send(m.Chat, onlyAdminsText)
+			return
+		}
+		cutoff, err := time.ParseDuration(strings.ToLower(payload))
+		if err != nil || cutoff < 0 {
+			b.Send(m.Chat, fmt.Sprintf(invalidCutoffText, payload))
+			return
+		}
+		err = mf.SetMeetingRSVPCutoff(groupID, cutoff)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		err = t.refreshMeetingMessage(groupID)
+		if err != nil {
+			log.Print(err)
+		}
+	})
+
+	b.Handle("/guests", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		groupID, err := uf.GetOrCreateGroup(&users.ExternalGroup{
+			Source: users.SourceTelegram,
+			ID:     strconv.FormatInt(m.Chat.ID, 10),
+		})
+		if err != nil {
+			return
+		}
+		meeting, err := mf.GetMeeting(groupID)
+		if err != nil {
+			if err == meetings.NoActiveMeeting {
+				b.Send(m.Chat, err.Error())
+			}
+			return
+		}
+		payload := strings.ToLower(strings.TrimSpace(m.Payload))
+		if payload == "" {
+			b.Send(m.Chat, fmt.Sprintf(guestsText, guestLimitDescription(meeting.MaxGuests)))
+			return
+		}
+		if !t.isAdmin(m.Chat, m.Sender) {
+			b.Send(m.Chat, onlyAdminsText)
+			return
+		}
+		maxGuests, err := parseGuestLimit(payload)
+		if err != nil {
+			b.Send(m.Chat, fmt.Sprintf(invalidGuestsText, payload))
+			return
+		}
+		err = mf.SetMeetingMaxGuests(groupID, maxGuests)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		err = t.refreshMeetingMessage(groupID)
+		if err != nil {
+			log.Print(err)
+		}
+	})
+
+	b.Handle("/timezone", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		groupID, err := uf.GetOrCreateGroup(&users.ExternalGroup{
+			Source: users.SourceTelegram,
+			ID:     strconv.FormatInt(m.Chat.ID, 10),
+		})
+		if err != nil {
+			return
+		}
+		timezone := strings.TrimSpace(m.Payload)
+		if timezone == "" {
+			b.Send(m.Chat, fmt.Sprintf(timezoneText, t.groupLocation(groupID)))
+			return
+		}
+		if !t.isAdmin(m.Chat, m.Sender) {
+			b.Send(m.Chat, onlyAdminsText)
+			return
+		}
+		location, err := time.LoadLocation(timezone)
+		if err != nil || timezone == "Local" {
+			b.Send(m.Chat, fmt.Sprintf(invalidTimezoneText, timezone))
+			return
+		}
+		err = uf.SetGroupTimezone(groupID, location.String())
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, fmt.Sprintf(timezoneSetText, location))
+		err = t.refreshMeetingMessage(groupID)
+		if err != nil && err != meetings.NoActiveMeeting {
+			log.Print(err)
+		}
+	})
+
+	t.registerGameHandlers()
+	t.registerPlayHandlers()
+	t.registerRatingHandlers()
+	t.registerStatsHandlers()
+	t.registerCheckInHandlers()
+	t.registerLocationHandlers()
+	t.registerRotationHandlers()
+
+	return t, nil
 }
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"log"
+	"strconv"
+	"strings"
+)
+
+const checkInText = "Who came to %s on %s? Tap everyone who showed up"
+const checkInIdentifier = "checkin"
+const checkedInLabel = "✓ %s"
+const guestsCheckInLabel = "%s's guests: %d/%d"
+const checkedInResponse = "Checked in %s"
+const notCheckedInResponse = "Unchecked %s"
+const guestsCheckedInResponse = "%d of %s's guests came"
+const onlyHostChecksInResponse = "Only the host or an admin can check people in"
+
+// checkInGuestsKind marks the buttons that count the guests who came rather
+// than the attendee
+const checkInGuestsKind = "guests"
+
+// checkInButtons lists everyone who said they would go, and a button per
+// attendee bringing guests that counts how many of them came.
+func checkInButtons(groupID string, meeting *meetings.Meeting, attendees []*meetings.Attendee, usersMap map[string]*users.ExternalUser) [][]tb.InlineButton {
+	rows := [][]tb.InlineButton{}
+	for _, attendee := range attendees {
+		if !attendee.Going() {
+			continue
+		}
+		name := "(unknown user)"
+		if user, found := usersMap[attendee.UserID]; found {
+			name = user.DisplayName
+		}
+		label := name
+		if attendee.CheckedIn {
+			label = fmt.Sprintf(checkedInLabel, name)
+		}
+		data := strings.Join([]string{groupID, meeting.ID, attendee.UserID}, "|")
+		rows = append(rows, []tb.InlineButton{tb.InlineButton{
+			Unique: checkInIdentifier,
+			Text:   label,
+			Data:   data,
+		}})
+		if attendee.Amount > 1 {
+			rows = append(rows, []tb.InlineButton{tb.InlineButton{
+				Unique: checkInIdentifier,
+				Text:   fmt.Sprintf(guestsCheckInLabel, name, attendee.GuestsCheckedIn, attendee.Amount-1),
+				Data:   data + "|" + checkInGuestsKind,
+			}})
+		}
+	}
+	return rows
+}
+
+func (t *telegram) checkInMarkup(groupID string, meeting *meetings.Meeting) (*tb.ReplyMarkup, error) {
+	attendees, err := t.mf.GetMeetingAttendeesByID(meeting.ID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, len(attendees))
+	for i, attendee := range attendees {
+		userIDs[i] = attendee.UserID
+	}
+	usersMap, err := t.uf.GetUsers(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &tb.ReplyMarkup{InlineKeyboard: checkInButtons(groupID, meeting, attendees, usersMap)}, nil
+}
+
+// sendCheckIn sends the host the checklist of who said they would go, or
+// to the group when the host cannot get private messages.
+func (t *telegram) sendCheckIn(groupID string, meeting *meetings.Meeting) error {
+	markup, err := t.checkInMarkup(groupID, meeting)
+	if err != nil {
+		return err
+	}
+	if len(markup.InlineKeyboard) == 0 {
+		return nil
+	}
+	text := fmt.Sprintf(checkInText, meeting.Location, meeting.Time.In(t.groupLocation(groupID)).Format(meetingCreatedDateFormat))
+	if meeting.HostID != "" {
+		usersMap, err := t.uf.GetUsers([]string{meeting.HostID})
+		if err != nil {
+			return err
+		}
+		if host, found := usersMap[meeting.HostID]; found && host.Source == users.SourceTelegram {
+			userID, err := strconv.Atoi(host.ID)
+			if err != nil {
+				return err
+			}
+			// users that never talked to the bot cannot get private messages
+			_, err = t.b.Send(&tb.User{ID: userID}, text, markup)
+			if err == nil {
+				return nil
+			}
+			log.Print(err)
+		}
+	}
+	chat, err := t.groupChat(groupID)
+	if err != nil || chat == nil {
+		return err
+	}
+	_, err = t.b.Send(chat, text, markup)
+	return err
+}
+
+// checkIn toggles whether someone came, or counts one more of their guests,
+// and returns the answer to the host.
+func (t *telegram) checkIn(c *tb.Callback) string {
+	data := strings.Split(c.Data, "|")
+	if len(data) < 3 {
+		return ""
+	}
+	groupID, meetingID, userID := data[0], data[1], data[2]
+	guests := len(data) > 3 && data[3] == checkInGuestsKind
+	list, err := t.mf.GetMeetings(groupID)
+	if err != nil {
+		log.Print(err)
+		return ""
+	}
+	var meeting *meetings.Meeting
+	for _, m := range list {
+		if m.ID == meetingID {
+			meeting = m
+		}
+	}
+	if meeting == nil {
+		return ""
+	}
+	senderID, err := t.uf.GetOrCreateUser(&users.ExternalUser{
+		Source:      users.SourceTelegram,
+		ID:          strconv.Itoa(c.Sender.ID),
+		DisplayName: formatUserDisplayName(c.Sender),
+	})
+	if err != nil {
+		return ""
+	}
+	if senderID != meeting.HostID {
+		chat, err := t.groupChat(groupID)
+		if err != nil || chat == nil || !t.isAdmin(chat, c.Sender) {
+			return onlyHostChecksInResponse
+		}
+	}
+	attendees, err := t.mf.GetMeetingAttendeesByID(meeting.ID)
+	if err != nil {
+		log.Print(err)
+		return ""
+	}
+	var attendee *meetings.Attendee
+	for _, a := range attendees {
+		if a.UserID == userID {
+			attendee = a
+		}
+	}
+	if attendee == nil {
+		return meetings.UserDoesNotAttendMeeting.Error()
+	}
+	usersMap, err := t.uf.GetUsers([]string{userID})
+	if err != nil {
+		log.Print(err)
+		return ""
+	}
+	name := "(unknown user)"
+	if user, found := usersMap[userID]; found {
+		name = user.DisplayName
+	}
+	checkedIn := !attendee.CheckedIn
+	count := attendee.GuestsCheckedIn
+	response := fmt.Sprintf(checkedInResponse, name)
+	if guests {
+		// the count wraps around so a wrong tap can be undone
+		checkedIn = attendee.CheckedIn
+		count = (count + 1) % attendee.Amount
+		response = fmt.Sprintf(guestsCheckedInResponse, count, name)
+	} else if !checkedIn {
+		response = fmt.Sprintf(notCheckedInResponse, name)
+	}
+	err = t.mf.CheckIn(meeting.ID, userID, checkedIn, count)
+	if err != nil {
+		if err == meetings.UserDoesNotAttendMeeting || err == meetings.TooManyGuests {
+			return err.Error()
+		}
+		log.Print(err)
+		return ""
+	}
+	markup, err := t.checkInMarkup(groupID, meeting)
+	if err != nil {
+		log.Print(err)
+		return response
+	}
+	_, err = t.b.Edit(c.Message, c.Message.Text, markup)
+	if err != nil {
+		log.Print(err)
+	}
+	return response
+}
+
+func (t *telegram) registerCheckInHandlers() {
+	b := t.b
+
+	b.Handle(&tb.InlineButton{Unique: checkInIdentifier}, func(c *tb.Callback) {
+		err := b.Respond(c, &tb.CallbackResponse{Text: t.checkIn(c)})
+		if err != nil {
+			log.Print(err)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheckInButtons(t *testing.T) {
+	assert := assert.New(t)
+	attendees := []*meetings.Attendee{
+		&meetings.Attendee{UserID: "1", Amount: 3, CheckedIn: true, GuestsCheckedIn: 1},
+		&meetings.Attendee{UserID: "2", Amount: 1},
+		&meetings.Attendee{UserID: "3", Amount: 0},
+		&meetings.Attendee{UserID: "4", Amount: 1, Maybe: true},
+	}
+	usersMap := map[string]*users.ExternalUser{"1": &users.ExternalUser{DisplayName: "alice"}}
+	rows := checkInButtons("g", &meetings.Meeting{ID: "m"}, attendees, usersMap)
+	texts := []string{}
+	data := []string{}
+	for _, row := range rows {
+		assert.Equal(len(row), 1)
+		assert.Equal(row[0].Unique, checkInIdentifier)
+		texts = append(texts, row[0].Text)
+		data = append(data, row[0].Data)
+	}
+	assert.Equal(texts, []string{"✓ alice", "alice's guests: 1/2", "(unknown user)"})
+	assert.Equal(data, []string{"g|m|1", "g|m|1|guests", "g|m|2"})
+}
@@ -0,0 +1,668 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/bgg"
+	"github.com/seppo0010/boardgamesorganizer/games"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/tables"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidGame = errors.New("Usage: /addgame title[;players[;playtime]]. For example '/addgame Catan;3-4;90m' or '/addgame Azul;2-4'")
+var ErrInvalidPlayers = errors.New("Players must be a number or a range. For example '/addgame Catan;3-4' or '/addgame Patchwork;2'")
+var ErrInvalidPlaytime = errors.New("Playtime must be minutes or a duration. For example '/addgame Catan;3-4;90' or '/addgame Catan;3-4;1h30m'")
+
+const gamesDisabledText = "The game library is not configured"
+const gameAddedText = "Added %s to your games"
+const noGamesText = "Nobody registered games yet. Add yours with /addgame Catan;3-4;90m"
+const gamesText = "Games in this group:\n%s"
+const whoHasUsageText = "Usage: /whohas Catan"
+const nobodyHasText = "Nobody has %q"
+const importGamesText = "Send me your collection privately and I will add it to this group's games"
+const importGamesLabel = "Import collection"
+const importGamesStartPrefix = "import_"
+const importPromptText = "Send me your BoardGameGeek collection export, either the CSV from the website or the XML from the collection API"
+const notAMemberText = "Only members of the group can add games to it"
+const importedText = "Imported %d game(s)"
+const importSharedText = "\nAlso owned by other members: %s"
+const importAlreadyOwnedText = "\nAlready in your games: %s"
+const nominateUsageText = "Usage: /nominate Catan"
+const nominatedText = "Nominated %s. Vote with the buttons under the meeting"
+const topGamesText = "\nTop games:\n"
+const votedResponse = "Voted for %s"
+const unvotedResponse = "Removed your vote for %s"
+const onlyAttendeesVoteResponse = "Only people who RSVPed can vote"
+const voteIdentifier = "vote"
+const voteLabel = "Vote %s (%d)"
+
+const tablesText = "Suggested tables:\n%s"
+const guestOfText = "%s's guest"
+const noNominationsText = "Nominate games with /nominate Catan to get tables suggested"
+
+// topNominations is how many of the most voted games the meeting shows
+const topNominations = 3
+
+// parseGame reads "title;players;playtime", where players is like 2 or 3-4
+// and playtime is minutes or a duration like 1h30m. Only the title is
+// required.
+func parseGame(input string) (*games.Game, error) {
+	data := strings.Split(input, ";")
+	game := &games.Game{Title: strings.TrimSpace(data[0])}
+	if game.Title == "" || len(data) > 3 {
+		return nil, ErrInvalidGame
+	}
+	if len(data) > 1 {
+		players := strings.SplitN(data[1], "-", 2)
+		min, err := strconv.Atoi(strings.TrimSpace(players[0]))
+		if err != nil || min <= 0 {
+			return nil, ErrInvalidPlayers
+		}
+		max := min
+		if len(players) == 2 {
+			max, err = strconv.Atoi(strings.TrimSpace(players[1]))
+			if err != nil || max < min {
+				return nil, ErrInvalidPlayers
+			}
+		}
+		game.MinPlayers = min
+		game.MaxPlayers = max
+	}
+	if len(data) > 2 {
+		playtime := strings.ToLower(strings.TrimSpace(data[2]))
+		if minutes, err := strconv.Atoi(playtime); err == nil {
+			game.Playtime = time.Duration(minutes) * time.Minute
+		} else if duration, err := time.ParseDuration(playtime); err == nil {
+			game.Playtime = duration
+		} else {
+			return nil, ErrInvalidPlaytime
+		}
+		if game.Playtime <= 0 {
+			return nil, ErrInvalidPlaytime
+		}
+	}
+	return game, nil
+}
+
+func gameText(game *games.Game) string {
+	details := []string{}
+	if game.Year > 0 {
+		details = append(details, strconv.Itoa(game.Year))
+	}
+	if game.MinPlayers == game.MaxPlayers && game.MinPlayers > 0 {
+		details = append(details, fmt.Sprintf("%d players", game.MinPlayers))
+	} else if game.MinPlayers > 0 {
+		details = append(details, fmt.Sprintf("%d-%d players", game.MinPlayers, game.MaxPlayers))
+	}
+	if game.Playtime > 0 {
+		details = append(details, fmt.Sprintf("%d min", int(game.Playtime/time.Minute)))
+	}
+	if game.Weight > 0 {
+		details = append(details, fmt.Sprintf("weight %.1f", game.Weight))
+	}
+	if len(details) == 0 {
+		return game.Title
+	}
+	return fmt.Sprintf("%s (%s)", game.Title, strings.Join(details, ", "))
+}
+
+func (t *telegram) gamesListText(list []*games.Game) (string, error) {
+	ownerIDs := make([]string, len(list))
+	for i, game := range list {
+		ownerIDs[i] = game.OwnerID
+	}
+	owners, err := t.uf.GetUsers(ownerIDs)
+	if err != nil {
+		return "", err
+	}
+	text := ""
+	for _, game := range list {
+		owner := "(unknown user)"
+		if user, found := owners[game.OwnerID]; found {
+			owner = user.DisplayName
+		}
+		text += fmt.Sprintf("* %s by %s\n", gameText(game), owner)
+	}
+	return text, nil
+}
+
+func importSummaryText(summary *games.ImportSummary) string {
+	titles := func(list []*games.Game) string {
+		names := make([]string, len(list))
+		for i, game := range list {
+			names[i] = game.Title
+		}
+		return strings.Join(names, ", ")
+	}
+	text := fmt.Sprintf(importedText, len(summary.Added))
+	if len(summary.Shared) > 0 {
+		text += fmt.Sprintf(importSharedText, titles(summary.Shared))
+	}
+	if len(summary.AlreadyOwned) > 0 {
+		text += fmt.Sprintf(importAlreadyOwnedText, titles(summary.AlreadyOwned))
+	}
+	return text
+}
+
+func (t *telegram) isMember(chat *tb.Chat, user *tb.User) bool {
+	member, err := t.b.ChatMemberOf(chat, user)
+	if err != nil {
+		log.Print(err)
+		return false
+	}
+	return member.Role != tb.Left && member.Role != tb.Kicked
+}
+
+// promptImport asks for the collection in the private chat started from
+// the group's /importgames button
+func (t *telegram) promptImport(m *tb.Message, groupID string) {
+	if t.gs == nil {
+		t.b.Send(m.Chat, gamesDisabledText)
+		return
+	}
+	chat, err := t.groupChat(groupID)
+	if err != nil || chat == nil {
+		return
+	}
+	if !t.isMember(chat, m.Sender) {
+		t.b.Send(m.Chat, notAMemberText)
+		return
+	}
+	t.promptsMutex.Lock()
+	t.importPrompts[m.Sender.ID] = groupID
+	t.promptsMutex.Unlock()
+	t.b.Send(m.Chat, importPromptText)
+}
+
+func (t *telegram) importGames(m *tb.Message, groupID string) {
+	file, err := t.b.GetFile(&m.Document.File)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	defer file.Close()
+	list, err := bgg.Parse(file)
+	if err != nil {
+		if err == bgg.ErrInvalidExport {
+			t.b.Send(m.Chat, err.Error())
+		} else {
+			log.Print(err)
+		}
+		return
+	}
+	ownerID, err := t.uf.GetOrCreateUser(&users.ExternalUser{
+		Source:      users.SourceTelegram,
+		ID:          strconv.Itoa(m.Sender.ID),
+		DisplayName: formatUserDisplayName(m.Sender),
+	})
+	if err != nil {
+		return
+	}
+	summary, err := games.Import(t.gs, groupID, ownerID, list)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	t.b.Send(m.Chat, importSummaryText(summary))
+}
+
+// nominationsText lists the most voted games, leaving out the ones nobody
+// voted for.
+func nominationsText(nominations []*games.Nomination) string {
+	sorted := append([]*games.Nomination{}, nominations...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].Votes) > len(sorted[j].Votes)
+	})
+	text := ""
+	for i, nomination := range sorted {
+		if i == topNominations || len(nomination.Votes) == 0 {
+			break
+		}
+		text += fmt.Sprintf("%d. %s (%d)\n", i+1, nomination.Title, len(nomination.Votes))
+	}
+	if text == "" {
+		return ""
+	}
+	return topGamesText + text
+}
+
+func (t *telegram) nominations(meeting *meetings.Meeting) []*games.Nomination {
+	if t.gs == nil {
+		return nil
+	}
+	nominations, err := t.gs.GetNominations(meeting.ID)
+	if err != nil {
+		log.Print(err)
+		return nil
+	}
+	return nominations
+}
+
+func (t *telegram) meetingNominationsText(meeting *meetings.Meeting) string {
+	return nominationsText(t.nominations(meeting))
+}
+
+func (t *telegram) voteButtons(meeting *meetings.Meeting) [][]tb.InlineButton {
+	rows := [][]tb.InlineButton{}
+	for _, nomination := range t.nominations(meeting) {
+		rows = append(rows, []tb.InlineButton{tb.InlineButton{
+			Unique: voteIdentifier,
+			Text:   fmt.Sprintf(voteLabel, nomination.Title, len(nomination.Votes)),
+			Data:   nomination.ID,
+		}})
+	}
+	return rows
+}
+
+// nominatedGame is the game of the library that was meant, if any
+func nominatedGame(library []*games.Game, title string) *games.Game {
+	titles := map[string]bool{}
+	for _, game := range library {
+		if strings.EqualFold(game.Title, title) {
+			return game
+		}
+		titles[strings.ToLower(game.Title)] = true
+	}
+	if len(titles) == 1 {
+		return library[0]
+	}
+	return nil
+}
+
+func (t *telegram) vote(c *tb.Callback) string {
+	groupID, err := t.chatGroupID(c.Message.Chat)
+	if err != nil {
+		return ""
+	}
+	userID, err := t.uf.GetOrCreateUser(&users.ExternalUser{
+		Source:      users.SourceTelegram,
+		ID:          strconv.Itoa(c.Sender.ID),
+		DisplayName: formatUserDisplayName(c.Sender),
+	})
+	if err != nil {
+		return ""
+	}
+	meeting, err := t.mf.GetMeeting(groupID)
+	if err != nil {
+		if err == meetings.NoActiveMeeting {
+			return err.Error()
+		}
+		return ""
+	}
+	attendee, err := t.currentAttendee(groupID, userID)
+	if err != nil {
+		return ""
+	}
+	if attendee.Amount <= 0 {
+		return onlyAttendeesVoteResponse
+	}
+	for _, nomination := range t.nominations(meeting) {
+		if nomination.ID != c.Data {
+			continue
+		}
+		vote := !nomination.HasVote(userID)
+		if err := t.gs.SetVote(nomination.ID, userID, vote); err != nil {
+			log.Print(err)
+			return ""
+		}
+		if err := t.refreshMeetingMessage(groupID); err != nil {
+			log.Print(err)
+		}
+		if vote {
+			return fmt.Sprintf(votedResponse, nomination.Title)
+		}
+		return fmt.Sprintf(unvotedResponse, nomination.Title)
+	}
+	return games.NominationNotFound.Error()
+}
+
+// tablesListText names the people at each table, and the guests by their
+// name when the host gave it.
+func tablesListText(split []*tables.Table, attendees []*meetings.Attendee, usersMap map[string]*users.ExternalUser) string {
+	displayName := func(userID string) string {
+		if user, found := usersMap[userID]; found {
+			return user.DisplayName
+		}
+		return "(unknown user)"
+	}
+	guests := map[string][]*meetings.Guest{}
+	for _, attendee := range attendees {
+		guests[attendee.UserID] = attendee.Guests
+	}
+	text := ""
+	for _, table := range split {
+		names := make([]string, len(table.Seats))
+		for i, seat := range table.Seats {
+			if !seat.Guest {
+				names[i] = displayName(seat.UserID)
+			} else if len(guests[seat.UserID]) > 0 {
+				names[i] = guests[seat.UserID][0].Name
+				guests[seat.UserID] = guests[seat.UserID][1:]
+			} else {
+				names[i] = fmt.Sprintf(guestOfText, displayName(seat.UserID))
+			}
+		}
+		text += fmt.Sprintf("* %s: %s\n", table.Game.Title, strings.Join(names, ", "))
+	}
+	return text
+}
+
+// tablesText suggests how the people going to the meeting can split into
+// tables to play the nominated games. It is empty when nothing was
+// nominated.
+func (t *telegram) tablesText(groupID string, meeting *meetings.Meeting) (string, error) {
+	nominations := t.nominations(meeting)
+	if len(nominations) == 0 {
+		return "", nil
+	}
+	library, err := t.gs.GetGames(groupID)
+	if err != nil {
+		return "", err
+	}
+	libraryByID := map[string]*games.Game{}
+	for _, game := range library {
+		libraryByID[game.ID] = game
+	}
+	options := make([]*tables.Game, len(nominations))
+	for i, nomination := range nominations {
+		options[i] = &tables.Game{ID: nomination.ID, Title: nomination.Title, Votes: nomination.Votes}
+		if game, found := libraryByID[nomination.GameID]; found {
+			options[i].MinPlayers = game.MinPlayers
+			options[i].MaxPlayers = game.MaxPlayers
+		}
+	}
+	attendees, err := t.mf.GetMeetingAttendees(groupID)
+	if err != nil {
+		return "", err
+	}
+	split, err := tables.Split(attendees, options)
+	if err != nil {
+		if err == tables.NoSplit {
+			return err.Error(), nil
+		}
+		return "", err
+	}
+	userIDs := make([]string, len(attendees))
+	for i, attendee := range attendees {
+		userIDs[i] = attendee.UserID
+	}
+	usersMap, err := t.uf.GetUsers(userIDs)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(tablesText, tablesListText(split, attendees, usersMap)), nil
+}
+
+// postTables proposes tables to the group, once per meeting
+func (t *telegram) postTables(groupID string, meeting *meetings.Meeting) error {
+	if t.gs == nil {
+		return nil
+	}
+	meetingMessage := &editableMessage{}
+	err := t.mf.GetMeetingAttendeesData(groupID, meetingMessage)
+	if err != nil || meetingMessage.TablesPosted {
+		return err
+	}
+	text, err := t.tablesText(groupID, meeting)
+	if err != nil || text == "" {
+		return err
+	}
+	chat, err := t.groupChat(groupID)
+	if err != nil || chat == nil {
+		return err
+	}
+	_, err = t.b.Send(chat, text)
+	if err != nil {
+		return err
+	}
+	meetingMessage.TablesPosted = true
+	return t.mf.SetMeetingAttendeesData(groupID, meetingMessage)
+}
+
+func (t *telegram) chatGroupID(chat *tb.Chat) (string, error) {
+	return t.uf.GetOrCreateGroup(&users.ExternalGroup{
+		Source: users.SourceTelegram,
+		ID:     strconv.FormatInt(chat.ID, 10),
+	})
+}
+
+func (t *telegram) registerGameHandlers() {
+	b := t.b
+
+	b.Handle("/addgame", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.gs == nil {
+			b.Send(m.Chat, gamesDisabledText)
+			return
+		}
+		game, err := parseGame(m.Payload)
+		if err != nil {
+			b.Send(m.Chat, err.Error())
+			return
+		}
+		game.GroupID, err = t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		game.OwnerID, err = t.uf.GetOrCreateUser(&users.ExternalUser{
+			Source:      users.SourceTelegram,
+			ID:          strconv.Itoa(m.Sender.ID),
+			DisplayName: formatUserDisplayName(m.Sender),
+		})
+		if err != nil {
+			return
+		}
+		err = t.gs.AddGame(game)
+		if err != nil {
+			if err == games.GameAlreadyAdded {
+				b.Send(m.Chat, err.Error())
+			} else {
+				log.Print(err)
+			}
+			return
+		}
+		b.Send(m.Chat, fmt.Sprintf(gameAddedText, gameText(game)))
+	})
+
+	b.Handle("/games", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.gs == nil {
+			b.Send(m.Chat, gamesDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		list, err := t.gs.GetGames(groupID)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		if len(list) == 0 {
+			b.Send(m.Chat, noGamesText)
+			return
+		}
+		text, err := t.gamesListText(list)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, fmt.Sprintf(gamesText, text))
+	})
+
+	b.Handle("/importgames", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.gs == nil {
+			b.Send(m.Chat, gamesDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		b.Send(m.Chat, importGamesText, &tb.SendOptions{
+			ReplyMarkup: &tb.ReplyMarkup{
+				InlineKeyboard: [][]tb.InlineButton{[]tb.InlineButton{tb.InlineButton{
+					Text: importGamesLabel,
+					URL:  fmt.Sprintf(startLinkURL, b.Me.Username, importGamesStartPrefix, groupID),
+				}}},
+			},
+		})
+	})
+
+	b.Handle(tb.OnDocument, func(m *tb.Message) {
+		if !m.Private() || m.Document == nil {
+			return
+		}
+		t.promptsMutex.Lock()
+		groupID, ok := t.importPrompts[m.Sender.ID]
+		delete(t.importPrompts, m.Sender.ID)
+		t.promptsMutex.Unlock()
+		if ok {
+			t.importGames(m, groupID)
+		}
+	})
+
+	b.Handle("/nominate", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.gs == nil {
+			b.Send(m.Chat, gamesDisabledText)
+			return
+		}
+		title := strings.TrimSpace(m.Payload)
+		if title == "" {
+			b.Send(m.Chat, nominateUsageText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		meeting, err := t.mf.GetMeeting(groupID)
+		if err != nil {
+			if err == meetings.NoActiveMeeting {
+				b.Send(m.Chat, err.Error())
+			}
+			return
+		}
+		userID, err := t.uf.GetOrCreateUser(&users.ExternalUser{
+			Source:      users.SourceTelegram,
+			ID:          strconv.Itoa(m.Sender.ID),
+			DisplayName: formatUserDisplayName(m.Sender),
+		})
+		if err != nil {
+			return
+		}
+		library, err := t.gs.FindGames(groupID, title)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		nomination := &games.Nomination{MeetingID: meeting.ID, Title: title, NominatedBy: userID}
+		if game := nominatedGame(library, title); game != nil {
+			nomination.GameID = game.ID
+			nomination.Title = game.Title
+		}
+		err = t.gs.Nominate(nomination)
+		if err != nil {
+			if err == games.NominationAlreadyExists {
+				b.Send(m.Chat, err.Error())
+			} else {
+				log.Print(err)
+			}
+			return
+		}
+		b.Send(m.Chat, fmt.Sprintf(nominatedText, nomination.Title))
+		err = t.refreshMeetingMessage(groupID)
+		if err != nil {
+			log.Print(err)
+		}
+	})
+
+	b.Handle(&tb.InlineButton{Unique: voteIdentifier}, func(c *tb.Callback) {
+		err := b.Respond(c, &tb.CallbackResponse{Text: t.vote(c)})
+		if err != nil {
+			log.Print(err)
+		}
+	})
+
+	b.Handle("/tables", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.gs == nil {
+			b.Send(m.Chat, gamesDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		meeting, err := t.mf.GetMeeting(groupID)
+		if err != nil {
+			if err == meetings.NoActiveMeeting {
+				b.Send(m.Chat, err.Error())
+			}
+			return
+		}
+		text, err := t.tablesText(groupID, meeting)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		if text == "" {
+			text = noNominationsText
+		}
+		b.Send(m.Chat, text)
+	})
+
+	b.Handle("/whohas", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.gs == nil {
+			b.Send(m.Chat, gamesDisabledText)
+			return
+		}
+		title := strings.TrimSpace(m.Payload)
+		if title == "" {
+			b.Send(m.Chat, whoHasUsageText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		list, err := t.gs.FindGames(groupID, title)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		if len(list) == 0 {
+			b.Send(m.Chat, fmt.Sprintf(nobodyHasText, title))
+			return
+		}
+		text, err := t.gamesListText(list)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, text)
+	})
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/games"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/tables"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseGame(t *testing.T) {
+	assert := assert.New(t)
+	for input, expected := range map[string]*games.Game{
+		"Catan;3-4;90":           &games.Game{Title: "Catan", MinPlayers: 3, MaxPlayers: 4, Playtime: 90 * time.Minute},
+		" Catan ; 3 - 4 ; 1h30m": &games.Game{Title: "Catan", MinPlayers: 3, MaxPlayers: 4, Playtime: 90 * time.Minute},
+		"Patchwork;2":            &games.Game{Title: "Patchwork", MinPlayers: 2, MaxPlayers: 2},
+		"Azul":                   &games.Game{Title: "Azul"},
+	} {
+		game, err := parseGame(input)
+		assert.NoError(err, input)
+		assert.Equal(game, expected, input)
+	}
+	for input, expected := range map[string]error{
+		"":               ErrInvalidGame,
+		";2":             ErrInvalidGame,
+		"Catan;3-4;90;x": ErrInvalidGame,
+		"Catan;three":    ErrInvalidPlayers,
+		"Catan;4-3":      ErrInvalidPlayers,
+		"Catan;0":        ErrInvalidPlayers,
+		"Catan;3-4;long": ErrInvalidPlaytime,
+		"Catan;3-4;0":    ErrInvalidPlaytime,
+	} {
+		_, err := parseGame(input)
+		assert.Equal(err, expected, input)
+	}
+}
+
+func TestGameText(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(gameText(&games.Game{Title: "Catan", MinPlayers: 3, MaxPlayers: 4, Playtime: 90 * time.Minute}), "Catan (3-4 players, 90 min)")
+	assert.Equal(gameText(&games.Game{Title: "Patchwork", MinPlayers: 2, MaxPlayers: 2}), "Patchwork (2 players)")
+	assert.Equal(gameText(&games.Game{Title: "Azul"}), "Azul")
+	assert.Equal(gameText(&games.Game{Title: "Catan", MinPlayers: 3, MaxPlayers: 4, Weight: 2.32, Year: 1995}), "Catan (1995, 3-4 players, weight 2.3)")
+}
+
+func TestImportSummaryText(t *testing.T) {
+	assert := assert.New(t)
+	catan := &games.Game{Title: "Catan"}
+	azul := &games.Game{Title: "Azul"}
+	wingspan := &games.Game{Title: "Wingspan"}
+	assert.Equal(importSummaryText(&games.ImportSummary{Added: []*games.Game{catan, wingspan}, Shared: []*games.Game{catan}, AlreadyOwned: []*games.Game{azul}}), "Imported 2 game(s)\nAlso owned by other members: Catan\nAlready in your games: Azul")
+	assert.Equal(importSummaryText(&games.ImportSummary{}), "Imported 0 game(s)")
+}
+
+func TestNominationsText(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(nominationsText([]*games.Nomination{
+		&games.Nomination{Title: "Chess", Votes: []string{}},
+		&games.Nomination{Title: "Azul", Votes: []string{"1"}},
+		&games.Nomination{Title: "Catan", Votes: []string{"1", "2"}},
+		&games.Nomination{Title: "Go", Votes: []string{"3"}},
+		&games.Nomination{Title: "Wingspan", Votes: []string{"2"}},
+	}), "\nTop games:\n1. Catan (2)\n2. Azul (1)\n3. Go (1)\n")
+	assert.Equal(nominationsText([]*games.Nomination{&games.Nomination{Title: "Chess", Votes: []string{}}}), "")
+	assert.Equal(nominationsText(nil), "")
+}
+
+func TestNominatedGame(t *testing.T) {
+	assert := assert.New(t)
+	catan := &games.Game{ID: "1", Title: "Catan"}
+	otherCatan := &games.Game{ID: "2", Title: "catan"}
+	seafarers := &games.Game{ID: "3", Title: "Catan: Seafarers"}
+	assert.Equal(nominatedGame([]*games.Game{catan, seafarers}, "CATAN"), catan)
+	assert.Equal(nominatedGame([]*games.Game{catan, otherCatan}, "cat"), catan)
+	assert.Nil(nominatedGame([]*games.Game{catan, seafarers}, "cat"))
+	assert.Nil(nominatedGame(nil, "Chess"))
+}
+
+func TestTablesListText(t *testing.T) {
+	assert := assert.New(t)
+	attendees := []*meetings.Attendee{
+		&meetings.Attendee{UserID: "1", Amount: 3, Guests: []*meetings.Guest{&meetings.Guest{Name: "Bob"}}},
+		&meetings.Attendee{UserID: "2", Amount: 1},
+	}
+	split := []*tables.Table{
+		&tables.Table{Game: &tables.Game{Title: "Catan"}, Seats: []*tables.Seat{&tables.Seat{UserID: "1"}, &tables.Seat{UserID: "1", Guest: true}, &tables.Seat{UserID: "2"}}},
+		&tables.Table{Game: &tables.Game{Title: "Chess"}, Seats: []*tables.Seat{&tables.Seat{UserID: "1", Guest: true}, &tables.Seat{UserID: "3"}}},
+	}
+	usersMap := map[string]*users.ExternalUser{"1": &users.ExternalUser{DisplayName: "alice"}, "2": &users.ExternalUser{DisplayName: "carol"}}
+	assert.Equal(tablesListText(split, attendees, usersMap), "* Catan: alice, Bob, carol\n* Chess: alice's guest, (unknown user)\n")
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/locations"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"log"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidLocation = errors.New("Usage: /addlocation name; address; coordinates; capacity; access notes; accessibility. Only the name is required, for example '/addlocation Home; Av. Corrientes 1234; -34.6037,-58.3816; 8; Ring 3B; Elevator' or '/addlocation Cafe; Florida 100;; 20'")
+var ErrInvalidCoordinates = errors.New("Coordinates must be latitude,longitude. For example -34.6037,-58.3816")
+
+const locationsDisabledText = "Saved locations are not configured"
+const noLocationsText = "No saved locations yet. Add one with /addlocation Home; Av. Corrientes 1234"
+const locationsText = "Saved locations:\n%s"
+const locationSavedText = "Saved %s"
+const locationDeletedText = "Deleted %s"
+const deleteLocationUsageText = "Usage: /deletelocation Home"
+const onlyHostEditsLocationText = "Only the location's host or an admin can change it"
+const unknownLocationText = "There is no saved location named %q, see /locations"
+const locationCapacityText = " (up to %d people)"
+const locationHostText = ", hosted by %s"
+const placeAddressText = "\nAddress: %s"
+const placeMapText = "\nMap: %s"
+const placeAccessText = "\nAccess: %s"
+const placeAccessibilityText = "\nAccessibility: %s"
+
+// parseLocation reads "name; address; coordinates; capacity; access notes;
+// accessibility", where everything but the name can be left empty or out.
+func parseLocation(input string) (*locations.Location, error) {
+	data := strings.Split(input, ";")
+	if len(data) > 6 {
+		return nil, ErrInvalidLocation
+	}
+	for len(data) < 6 {
+		data = append(data, "")
+	}
+	for i := range data {
+		data[i] = strings.TrimSpace(data[i])
+	}
+	place := &locations.Location{
+		Name:          data[0],
+		Address:       data[1],
+		AccessNotes:   data[4],
+		Accessibility: data[5],
+	}
+	if place.Name == "" {
+		return nil, ErrInvalidLocation
+	}
+	if data[2] != "" {
+		coordinates := strings.Split(data[2], ",")
+		if len(coordinates) != 2 {
+			return nil, ErrInvalidCoordinates
+		}
+		var err error
+		place.Latitude, err = strconv.ParseFloat(strings.TrimSpace(coordinates[0]), 64)
+		if err != nil || place.Latitude < -90 || place.Latitude > 90 {
+			return nil, ErrInvalidCoordinates
+		}
+		place.Longitude, err = strconv.ParseFloat(strings.TrimSpace(coordinates[1]), 64)
+		if err != nil || place.Longitude < -180 || place.Longitude > 180 {
+			return nil, ErrInvalidCoordinates
+		}
+	}
+	if data[3] != "" {
+		capacity, err := strconv.Atoi(data[3])
+		if err != nil || capacity < 0 {
+			return nil, ErrInvalidCapacity
+		}
+		place.MaxCapacity = capacity
+	}
+	return place, nil
+}
+
+// placeText is what the meeting message tells about a saved location
+func placeText(place *locations.Location) string {
+	text := ""
+	if place.Address != "" {
+		text += fmt.Sprintf(placeAddressText, place.Address)
+	}
+	if url := place.MapURL(); url != "" {
+		text += fmt.Sprintf(placeMapText, url)
+	}
+	if place.AccessNotes != "" {
+		text += fmt.Sprintf(placeAccessText, place.AccessNotes)
+	}
+	if place.Accessibility != "" {
+		text += fmt.Sprintf(placeAccessibilityText, place.Accessibility)
+	}
+	return text
+}
+
+func placeVenue(place *locations.Location) *tb.Venue {
+	return &tb.Venue{
+		Location: tb.Location{Lat: float32(place.Latitude), Lng: float32(place.Longitude)},
+		Title:    place.Name,
+		Address:  place.Address,
+	}
+}
+
+func locationText(place *locations.Location, usersMap map[string]*users.ExternalUser) string {
+	text := "* " + place.Name
+	if place.MaxCapacity > 0 {
+		text += fmt.Sprintf(locationCapacityText, place.MaxCapacity)
+	}
+	if user, found := usersMap[place.HostID]; found {
+		text += fmt.Sprintf(locationHostText, user.DisplayName)
+	}
+	return text + placeText(place) + "\n"
+}
+
+func (t *telegram) locationsListText(list []*locations.Location) (string, error) {
+	userIDs := make([]string, len(list))
+	for i, place := range list {
+		userIDs[i] = place.HostID
+	}
+	usersMap, err := t.uf.GetUsers(userIDs)
+	if err != nil {
+		return "", err
+	}
+	text := ""
+	for _, place := range list {
+		text += locationText(place, usersMap)
+	}
+	return text, nil
+}
+
+// savedLocations returns the group's saved locations, or none when they are
+// not configured.
+func (t *telegram) savedLocations(groupID string) ([]*locations.Location, error) {
+	if t.ls == nil {
+		return nil, nil
+	}
+	return t.ls.GetLocations(groupID)
+}
+
+// meetingPlace returns the saved location of the group's meeting, or nil
+// when it has none, it was deleted or it belongs to another group.
+func (t *telegram) meetingPlace(groupID string, meeting *meetings.Meeting) *locations.Location {
+	if t.ls == nil || meeting.LocationID == "" {
+		return nil
+	}
+	place, err := t.ls.GetLocation(meeting.LocationID)
+	if err != nil {
+		if err != locations.LocationNotFound {
+			log.Print(err)
+		}
+		return nil
+	}
+	if place.GroupID != groupID {
+		return nil
+	}
+	return place
+}
+
+// addLocation saves the location described in the message, hosted by the
+// sender. A location with the same name is replaced if the sender hosts it
+// or is an admin.
+func (t *telegram) addLocation(m *tb.Message, groupID string) (string, error) {
+	place, err := parseLocation(m.Payload)
+	if err != nil {
+		return err.Error(), nil
+	}
+	userID, err := t.uf.GetOrCreateUser(&users.ExternalUser{
+		Source:      users.SourceTelegram,
+		ID:          strconv.Itoa(m.Sender.ID),
+		DisplayName: formatUserDisplayName(m.Sender),
+	})
+	if err != nil {
+		return "", err
+	}
+	list, err := t.ls.GetLocations(groupID)
+	if err != nil {
+		return "", err
+	}
+	place.GroupID = groupID
+	place.HostID = userID
+	if existing := locations.Find(list, place.Name); existing != nil {
+		if existing.HostID != userID && !t.isAdmin(m.Chat, m.Sender) {
+			return onlyHostEditsLocationText, nil
+		}
+		place.ID = existing.ID
+		place.HostID = existing.HostID
+		place.Rotation = existing.Rotation
+		err = t.ls.UpdateLocation(place)
+	} else {
+		err = t.ls.CreateLocation(place)
+	}
+	if err != nil {
+		if err == locations.LocationAlreadyExists || err == locations.LocationNotFound {
+			return err.Error(), nil
+		}
+		return "", err
+	}
+	text, err := t.locationsListText([]*locations.Location{place})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(locationSavedText, text), nil
+}
+
+// deleteLocation deletes the location named in the message if the sender
+// hosts it or is an admin. Meetings that took place there keep its name.
+func (t *telegram) deleteLocation(m *tb.Message, groupID string) (string, error) {
+	name := strings.TrimSpace(m.Payload)
+	if name == "" {
+		return deleteLocationUsageText, nil
+	}
+	list, err := t.ls.GetLocations(groupID)
+	if err != nil {
+		return "", err
+	}
+	place := locations.Find(list, name)
+	if place == nil {
+		return fmt.Sprintf(unknownLocationText, name), nil
+	}
+	userID, err := t.uf.GetOrCreateUser(&users.ExternalUser{
+		Source:      users.SourceTelegram,
+		ID:          strconv.Itoa(m.Sender.ID),
+		DisplayName: formatUserDisplayName(m.Sender),
+	})
+	if err != nil {
+		return "", err
+	}
+	if place.HostID != userID && !t.isAdmin(m.Chat, m.Sender) {
+		return onlyHostEditsLocationText, nil
+	}
+	err = t.ls.DeleteLocation(groupID, place.ID)
+	if err != nil {
+		if err == locations.LocationNotFound {
+			return err.Error(), nil
+		}
+		return "", err
+	}
+	return fmt.Sprintf(locationDeletedText, place.Name), nil
+}
+
+func (t *telegram) registerLocationHandlers() {
+	b := t.b
+
+	b.Handle("/addlocation", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.ls == nil {
+			b.Send(m.Chat, locationsDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		text, err := t.addLocation(m, groupID)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, text)
+	})
+
+	b.Handle("/locations", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.ls == nil {
+			b.Send(m.Chat, locationsDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		list, err := t.ls.GetLocations(groupID)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		if len(list) == 0 {
+			b.Send(m.Chat, noLocationsText)
+			return
+		}
+		text, err := t.locationsListText(list)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, fmt.Sprintf(locationsText, text))
+	})
+
+	b.Handle("/deletelocation", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.ls == nil {
+			b.Send(m.Chat, locationsDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		text, err := t.deleteLocation(m, groupID)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, text)
+	})
+}
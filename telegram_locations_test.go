@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/locations"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseLocation(t *testing.T) {
+	assert := assert.New(t)
+	place, err := parseLocation(" Home ; Av. Corrientes 1234; -34.6037, -58.3816; 8; Ring 3B; Elevator")
+	assert.NoError(err)
+	assert.Equal(place, &locations.Location{Name: "Home", Address: "Av. Corrientes 1234", Latitude: -34.6037, Longitude: -58.3816, MaxCapacity: 8, AccessNotes: "Ring 3B", Accessibility: "Elevator"})
+
+	place, err = parseLocation("Cafe; Florida 100;; 20")
+	assert.NoError(err)
+	assert.Equal(place, &locations.Location{Name: "Cafe", Address: "Florida 100", MaxCapacity: 20})
+
+	place, err = parseLocation("Park")
+	assert.NoError(err)
+	assert.Equal(place, &locations.Location{Name: "Park"})
+
+	_, err = parseLocation("")
+	assert.Equal(err, ErrInvalidLocation)
+	_, err = parseLocation("; Florida 100")
+	assert.Equal(err, ErrInvalidLocation)
+	_, err = parseLocation("a;b;c;d;e;f;g")
+	assert.Equal(err, ErrInvalidLocation)
+	_, err = parseLocation("Home;; -34.6")
+	assert.Equal(err, ErrInvalidCoordinates)
+	_, err = parseLocation("Home;; 100,20")
+	assert.Equal(err, ErrInvalidCoordinates)
+	_, err = parseLocation("Home;;; many")
+	assert.Equal(err, ErrInvalidCapacity)
+}
+
+func TestLocationText(t *testing.T) {
+	assert := assert.New(t)
+	usersMap := map[string]*users.ExternalUser{"1": &users.ExternalUser{DisplayName: "alice"}}
+	place := &locations.Location{Name: "Home", HostID: "1", MaxCapacity: 8, Latitude: -34.6037, Longitude: -58.3816, Accessibility: "Elevator"}
+	assert.Equal(locationText(place, usersMap), "* Home (up to 8 people), hosted by alice\nMap: https://www.google.com/maps/search/?api=1&query=-34.6037%2C-58.3816\nAccessibility: Elevator\n")
+	assert.Equal(locationText(&locations.Location{Name: "Park", HostID: "2"}, usersMap), "* Park\n")
+}
+
+func TestMeetingPlace(t *testing.T) {
+	assert := assert.New(t)
+	ls := locations.NewMemory()
+	home := &locations.Location{GroupID: "1", Name: "Home", Address: "Av. Corrientes 1234"}
+	assert.NoError(ls.CreateLocation(home))
+	tg := &telegram{ls: ls}
+
+	assert.Equal(tg.meetingPlace("1", &meetings.Meeting{LocationID: home.ID}), home)
+	assert.Nil(tg.meetingPlace("2", &meetings.Meeting{LocationID: home.ID}))
+	assert.Nil(tg.meetingPlace("1", &meetings.Meeting{}))
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/games"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidPlay = errors.New("Usage: /logplay game; players. For example '/logplay Catan; alice 10, bob 8, me 7', '/logplay Codenames; alice*, bob' or '/logplay Pandemic; won; alice, bob'. Without players everybody who went is added")
+var ErrMissingScores = errors.New("Either every player or none of them has a score")
+var ErrNoPastMeeting = errors.New("There is no past meeting to log plays for")
+
+const unknownPlayerText = "%q did not go to the last meeting"
+const ambiguousPlayerText = "%q could be more than one person, use their username"
+const playLoggedText = "Logged %s"
+const noPlaysText = "No plays logged yet. Log one with /logplay Catan; alice 10, bob 8"
+const playsText = "Recent plays:\n%s"
+const playDateFormat = "02 Jan 2006"
+const wonText = "won"
+const lostText = "lost"
+const winnerText = "%s (winner)"
+
+// maxPlays is how many plays /plays lists
+const maxPlays = 10
+
+// parsePlay reads "game[; won|lost][; players]", where players is a comma
+// separated list of names, each optionally followed by a score and marked
+// with a trailing * when they won. It returns the play along with the names
+// of its players, in the same order. When scores are given and nobody is
+// marked, the highest scores win.
+func parsePlay(input string) (*games.Play, []string, error) {
+	data := strings.Split(input, ";")
+	play := &games.Play{Title: strings.TrimSpace(data[0]), Players: []*games.Player{}}
+	if play.Title == "" || len(data) > 3 {
+		return nil, nil, ErrInvalidPlay
+	}
+	names := []string{}
+	segments := data[1:]
+	if len(segments) > 0 {
+		result := strings.ToLower(strings.TrimSpace(segments[0]))
+		if result == wonText || result == lostText {
+			play.Cooperative = true
+			play.Won = result == wonText
+			segments = segments[1:]
+		} else if len(segments) > 1 {
+			return nil, nil, ErrInvalidPlay
+		}
+	}
+	for _, segment := range segments {
+		for _, entry := range strings.Split(segment, ",") {
+			name, player, err := parsePlayer(entry)
+			if err != nil {
+				return nil, nil, err
+			}
+			names = append(names, name)
+			play.Players = append(play.Players, player)
+		}
+	}
+	scored := 0
+	marked := false
+	for _, player := range play.Players {
+		if player.Score != math.MinInt32 {
+			scored++
+		}
+		marked = marked || player.Winner
+	}
+	if scored != 0 && scored != len(play.Players) {
+		return nil, nil, ErrMissingScores
+	}
+	if play.Cooperative && marked {
+		return nil, nil, ErrInvalidPlay
+	}
+	play.Scored = scored > 0
+	best := math.MinInt32
+	for _, player := range play.Players {
+		if !play.Scored {
+			player.Score = 0
+		} else if player.Score > best {
+			best = player.Score
+		}
+	}
+	if play.Scored && !play.Cooperative && !marked {
+		for _, player := range play.Players {
+			player.Winner = player.Score == best
+		}
+	}
+	return play, names, nil
+}
+
+// parsePlayer reads "name [score][*]". The score is math.MinInt32 when it
+// is missing.
+func parsePlayer(entry string) (string, *games.Player, error) {
+	entry = strings.TrimSpace(entry)
+	player := &games.Player{Score: math.MinInt32}
+	if strings.HasSuffix(entry, "*") {
+		player.Winner = true
+		entry = strings.TrimSpace(strings.TrimSuffix(entry, "*"))
+	}
+	fields := strings.Fields(entry)
+	if len(fields) == 0 {
+		return "", nil, ErrInvalidPlay
+	}
+	if len(fields) > 1 {
+		if score, err := strconv.Atoi(fields[len(fields)-1]); err == nil {
+			player.Score = score
+			fields = fields[:len(fields)-1]
+		}
+	}
+	return strings.Join(fields, " "), player, nil
+}
+
+// playCandidate is someone who can be named as a player
+type playCandidate struct {
+	userID string
+	names  []string
+}
+
+// matchPlayer finds who a name refers to, either by their whole display
+// name or by its first word, which is the username when there is one.
+// unknownText formats the error when nobody matches.
+func matchPlayer(name string, candidates []*playCandidate, unknownText string) (string, error) {
+	name = strings.ToLower(strings.TrimPrefix(name, "@"))
+	found := map[string]bool{}
+	userID := ""
+	for _, candidate := range candidates {
+		for _, candidateName := range candidate.names {
+			candidateName = strings.ToLower(strings.TrimPrefix(candidateName, "@"))
+			fields := strings.Fields(candidateName)
+			if candidateName == name || (len(fields) > 0 && fields[0] == name) {
+				found[candidate.userID] = true
+				userID = candidate.userID
+			}
+		}
+	}
+	if len(found) == 0 {
+		return "", fmt.Errorf(unknownText, name)
+	}
+	if len(found) > 1 {
+		return "", fmt.Errorf(ambiguousPlayerText, name)
+	}
+	return userID, nil
+}
+
+func (t *telegram) lastMeeting(groupID string) (*meetings.Meeting, error) {
+	list, err := t.mf.GetMeetings(groupID)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(list) - 1; i >= 0; i-- {
+		if list[i].Closed && !list[i].Cancelled {
+			return list[i], nil
+		}
+	}
+	return nil, ErrNoPastMeeting
+}
+
+// playCandidates returns the people who RSVPed to the meeting and the
+// guests they brought who are known users.
+func (t *telegram) playCandidates(meeting *meetings.Meeting) ([]*playCandidate, error) {
+	attendees, err := t.mf.GetMeetingAttendeesByID(meeting.ID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := []string{}
+	for _, attendee := range attendees {
+		userIDs = append(userIDs, attendee.UserID)
+		for _, guest := range attendee.Guests {
+			if guest.UserID != "" {
+				userIDs = append(userIDs, guest.UserID)
+			}
+		}
+	}
+	usersMap, err := t.uf.GetUsers(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	candidates := []*playCandidate{}
+	for _, attendee := range attendees {
+		if attendee.Amount <= 0 {
+			continue
+		}
+		candidate := &playCandidate{userID: attendee.UserID}
+		if user, found := usersMap[attendee.UserID]; found {
+			candidate.names = append(candidate.names, user.DisplayName)
+		}
+		candidates = append(candidates, candidate)
+		for _, guest := range attendee.Guests {
+			if guest.UserID == "" {
+				continue
+			}
+			candidate := &playCandidate{userID: guest.UserID, names: []string{guest.Name, guest.Handle}}
+			if user, found := usersMap[guest.UserID]; found {
+				candidate.names = append(candidate.names, user.DisplayName)
+			}
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates, nil
+}
+
+// logPlay logs the play described in the message for the group's last
+// meeting, and returns the answer to send back.
+func (t *telegram) logPlay(m *tb.Message, groupID string) (string, error) {
+	play, names, err := parsePlay(m.Payload)
+	if err != nil {
+		return err.Error(), nil
+	}
+	meeting, err := t.lastMeeting(groupID)
+	if err != nil {
+		if err == ErrNoPastMeeting {
+			return err.Error(), nil
+		}
+		return "", err
+	}
+	userID, err := t.uf.GetOrCreateUser(&users.ExternalUser{
+		Source:      users.SourceTelegram,
+		ID:          strconv.Itoa(m.Sender.ID),
+		DisplayName: formatUserDisplayName(m.Sender),
+	})
+	if err != nil {
+		return "", err
+	}
+	candidates, err := t.playCandidates(meeting)
+	if err != nil {
+		return "", err
+	}
+	if len(play.Players) == 0 {
+		for _, candidate := range candidates {
+			play.Players = append(play.Players, &games.Player{UserID: candidate.userID})
+		}
+	}
+	for i, name := range names {
+		if strings.EqualFold(name, "me") {
+			play.Players[i].UserID = userID
+			continue
+		}
+		play.Players[i].UserID, err = matchPlayer(name, candidates, unknownPlayerText)
+		if err != nil {
+			return err.Error(), nil
+		}
+	}
+	library, err := t.gs.FindGames(groupID, play.Title)
+	if err != nil {
+		return "", err
+	}
+	if game := nominatedGame(library, play.Title); game != nil {
+		play.GameID = game.ID
+		play.Title = game.Title
+	}
+	play.GroupID = groupID
+	play.MeetingID = meeting.ID
+	play.Time = meeting.Time
+	play.LoggedBy = userID
+	err = t.storePlay(play)
+	if err != nil {
+		if err == games.InvalidPlay {
+			return err.Error(), nil
+		}
+		return "", err
+	}
+	text, err := t.playsListText([]*games.Play{play}, t.groupLocation(groupID))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(playLoggedText, text), nil
+}
+
+func playText(play *games.Play, usersMap map[string]*users.ExternalUser, location *time.Location) string {
+	players := make([]string, len(play.Players))
+	for i, player := range play.Players {
+		players[i] = "(unknown user)"
+		if user, found := usersMap[player.UserID]; found {
+			players[i] = user.DisplayName
+		}
+		if play.Scored {
+			players[i] += " " + strconv.Itoa(player.Score)
+		}
+		if player.Winner {
+			players[i] = fmt.Sprintf(winnerText, players[i])
+		}
+	}
+	title := play.Title
+	if play.Cooperative && play.Won {
+		title += " (" + wonText + ")"
+	} else if play.Cooperative {
+		title += " (" + lostText + ")"
+	}
+	return fmt.Sprintf("* #%s %s %s: %s\n", play.ID, play.Time.In(location).Format(playDateFormat), title, strings.Join(players, ", "))
+}
+
+func (t *telegram) playsListText(list []*games.Play, location *time.Location) (string, error) {
+	userIDs := []string{}
+	for _, play := range list {
+		for _, player := range play.Players {
+			userIDs = append(userIDs, player.UserID)
+		}
+	}
+	usersMap, err := t.uf.GetUsers(userIDs)
+	if err != nil {
+		return "", err
+	}
+	text := ""
+	for _, play := range list {
+		text += playText(play, usersMap, location)
+	}
+	return text, nil
+}
+
+func (t *telegram) registerPlayHandlers() {
+	b := t.b
+
+	b.Handle("/logplay", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.gs == nil {
+			b.Send(m.Chat, gamesDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		text, err := t.logPlay(m, groupID)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, text)
+	})
+
+	b.Handle("/plays", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.gs == nil {
+			b.Send(m.Chat, gamesDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		list, err := t.gs.GetPlays(groupID)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		title := strings.ToLower(strings.TrimSpace(m.Payload))
+		plays := []*games.Play{}
+		for _, play := range list {
+			if len(plays) < maxPlays && strings.Contains(strings.ToLower(play.Title), title) {
+				plays = append(plays, play)
+			}
+		}
+		if len(plays) == 0 {
+			b.Send(m.Chat, noPlaysText)
+			return
+		}
+		text, err := t.playsListText(plays, t.groupLocation(groupID))
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, fmt.Sprintf(playsText, text))
+	})
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/games"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParsePlay(t *testing.T) {
+	assert := assert.New(t)
+	type parsed struct {
+		play  *games.Play
+		names []string
+	}
+	for input, expected := range map[string]parsed{
+		"Catan; alice 10, bob 8, me 10": parsed{
+			play: &games.Play{Title: "Catan", Scored: true, Players: []*games.Player{
+				&games.Player{Score: 10, Winner: true},
+				&games.Player{Score: 8},
+				&games.Player{Score: 10, Winner: true},
+			}},
+			names: []string{"alice", "bob", "me"},
+		},
+		"Catan; alice 10, bob 8*": parsed{
+			play: &games.Play{Title: "Catan", Scored: true, Players: []*games.Player{
+				&games.Player{Score: 10},
+				&games.Player{Score: 8, Winner: true},
+			}},
+			names: []string{"alice", "bob"},
+		},
+		"Codenames; alice*, Bob Smith": parsed{
+			play: &games.Play{Title: "Codenames", Players: []*games.Player{
+				&games.Player{Winner: true},
+				&games.Player{},
+			}},
+			names: []string{"alice", "Bob Smith"},
+		},
+		"Pandemic; Won; alice, bob": parsed{
+			play: &games.Play{Title: "Pandemic", Cooperative: true, Won: true, Players: []*games.Player{
+				&games.Player{},
+				&games.Player{},
+			}},
+			names: []string{"alice", "bob"},
+		},
+		"Pandemic; lost": parsed{
+			play:  &games.Play{Title: "Pandemic", Cooperative: true, Players: []*games.Player{}},
+			names: []string{},
+		},
+		"Azul": parsed{
+			play:  &games.Play{Title: "Azul", Players: []*games.Player{}},
+			names: []string{},
+		},
+	} {
+		play, names, err := parsePlay(input)
+		assert.NoError(err, input)
+		assert.Equal(play, expected.play, input)
+		assert.Equal(names, expected.names, input)
+	}
+	for input, expected := range map[string]error{
+		"":                          ErrInvalidPlay,
+		"; alice":                   ErrInvalidPlay,
+		"Catan; alice, , bob":       ErrInvalidPlay,
+		"Catan; alice; bob":         ErrInvalidPlay,
+		"Catan; won; alice; bob":    ErrInvalidPlay,
+		"Pandemic; won; alice*":     ErrInvalidPlay,
+		"Catan; alice 10, bob":      ErrMissingScores,
+		"Catan; alice 10, bob 8, c": ErrMissingScores,
+	} {
+		_, _, err := parsePlay(input)
+		assert.Equal(err, expected, input)
+	}
+}
+
+func TestMatchPlayer(t *testing.T) {
+	assert := assert.New(t)
+	candidates := []*playCandidate{
+		&playCandidate{userID: "1", names: []string{"alice (Alice Smith)"}},
+		&playCandidate{userID: "2", names: []string{"Bob"}},
+		&playCandidate{userID: "3", names: []string{"Bob", "@bobby"}},
+	}
+	userID, err := matchPlayer("@Alice", candidates, unknownPlayerText)
+	assert.NoError(err)
+	assert.Equal(userID, "1")
+	userID, err = matchPlayer("alice (alice smith)", candidates, unknownPlayerText)
+	assert.NoError(err)
+	assert.Equal(userID, "1")
+	userID, err = matchPlayer("bobby", candidates, unknownPlayerText)
+	assert.NoError(err)
+	assert.Equal(userID, "3")
+	_, err = matchPlayer("bob", candidates, unknownPlayerText)
+	assert.EqualError(err, `"bob" could be more than one person, use their username`)
+	_, err = matchPlayer("carol", candidates, unknownPlayerText)
+	assert.EqualError(err, `"carol" did not go to the last meeting`)
+}
+
+func TestPlayText(t *testing.T) {
+	assert := assert.New(t)
+	usersMap := map[string]*users.ExternalUser{"1": &users.ExternalUser{DisplayName: "alice"}, "2": &users.ExternalUser{DisplayName: "bob"}}
+	june := time.Date(2019, 6, 7, 1, 0, 0, 0, time.UTC)
+	location := time.FixedZone("UTC-3", -3*60*60)
+	assert.Equal(playText(&games.Play{ID: "3", Title: "Catan", Time: june, Scored: true, Players: []*games.Player{
+		&games.Player{UserID: "1", Score: 10, Winner: true},
+		&games.Player{UserID: "2", Score: 8},
+		&games.Player{UserID: "3", Score: 7},
+	}}, usersMap, location), "* #3 06 Jun 2019 Catan: alice 10 (winner), bob 8, (unknown user) 7\n")
+	assert.Equal(playText(&games.Play{ID: "4", Title: "Pandemic", Time: june, Cooperative: true, Players: []*games.Player{
+		&games.Player{UserID: "1"},
+		&games.Player{UserID: "2"},
+	}}, usersMap, time.UTC), "* #4 07 Jun 2019 Pandemic (lost): alice, bob\n")
+}
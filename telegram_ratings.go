@@ -0,0 +1,292 @@
+package main
+
+import (
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/games"
+	"github.com/seppo0010/boardgamesorganizer/ratings"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"log"
+	"strconv"
+	"strings"
+)
+
+const leaderboardText = "Leaderboard:\n%s"
+const gameLeaderboardText = "Leaderboard for %s:\n%s"
+const noRatingsText = "Nobody has rated plays yet. Competitive plays logged with /logplay are rated"
+const noGameRatingsText = "No rated plays of %q"
+const unratedPlayerText = "%q has no rated plays"
+const ratingText = "%s: %.0f overall (%s)\n"
+const gameRatingText = "* %s: %.0f (%s)\n"
+const ratingPlaysText = "%d plays, %d wins"
+const deletePlayUsageText = "Usage: /deleteplay 12, with the number /plays shows"
+const onlyLoggerDeletesText = "Only whoever logged a play or an admin can delete it"
+const playDeletedText = "Deleted %s"
+
+// maxLeaderboard is how many players /leaderboard lists
+const maxLeaderboard = 10
+
+// withRatings runs f with the group's ratings, computing them from the
+// logged plays if they are not known yet. f must not keep them.
+func (t *telegram) withRatings(groupID string, f func(r *ratings.Ratings) error) error {
+	t.ratingsMutex.Lock()
+	defer t.ratingsMutex.Unlock()
+	r, found := t.groupRatings[groupID]
+	if !found {
+		plays, err := t.gs.GetPlays(groupID)
+		if err != nil {
+			return err
+		}
+		r = ratings.Compute(plays)
+		t.groupRatings[groupID] = r
+	}
+	return f(r)
+}
+
+// storePlay logs a play and updates the group's ratings with it, holding
+// the ratings while at it so they cannot be computed with the play before
+// it is added.
+func (t *telegram) storePlay(play *games.Play) error {
+	t.ratingsMutex.Lock()
+	defer t.ratingsMutex.Unlock()
+	err := t.gs.LogPlay(play)
+	if err != nil {
+		return err
+	}
+	if r, found := t.groupRatings[play.GroupID]; found {
+		r.Add(play)
+	}
+	return nil
+}
+
+// forgetRatings makes the group's ratings be computed again, since removing
+// a play changes every rating after it.
+func (t *telegram) forgetRatings(groupID string) {
+	t.ratingsMutex.Lock()
+	defer t.ratingsMutex.Unlock()
+	delete(t.groupRatings, groupID)
+}
+
+func ratingPlays(rating *ratings.Rating) string {
+	return fmt.Sprintf(ratingPlaysText, rating.Plays, rating.Wins)
+}
+
+func leaderboardListText(leaderboard []*ratings.Rating, usersMap map[string]*users.ExternalUser) string {
+	text := ""
+	for i, rating := range leaderboard {
+		if i == maxLeaderboard {
+			break
+		}
+		name := "(unknown user)"
+		if user, found := usersMap[rating.UserID]; found {
+			name = user.DisplayName
+		}
+		text += fmt.Sprintf("%d. %s %.0f (%s)\n", i+1, name, rating.Rating, ratingPlays(rating))
+	}
+	return text
+}
+
+// userRatingText shows the user's overall rating and their rating in every
+// game they played.
+func userRatingText(r *ratings.Ratings, userID string, name string) string {
+	text := fmt.Sprintf(ratingText, name, r.Overall[userID].Rating, ratingPlays(r.Overall[userID]))
+	for _, key := range r.UserGames(userID) {
+		rating := r.Games[key][userID]
+		text += fmt.Sprintf(gameRatingText, r.Titles[key], rating.Rating, ratingPlays(rating))
+	}
+	return text
+}
+
+func (t *telegram) leaderboard(groupID string, title string) (string, error) {
+	text := ""
+	err := t.withRatings(groupID, func(r *ratings.Ratings) error {
+		key := ""
+		if title != "" {
+			found := false
+			key, found = r.FindGame(title)
+			if !found {
+				text = fmt.Sprintf(noGameRatingsText, title)
+				return nil
+			}
+		}
+		leaderboard := r.Leaderboard(key)
+		if len(leaderboard) == 0 {
+			text = noRatingsText
+			return nil
+		}
+		userIDs := make([]string, len(leaderboard))
+		for i, rating := range leaderboard {
+			userIDs[i] = rating.UserID
+		}
+		usersMap, err := t.uf.GetUsers(userIDs)
+		if err != nil {
+			return err
+		}
+		if key == "" {
+			text = fmt.Sprintf(leaderboardText, leaderboardListText(leaderboard, usersMap))
+		} else {
+			text = fmt.Sprintf(gameLeaderboardText, r.Titles[key], leaderboardListText(leaderboard, usersMap))
+		}
+		return nil
+	})
+	return text, err
+}
+
+// rating shows the rating of whoever is named, or of the sender when nobody
+// is.
+func (t *telegram) rating(m *tb.Message, groupID string) (string, error) {
+	text := ""
+	err := t.withRatings(groupID, func(r *ratings.Ratings) error {
+		userIDs := []string{}
+		for userID := range r.Overall {
+			userIDs = append(userIDs, userID)
+		}
+		usersMap, err := t.uf.GetUsers(userIDs)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSpace(m.Payload)
+		userID := ""
+		if name == "" || strings.EqualFold(name, "me") {
+			name = formatUserDisplayName(m.Sender)
+			userID, err = t.uf.GetOrCreateUser(&users.ExternalUser{
+				Source:      users.SourceTelegram,
+				ID:          strconv.Itoa(m.Sender.ID),
+				DisplayName: name,
+			})
+			if err != nil {
+				return err
+			}
+			if r.Overall[userID] == nil {
+				text = fmt.Sprintf(unratedPlayerText, name)
+				return nil
+			}
+		} else {
+			candidates := []*playCandidate{}
+			for _, userID := range userIDs {
+				if user, found := usersMap[userID]; found {
+					candidates = append(candidates, &playCandidate{userID: userID, names: []string{user.DisplayName}})
+				}
+			}
+			userID, err = matchPlayer(name, candidates, unratedPlayerText)
+			if err != nil {
+				text = err.Error()
+				return nil
+			}
+			name = usersMap[userID].DisplayName
+		}
+		text = userRatingText(r, userID, name)
+		return nil
+	})
+	return text, err
+}
+
+// deletePlay deletes the play whose ID is in the message if the sender
+// logged it or is an admin, and returns the answer to send back.
+func (t *telegram) deletePlay(m *tb.Message, groupID string) (string, error) {
+	playID := strings.TrimPrefix(strings.TrimSpace(m.Payload), "#")
+	if playID == "" {
+		return deletePlayUsageText, nil
+	}
+	plays, err := t.gs.GetPlays(groupID)
+	if err != nil {
+		return "", err
+	}
+	var play *games.Play
+	for _, p := range plays {
+		if p.ID == playID {
+			play = p
+		}
+	}
+	if play == nil {
+		return games.PlayNotFound.Error(), nil
+	}
+	userID, err := t.uf.GetOrCreateUser(&users.ExternalUser{
+		Source:      users.SourceTelegram,
+		ID:          strconv.Itoa(m.Sender.ID),
+		DisplayName: formatUserDisplayName(m.Sender),
+	})
+	if err != nil {
+		return "", err
+	}
+	if play.LoggedBy != userID && !t.isAdmin(m.Chat, m.Sender) {
+		return onlyLoggerDeletesText, nil
+	}
+	text, err := t.playsListText([]*games.Play{play}, t.groupLocation(groupID))
+	if err != nil {
+		return "", err
+	}
+	err = t.gs.DeletePlay(play.ID)
+	if err != nil {
+		if err == games.PlayNotFound {
+			return err.Error(), nil
+		}
+		return "", err
+	}
+	t.forgetRatings(groupID)
+	return fmt.Sprintf(playDeletedText, text), nil
+}
+
+func (t *telegram) registerRatingHandlers() {
+	b := t.b
+
+	b.Handle("/leaderboard", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.gs == nil {
+			b.Send(m.Chat, gamesDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		text, err := t.leaderboard(groupID, strings.TrimSpace(m.Payload))
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, text)
+	})
+
+	b.Handle("/rating", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.gs == nil {
+			b.Send(m.Chat, gamesDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		text, err := t.rating(m, groupID)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, text)
+	})
+
+	b.Handle("/deleteplay", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.gs == nil {
+			b.Send(m.Chat, gamesDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		text, err := t.deletePlay(m, groupID)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, text)
+	})
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/games"
+	"github.com/seppo0010/boardgamesorganizer/ratings"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLeaderboardListText(t *testing.T) {
+	assert := assert.New(t)
+	leaderboard := []*ratings.Rating{
+		&ratings.Rating{UserID: "1", Rating: 1516.4, Plays: 2, Wins: 2},
+		&ratings.Rating{UserID: "2", Rating: 1483.6, Plays: 2},
+	}
+	usersMap := map[string]*users.ExternalUser{"1": &users.ExternalUser{DisplayName: "alice"}}
+	assert.Equal(leaderboardListText(leaderboard, usersMap), "1. alice 1516 (2 plays, 2 wins)\n2. (unknown user) 1484 (2 plays, 0 wins)\n")
+}
+
+func TestUserRatingText(t *testing.T) {
+	assert := assert.New(t)
+	r := ratings.Compute([]*games.Play{
+		&games.Play{Title: "Chess", Players: []*games.Player{&games.Player{UserID: "1", Winner: true}, &games.Player{UserID: "2"}}},
+		&games.Play{Title: "Catan", Players: []*games.Player{&games.Player{UserID: "2", Winner: true}, &games.Player{UserID: "1"}}},
+	})
+	assert.Equal(userRatingText(r, "1", "alice"), "alice: 1501 overall (2 plays, 1 wins)\n* Catan: 1484 (1 plays, 0 wins)\n* Chess: 1516 (1 plays, 1 wins)\n")
+}
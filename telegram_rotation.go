@@ -0,0 +1,503 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/dates"
+	"github.com/seppo0010/boardgamesorganizer/locations"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/rotation"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRotation = errors.New("Usage: /joinrotation location; capacity. For example '/joinrotation Home; 8'. Add its address and more with /addlocation")
+
+const proposeHostUsageText = "Usage: /proposehost friday 20:00"
+const joinedRotationText = "You are in the host rotation with %s"
+const leftRotationText = "You left the host rotation"
+const notInRotationText = "You are not in the host rotation"
+const emptyRotationText = "Nobody is in the host rotation. Join it with /joinrotation Home; 8"
+const rotationText = "Host rotation, next first:\n%s"
+const rotationHostText = "%d. %s at %s"
+const lastHostedText = ", last hosted %s"
+const neverHostedText = ", never hosted"
+const hostProposalText = "Can you host board games at %s on %s? If you accept I will create the meeting"
+const hostAskedText = "Asked %s to host on %s"
+const noHostText = "Nobody in the host rotation can host on %s. Create the meeting as usual"
+const hostAcceptedText = "You are hosting at %s on %s. Thanks!"
+const hostDeclinedText = "OK, I will ask someone else"
+const acceptHostLabel = "Accept"
+const declineHostLabel = "Decline"
+const acceptHostIdentifier = "acceptHost"
+const declineHostIdentifier = "declineHost"
+const proposalReplacedText = "No longer looking for a host on %s"
+const proposalClosedResponse = "This request is no longer open"
+const onlyProposedHostResponse = "Only the proposed host can answer"
+const hostAcceptedResponse = "Meeting created!"
+const hostDeclinedResponse = "Declined"
+
+// hostProposal is a meeting the bot is looking for a host for
+type hostProposal struct {
+	time time.Time
+	// locationID is the location whose host was asked last and can answer
+	locationID string
+	// asked holds the IDs of the locations whose host was already asked
+	asked map[string]bool
+}
+
+// parseRotationJoin reads "location[; capacity]". The capacity is zero
+// when it is not given.
+func parseRotationJoin(input string) (string, int, error) {
+	data := strings.Split(input, ";")
+	name := strings.TrimSpace(data[0])
+	if name == "" || len(data) > 2 {
+		return "", 0, ErrInvalidRotation
+	}
+	if len(data) < 2 || strings.TrimSpace(data[1]) == "" {
+		return name, 0, nil
+	}
+	capacity, err := strconv.Atoi(strings.TrimSpace(data[1]))
+	if err != nil || capacity <= 0 {
+		return "", 0, ErrInvalidRotation
+	}
+	return name, capacity, nil
+}
+
+func rotationListText(hosts []*rotation.Host, usersMap map[string]*users.ExternalUser, location *time.Location) string {
+	text := ""
+	for i, host := range hosts {
+		name := "(unknown user)"
+		if user, found := usersMap[host.Location.HostID]; found {
+			name = user.DisplayName
+		}
+		text += fmt.Sprintf(rotationHostText, i+1, name, host.Location.Name)
+		if host.Location.MaxCapacity > 0 {
+			text += fmt.Sprintf(locationCapacityText, host.Location.MaxCapacity)
+		}
+		if host.LastHosted.IsZero() {
+			text += neverHostedText
+		} else {
+			text += fmt.Sprintf(lastHostedText, host.LastHosted.In(location).Format(playDateFormat))
+		}
+		text += "\n"
+	}
+	return text
+}
+
+func (t *telegram) rotationHosts(groupID string) ([]*rotation.Host, error) {
+	list, err := t.ls.GetLocations(groupID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := t.mf.GetMeetings(groupID)
+	if err != nil {
+		return nil, err
+	}
+	return rotation.Order(list, history), nil
+}
+
+func (t *telegram) rotationHostUsers(hosts []*rotation.Host) (map[string]*users.ExternalUser, error) {
+	userIDs := make([]string, len(hosts))
+	for i, host := range hosts {
+		userIDs[i] = host.Location.HostID
+	}
+	return t.uf.GetUsers(userIDs)
+}
+
+// joinRotation puts the sender's location in the rotation, saving it first
+// if it is new. A location needs a capacity to be in it.
+func (t *telegram) joinRotation(m *tb.Message, groupID string) (string, error) {
+	name, capacity, err := parseRotationJoin(m.Payload)
+	if err != nil {
+		return err.Error(), nil
+	}
+	userID, err := t.uf.GetOrCreateUser(&users.ExternalUser{
+		Source:      users.SourceTelegram,
+		ID:          strconv.Itoa(m.Sender.ID),
+		DisplayName: formatUserDisplayName(m.Sender),
+	})
+	if err != nil {
+		return "", err
+	}
+	list, err := t.ls.GetLocations(groupID)
+	if err != nil {
+		return "", err
+	}
+	place := locations.Find(list, name)
+	if place != nil && place.HostID != userID {
+		return onlyHostEditsLocationText, nil
+	}
+	if place == nil {
+		place = &locations.Location{GroupID: groupID, Name: name, HostID: userID}
+	}
+	if capacity > 0 {
+		place.MaxCapacity = capacity
+	}
+	if place.MaxCapacity == 0 {
+		return ErrInvalidRotation.Error(), nil
+	}
+	place.Rotation = true
+	if place.ID == "" {
+		err = t.ls.CreateLocation(place)
+	} else {
+		err = t.ls.UpdateLocation(place)
+	}
+	if err != nil {
+		if err == locations.LocationAlreadyExists || err == locations.LocationNotFound {
+			return err.Error(), nil
+		}
+		return "", err
+	}
+	return fmt.Sprintf(joinedRotationText, place.Name+fmt.Sprintf(locationCapacityText, place.MaxCapacity)), nil
+}
+
+// leaveRotation takes all the sender's locations out of the rotation
+func (t *telegram) leaveRotation(m *tb.Message, groupID string) (string, error) {
+	userID, err := t.uf.GetOrCreateUser(&users.ExternalUser{
+		Source:      users.SourceTelegram,
+		ID:          strconv.Itoa(m.Sender.ID),
+		DisplayName: formatUserDisplayName(m.Sender),
+	})
+	if err != nil {
+		return "", err
+	}
+	list, err := t.ls.GetLocations(groupID)
+	if err != nil {
+		return "", err
+	}
+	left := false
+	for _, place := range list {
+		if place.HostID != userID || !place.Rotation {
+			continue
+		}
+		place.Rotation = false
+		err = t.ls.UpdateLocation(place)
+		if err != nil {
+			return "", err
+		}
+		left = true
+	}
+	if !left {
+		return notInRotationText, nil
+	}
+	return leftRotationText, nil
+}
+
+// proposalData identifies a proposal to a host in its buttons, so answers
+// to one that was replaced are not taken for the current one.
+func proposalData(groupID string, locationID string, proposal *hostProposal) string {
+	return strings.Join([]string{groupID, locationID, strconv.FormatInt(proposal.time.Unix(), 10)}, "|")
+}
+
+// proposeNextHost asks the next host of the rotation who was not asked yet
+// to host the proposed meeting, skipping those who cannot get private
+// messages, and tells the group. Once everybody was asked the proposal is
+// dropped. It stops if the proposal was answered or replaced meanwhile, and
+// it only holds proposalsMutex to update it.
+func (t *telegram) proposeNextHost(groupID string, proposal *hostProposal) error {
+	hosts, err := t.rotationHosts(groupID)
+	if err != nil {
+		return err
+	}
+	usersMap, err := t.rotationHostUsers(hosts)
+	if err != nil {
+		return err
+	}
+	chat, err := t.groupChat(groupID)
+	if err != nil {
+		return err
+	}
+	date := proposal.time.In(t.groupLocation(groupID)).Format(meetingCreatedDateFormat)
+	for _, host := range hosts {
+		t.proposalsMutex.Lock()
+		if t.hostProposals[groupID] != proposal {
+			t.proposalsMutex.Unlock()
+			return nil
+		}
+		asked := proposal.asked[host.Location.ID]
+		proposal.asked[host.Location.ID] = true
+		// a host that could not be messaged cannot answer either
+		proposal.locationID = ""
+		t.proposalsMutex.Unlock()
+		if asked {
+			continue
+		}
+		user, found := usersMap[host.Location.HostID]
+		if !found || user.Source != users.SourceTelegram {
+			continue
+		}
+		userID, err := strconv.Atoi(user.ID)
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+		// the host can answer as soon as the message arrives
+		t.proposalsMutex.Lock()
+		if t.hostProposals[groupID] != proposal {
+			t.proposalsMutex.Unlock()
+			return nil
+		}
+		proposal.locationID = host.Location.ID
+		t.proposalsMutex.Unlock()
+		data := proposalData(groupID, host.Location.ID, proposal)
+		markup := &tb.ReplyMarkup{InlineKeyboard: [][]tb.InlineButton{[]tb.InlineButton{
+			tb.InlineButton{Unique: acceptHostIdentifier, Text: acceptHostLabel, Data: data},
+			tb.InlineButton{Unique: declineHostIdentifier, Text: declineHostLabel, Data: data},
+		}}}
+		// users that never talked to the bot cannot get private messages
+		_, err = t.b.Send(&tb.User{ID: userID}, fmt.Sprintf(hostProposalText, host.Location.Name, date), markup)
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+		if chat != nil {
+			_, err = t.b.Send(chat, fmt.Sprintf(hostAskedText, user.DisplayName, date))
+		}
+		return err
+	}
+	t.proposalsMutex.Lock()
+	current := t.hostProposals[groupID] == proposal
+	if current {
+		delete(t.hostProposals, groupID)
+	}
+	t.proposalsMutex.Unlock()
+	if current && chat != nil {
+		_, err = t.b.Send(chat, fmt.Sprintf(noHostText, date))
+	}
+	return err
+}
+
+// proposeHost starts looking for a host for a meeting at the date in the
+// message, replacing the search the group had going, and returns the answer
+// to send back if there is one.
+func (t *telegram) proposeHost(m *tb.Message, groupID string) (string, error) {
+	if strings.TrimSpace(m.Payload) == "" {
+		return proposeHostUsageText, nil
+	}
+	date, err := dates.Parse(m.Payload, t.timeFactory.Now(), t.groupLocation(groupID))
+	if err != nil {
+		if err == dates.ErrNonexistentTime {
+			return ErrNonexistentDate.Error(), nil
+		}
+		return proposeHostUsageText, nil
+	}
+	if date.Before(t.timeFactory.Now()) {
+		return meetings.MeetingIsInThePast.Error(), nil
+	}
+	_, err = t.mf.GetMeeting(groupID)
+	if err == nil {
+		return meetings.MeetingAlreadyActive.Error(), nil
+	}
+	if err != meetings.NoActiveMeeting {
+		return "", err
+	}
+	hosts, err := t.rotationHosts(groupID)
+	if err != nil {
+		return "", err
+	}
+	if len(hosts) == 0 {
+		return emptyRotationText, nil
+	}
+	proposal := &hostProposal{time: date, asked: map[string]bool{}}
+	t.proposalsMutex.Lock()
+	replaced, found := t.hostProposals[groupID]
+	t.hostProposals[groupID] = proposal
+	t.proposalsMutex.Unlock()
+	if found {
+		_, err = t.b.Send(m.Chat, fmt.Sprintf(proposalReplacedText, replaced.time.In(t.groupLocation(groupID)).Format(meetingCreatedDateFormat)))
+		if err != nil {
+			log.Print(err)
+		}
+	}
+	return "", t.proposeNextHost(groupID, proposal)
+}
+
+// answerProposal creates the proposed meeting when its host accepts, or
+// asks the next one when they decline, and returns the answer to them.
+func (t *telegram) answerProposal(c *tb.Callback, accept bool) string {
+	data := strings.Split(c.Data, "|")
+	if len(data) != 3 {
+		return proposalClosedResponse
+	}
+	groupID, locationID := data[0], data[1]
+	place, err := t.ls.GetLocation(locationID)
+	if err != nil {
+		if err == locations.LocationNotFound {
+			return proposalClosedResponse
+		}
+		log.Print(err)
+		return ""
+	}
+	userID, err := t.uf.GetOrCreateUser(&users.ExternalUser{
+		Source:      users.SourceTelegram,
+		ID:          strconv.Itoa(c.Sender.ID),
+		DisplayName: formatUserDisplayName(c.Sender),
+	})
+	if err != nil {
+		return ""
+	}
+	if userID != place.HostID {
+		return onlyProposedHostResponse
+	}
+	t.proposalsMutex.Lock()
+	proposal, found := t.hostProposals[groupID]
+	if !found || proposal.locationID != locationID || proposalData(groupID, locationID, proposal) != c.Data {
+		t.proposalsMutex.Unlock()
+		return proposalClosedResponse
+	}
+	proposal.locationID = ""
+	if accept {
+		delete(t.hostProposals, groupID)
+	}
+	t.proposalsMutex.Unlock()
+	if !accept {
+		if _, err := t.b.Edit(c.Message, hostDeclinedText); err != nil {
+			log.Print(err)
+		}
+		if err := t.proposeNextHost(groupID, proposal); err != nil {
+			log.Print(err)
+		}
+		return hostDeclinedResponse
+	}
+	meeting := &meetings.Meeting{
+		Time:       proposal.time,
+		Location:   place.Name,
+		LocationID: place.ID,
+		Capacity:   place.MaxCapacity,
+		HostID:     place.HostID,
+	}
+	err = t.mf.CreateMeeting(groupID, meeting)
+	if err != nil {
+		if err == meetings.MeetingAlreadyActive || err == meetings.MeetingIsInThePast || err == meetings.MeetingsOverlap {
+			return err.Error()
+		}
+		log.Print(err)
+		return ""
+	}
+	date := meeting.Time.In(t.groupLocation(groupID)).Format(meetingCreatedDateFormat)
+	if _, err := t.b.Edit(c.Message, fmt.Sprintf(hostAcceptedText, place.Name, date)); err != nil {
+		log.Print(err)
+	}
+	if err := t.postMeetingMessage(groupID); err != nil {
+		log.Print(err)
+	}
+	return hostAcceptedResponse
+}
+
+func (t *telegram) registerRotationHandlers() {
+	b := t.b
+
+	b.Handle("/joinrotation", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.ls == nil {
+			b.Send(m.Chat, locationsDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		text, err := t.joinRotation(m, groupID)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, text)
+	})
+
+	b.Handle("/leaverotation", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.ls == nil {
+			b.Send(m.Chat, locationsDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		text, err := t.leaveRotation(m, groupID)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, text)
+	})
+
+	b.Handle("/rotation", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.ls == nil {
+			b.Send(m.Chat, locationsDisabledText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		hosts, err := t.rotationHosts(groupID)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		if len(hosts) == 0 {
+			b.Send(m.Chat, emptyRotationText)
+			return
+		}
+		usersMap, err := t.rotationHostUsers(hosts)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		b.Send(m.Chat, fmt.Sprintf(rotationText, rotationListText(hosts, usersMap, t.groupLocation(groupID))))
+	})
+
+	b.Handle("/proposehost", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		if t.ls == nil {
+			b.Send(m.Chat, locationsDisabledText)
+			return
+		}
+		if !t.isAdmin(m.Chat, m.Sender) {
+			b.Send(m.Chat, onlyAdminsText)
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		text, err := t.proposeHost(m, groupID)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		if text != "" {
+			b.Send(m.Chat, text)
+		}
+	})
+
+	b.Handle(&tb.InlineButton{Unique: acceptHostIdentifier}, func(c *tb.Callback) {
+		err := b.Respond(c, &tb.CallbackResponse{Text: t.answerProposal(c, true)})
+		if err != nil {
+			log.Print(err)
+		}
+	})
+
+	b.Handle(&tb.InlineButton{Unique: declineHostIdentifier}, func(c *tb.Callback) {
+		err := b.Respond(c, &tb.CallbackResponse{Text: t.answerProposal(c, false)})
+		if err != nil {
+			log.Print(err)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/locations"
+	"github.com/seppo0010/boardgamesorganizer/rotation"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseRotationJoin(t *testing.T) {
+	assert := assert.New(t)
+	name, capacity, err := parseRotationJoin(" Home ; 8 ")
+	assert.NoError(err)
+	assert.Equal(name, "Home")
+	assert.Equal(capacity, 8)
+
+	name, capacity, err = parseRotationJoin("Home")
+	assert.NoError(err)
+	assert.Equal(name, "Home")
+	assert.Equal(capacity, 0)
+
+	for _, input := range []string{"", "; 8", "Home; many", "Home; 0", "Home; 8; 9"} {
+		_, _, err = parseRotationJoin(input)
+		assert.Equal(err, ErrInvalidRotation)
+	}
+}
+
+func TestRotationListText(t *testing.T) {
+	assert := assert.New(t)
+	hosts := []*rotation.Host{
+		&rotation.Host{Location: &locations.Location{Name: "Bob's", HostID: "2"}},
+		&rotation.Host{Location: &locations.Location{Name: "Home", HostID: "1", MaxCapacity: 8}, LastHosted: time.Date(2019, 3, 1, 23, 0, 0, 0, time.UTC)},
+	}
+	usersMap := map[string]*users.ExternalUser{"1": &users.ExternalUser{DisplayName: "alice"}}
+	newYork := loadLocation(t, "America/New_York")
+	assert.Equal(rotationListText(hosts, usersMap, newYork), "1. (unknown user) at Bob's, never hosted\n2. alice at Home (up to 8 people), last hosted 01 Mar 2019\n")
+}
+
+func TestProposalData(t *testing.T) {
+	assert := assert.New(t)
+	proposal := &hostProposal{time: time.Date(2019, 3, 1, 23, 0, 0, 0, time.UTC)}
+	assert.Equal(proposalData("1", "3", proposal), "1|3|1551481200")
+	// an answer to an earlier proposal to the same host is not taken for a
+	// later one
+	later := &hostProposal{time: time.Date(2019, 3, 8, 23, 0, 0, 0, time.UTC)}
+	assert.NotEqual(proposalData("1", "3", later), proposalData("1", "3", proposal))
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/seppo0010/boardgamesorganizer/stats"
+	tb "gopkg.in/tucnak/telebot.v2"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var ErrInvalidStats = errors.New("Usage: /stats [month] [csv]. For example '/stats', '/stats 2019-06' or '/stats 2019-06 csv'")
+
+const statsMonthFormat = "2006-01"
+const statsTitleText = "Stats:\n"
+const statsMonthTitleText = "Stats for %s:\n"
+const statsMonthTitleFormat = "January 2006"
+const noStatsText = "No meetings were held yet"
+const statsMeetingsText = "Meetings: %d"
+const statsCancelledText = " (%d cancelled)"
+const statsSizeText = "\nAverage attendance: %.1f people"
+const statsCapacityText = ", %.0f%% of the capacity"
+const statsFilledText = "\nFilled up: %d meeting(s)"
+const statsFillTimeText = ", in %s on average"
+const statsLocationsText = "\nLocations: %s"
+const statsWeekdaysText = "\nBusiest days: %s"
+const statsMembersText = "\nAttendance:\n"
+const statsMemberText = "* %s: %d meeting(s)"
+const statsNoShowsText = ", %d no-show(s)"
+const statsCountText = "%s (%d)"
+const statsFileName = "stats%s.csv"
+
+// parseStatsQuery reads "[month] [csv]", where month is like 2019-06. The
+// month is zero when it is not given.
+func parseStatsQuery(input string) (time.Time, bool, error) {
+	month := time.Time{}
+	csv := false
+	for _, field := range strings.Fields(input) {
+		if strings.EqualFold(field, "csv") && !csv {
+			csv = true
+			continue
+		}
+		parsed, err := time.Parse(statsMonthFormat, field)
+		if err != nil || !month.IsZero() {
+			return time.Time{}, false, ErrInvalidStats
+		}
+		month = parsed
+	}
+	return month, csv, nil
+}
+
+func countsText(counts []*stats.Count) string {
+	texts := make([]string, len(counts))
+	for i, count := range counts {
+		texts[i] = fmt.Sprintf(statsCountText, count.Name, count.Meetings)
+	}
+	return strings.Join(texts, ", ")
+}
+
+func statsText(report *stats.Report, names map[string]string) string {
+	text := fmt.Sprintf(statsMeetingsText, report.Meetings)
+	if report.Cancelled > 0 {
+		text += fmt.Sprintf(statsCancelledText, report.Cancelled)
+	}
+	if report.Meetings == 0 {
+		return text
+	}
+	text += fmt.Sprintf(statsSizeText, report.AverageSize)
+	if report.CapacityUse > 0 {
+		text += fmt.Sprintf(statsCapacityText, report.CapacityUse*100)
+	}
+	if report.Filled > 0 {
+		text += fmt.Sprintf(statsFilledText, report.Filled)
+		if report.AverageFillTime > 0 {
+			text += fmt.Sprintf(statsFillTimeText, report.AverageFillTime.Round(time.Minute))
+		}
+	}
+	text += fmt.Sprintf(statsLocationsText, countsText(report.Locations))
+	text += fmt.Sprintf(statsWeekdaysText, countsText(report.Weekdays))
+	text += statsMembersText
+	for _, member := range report.Members {
+		name, found := names[member.UserID]
+		if !found {
+			name = "(unknown user)"
+		}
+		text += fmt.Sprintf(statsMemberText, name, member.RSVPs)
+		if member.NoShows > 0 {
+			text += fmt.Sprintf(statsNoShowsText, member.NoShows)
+		}
+		text += "\n"
+	}
+	return text
+}
+
+// statsMeetings returns the group's meetings that took place in the month,
+// or all of them if it is zero, with who went to them as the host checked
+// them in. Meetings nobody was checked in have no one known present.
+func (t *telegram) statsMeetings(groupID string, month time.Time, location *time.Location) ([]*stats.Meeting, error) {
+	list, err := t.mf.GetMeetings(groupID)
+	if err != nil {
+		return nil, err
+	}
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, location)
+	to := from.AddDate(0, 1, 0)
+	meetings := []*stats.Meeting{}
+	for _, meeting := range list {
+		if !month.IsZero() && (meeting.Time.Before(from) || !meeting.Time.Before(to)) {
+			continue
+		}
+		attendees, err := t.mf.GetMeetingAttendeesByID(meeting.ID)
+		if err != nil {
+			return nil, err
+		}
+		var present map[string]bool
+		for _, attendee := range attendees {
+			if attendee.CheckedIn {
+				if present == nil {
+					present = map[string]bool{}
+				}
+				present[attendee.UserID] = true
+			}
+		}
+		meetings = append(meetings, &stats.Meeting{Meeting: meeting, Attendees: attendees, Present: present})
+	}
+	return meetings, nil
+}
+
+func (t *telegram) memberNames(report *stats.Report) (map[string]string, error) {
+	userIDs := make([]string, len(report.Members))
+	for i, member := range report.Members {
+		userIDs[i] = member.UserID
+	}
+	usersMap, err := t.uf.GetUsers(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]string{}
+	for userID, user := range usersMap {
+		names[userID] = user.DisplayName
+	}
+	return names, nil
+}
+
+// sendStatsCSV sends the report as a file, which needs to be on disk for
+// Telegram to get its name.
+func (t *telegram) sendStatsCSV(chat *tb.Chat, report *stats.Report, names map[string]string, month time.Time) error {
+	dir, err := ioutil.TempDir("", "stats")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	suffix := ""
+	if !month.IsZero() {
+		suffix = "-" + month.Format(statsMonthFormat)
+	}
+	path := filepath.Join(dir, fmt.Sprintf(statsFileName, suffix))
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = report.WriteCSV(file, names)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	_, err = t.b.Send(chat, &tb.Document{File: tb.FromDisk(path)})
+	return err
+}
+
+func (t *telegram) registerStatsHandlers() {
+	b := t.b
+
+	b.Handle("/stats", func(m *tb.Message) {
+		if !m.FromGroup() {
+			return
+		}
+		month, csv, err := parseStatsQuery(m.Payload)
+		if err != nil {
+			b.Send(m.Chat, err.Error())
+			return
+		}
+		groupID, err := t.chatGroupID(m.Chat)
+		if err != nil {
+			return
+		}
+		location := t.groupLocation(groupID)
+		meetings, err := t.statsMeetings(groupID, month, location)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		report := stats.Compute(meetings, location)
+		if report.Meetings+report.Cancelled == 0 {
+			b.Send(m.Chat, noStatsText)
+			return
+		}
+		names, err := t.memberNames(report)
+		if err != nil {
+			log.Print(err)
+			return
+		}
+		if csv {
+			err = t.sendStatsCSV(m.Chat, report, names, month)
+			if err != nil {
+				log.Print(err)
+			}
+			return
+		}
+		text := statsTitleText
+		if !month.IsZero() {
+			text = fmt.Sprintf(statsMonthTitleText, month.Format(statsMonthTitleFormat))
+		}
+		b.Send(m.Chat, text+statsText(report, names))
+	})
+}
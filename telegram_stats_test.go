@@ -0,0 +1,53 @@
+package main
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/stats"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseStatsQuery(t *testing.T) {
+	assert := assert.New(t)
+	month, csv, err := parseStatsQuery("")
+	assert.NoError(err)
+	assert.True(month.IsZero())
+	assert.False(csv)
+	month, csv, err = parseStatsQuery(" 2019-06 CSV")
+	assert.NoError(err)
+	assert.Equal(month, time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(csv)
+	month, csv, err = parseStatsQuery("csv")
+	assert.NoError(err)
+	assert.True(month.IsZero())
+	assert.True(csv)
+	for _, input := range []string{"june", "2019-06 2019-07", "csv csv", "2019-13"} {
+		_, _, err = parseStatsQuery(input)
+		assert.Equal(err, ErrInvalidStats, input)
+	}
+}
+
+func TestStatsText(t *testing.T) {
+	assert := assert.New(t)
+	report := &stats.Report{
+		Meetings:        3,
+		Cancelled:       1,
+		Members:         []*stats.Member{&stats.Member{UserID: "1", RSVPs: 3, Attended: 1}, &stats.Member{UserID: "2", RSVPs: 2, NoShows: 1}},
+		AverageSize:     8.0 / 3,
+		CapacityUse:     0.625,
+		Locations:       []*stats.Count{&stats.Count{Name: "Home", Meetings: 2}, &stats.Count{Name: "Bar", Meetings: 1}},
+		Weekdays:        []*stats.Count{&stats.Count{Name: "Thursday", Meetings: 3}},
+		Filled:          1,
+		AverageFillTime: 5*time.Hour + 20*time.Second,
+	}
+	assert.Equal(statsText(report, map[string]string{"1": "alice"}), `Meetings: 3 (1 cancelled)
+Average attendance: 2.7 people, 62% of the capacity
+Filled up: 1 meeting(s), in 5h0m0s on average
+Locations: Home (2), Bar (1)
+Busiest days: Thursday (3)
+Attendance:
+* alice: 3 meeting(s)
+* (unknown user): 2 meeting(s), 1 no-show(s)
+`)
+	assert.Equal(statsText(&stats.Report{}, map[string]string{}), "Meetings: 0")
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/locations"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"github.com/seppo0010/boardgamesorganizer/recurrence"
+	"github.com/seppo0010/boardgamesorganizer/users"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("cannot load location %s: %#v", name, err)
+	}
+	return location
+}
+
+var now = time.Date(2019, 3, 1, 17, 3, 7, 0, time.UTC)
+
+func TestParseQueryTimezone(t *testing.T) {
+	assert := assert.New(t)
+	madrid := loadLocation(t, "Europe/Madrid")
+
+	m, err := parseQuery("Home;2019-03-05 20:00:00;8", now, madrid, nil)
+	assert.NoError(err)
+	assert.True(m.Time.Equal(time.Date(2019, 3, 5, 19, 0, 0, 0, time.UTC)))
+	assert.Equal(m.Location, "Home")
+	assert.Equal(m.Capacity, 8)
+
+	m, err = parseQuery("Home;2019-03-05 20:00:00;8", now, defaultLocation, nil)
+	assert.NoError(err)
+	assert.True(m.Time.Equal(time.Date(2019, 3, 5, 23, 0, 0, 0, time.UTC)))
+}
+
+func TestParseQueryNaturalLanguage(t *testing.T) {
+	assert := assert.New(t)
+	madrid := loadLocation(t, "Europe/Madrid")
+
+	m, err := parseQuery("Home; next sat 15hs; 6", now, madrid, nil)
+	assert.NoError(err)
+	assert.True(m.Time.Equal(time.Date(2019, 3, 2, 14, 0, 0, 0, time.UTC)))
+	assert.Equal(m.Capacity, 6)
+
+	_, err = parseQuery("Home;someday;6", now, madrid, nil)
+	assert.Equal(err, ErrInvalidDate)
+	_, err = parseQuery("Home;friday 20:00", now, madrid, nil)
+	assert.Equal(err, ErrNeedsSegments)
+}
+
+func TestParseQueryDST(t *testing.T) {
+	assert := assert.New(t)
+	madrid := loadLocation(t, "Europe/Madrid")
+
+	// clocks go forward on 2019-03-31 at 02:00 CET
+	before, err := parseQuery("Home;2019-03-30 20:00:00;0", now, madrid, nil)
+	assert.NoError(err)
+	after, err := parseQuery("Home;2019-03-31 20:00:00;0", now, madrid, nil)
+	assert.NoError(err)
+	assert.True(before.Time.Equal(time.Date(2019, 3, 30, 19, 0, 0, 0, time.UTC)))
+	assert.True(after.Time.Equal(time.Date(2019, 3, 31, 18, 0, 0, 0, time.UTC)))
+	assert.Equal(after.Time.Sub(before.Time), 23*time.Hour)
+
+	_, err = parseQuery("Home;2019-03-31 02:30:00;0", now, madrid, nil)
+	assert.Equal(err, ErrNonexistentDate)
+
+	// clocks go back on 2019-10-27 at 03:00 CEST
+	before, err = parseQuery("Home;2019-10-26 20:00:00;0", now, madrid, nil)
+	assert.NoError(err)
+	after, err = parseQuery("Home;2019-10-27 20:00:00;0", now, madrid, nil)
+	assert.NoError(err)
+	assert.Equal(after.Time.Sub(before.Time), 25*time.Hour)
+
+	ambiguous, err := parseQuery("Home;2019-10-27 02:30:00;0", now, madrid, nil)
+	assert.NoError(err)
+	assert.Equal(ambiguous.Time.In(madrid).Format(queryDateFormat), "2019-10-27 02:30:00")
+}
+
+func TestMeetingTextTimezone(t *testing.T) {
+	assert := assert.New(t)
+	madrid := loadLocation(t, "Europe/Madrid")
+	newYork := loadLocation(t, "America/New_York")
+
+	summer := &meetings.Meeting{Time: time.Date(2019, 3, 31, 18, 0, 0, 0, time.UTC), Location: "Home"}
+	winter := &meetings.Meeting{Time: time.Date(2019, 3, 30, 19, 0, 0, 0, time.UTC), Location: "Home"}
+	assert.Equal(meetingText(summer, nil, nil, madrid), "Meeting created for Sunday 31 Mar 2019 20:00 at Home!")
+	assert.Equal(meetingText(winter, nil, nil, madrid), "Meeting created for Saturday 30 Mar 2019 20:00 at Home!")
+	// New York already switched to daylight saving time on March 10th
+	assert.Equal(meetingText(winter, nil, nil, newYork), "Meeting created for Saturday 30 Mar 2019 15:00 at Home!")
+}
+
+func TestParseQueryRecurrence(t *testing.T) {
+	assert := assert.New(t)
+
+	m, err := parseQuery("Home;friday 20:00;8;biweekly", now, time.UTC, nil)
+	assert.NoError(err)
+	assert.Equal(m.Recurrence, "FREQ=WEEKLY;INTERVAL=2")
+	assert.Equal(m.Capacity, 8)
+
+	m, err = parseQuery("Home;friday 20:00;8;FREQ=MONTHLY;BYDAY=-1FR", now, time.UTC, nil)
+	assert.NoError(err)
+	assert.Equal(m.Recurrence, "FREQ=MONTHLY;BYDAY=-1FR")
+
+	m, err = parseQuery("Home;friday 20:00;8;", now, time.UTC, nil)
+	assert.NoError(err)
+	assert.Equal(m.Recurrence, "")
+
+	_, err = parseQuery("Home;friday 20:00;8;daily", now, time.UTC, nil)
+	assert.Equal(err, recurrence.ErrInvalidRule)
+}
+
+func TestMeetingTextRecurrence(t *testing.T) {
+	assert := assert.New(t)
+	meeting := &meetings.Meeting{Time: time.Date(2019, 3, 8, 20, 0, 0, 0, time.UTC), Location: "Home", Recurrence: "FREQ=MONTHLY;BYDAY=2FR"}
+	assert.Equal(meetingText(meeting, nil, nil, time.UTC), "Meeting created for Friday 08 Mar 2019 20:00 at Home!\nRepeats on the 2nd Friday of every month")
+}
+
+func TestParseQueryDuration(t *testing.T) {
+	assert := assert.New(t)
+
+	m, err := parseQuery("Home;friday 20:00;8;3h", now, time.UTC, nil)
+	assert.NoError(err)
+	assert.Equal(m.Duration, 3*time.Hour)
+	assert.Equal(m.Recurrence, "")
+
+	m, err = parseQuery("Home;friday 20:00;8;2h30m;weekly", now, time.UTC, nil)
+	assert.NoError(err)
+	assert.Equal(m.Duration, 150*time.Minute)
+	assert.Equal(m.Recurrence, "FREQ=WEEKLY")
+
+	m, err = parseQuery("Home;friday 20:00;8;4H;FREQ=WEEKLY;INTERVAL=2", now, time.UTC, nil)
+	assert.NoError(err)
+	assert.Equal(m.Duration, 4*time.Hour)
+	assert.Equal(m.Recurrence, "FREQ=WEEKLY;INTERVAL=2")
+
+	_, err = parseQuery("Home;friday 20:00;8;0h", now, time.UTC, nil)
+	assert.Equal(err, ErrInvalidDuration)
+
+	m, err = parseQuery(queryText(m), now, time.UTC, nil)
+	assert.NoError(err)
+	assert.Equal(m.Duration, 4*time.Hour)
+	assert.Equal(m.Recurrence, "FREQ=WEEKLY;INTERVAL=2")
+}
+
+func TestMeetingTextDuration(t *testing.T) {
+	assert := assert.New(t)
+	meeting := &meetings.Meeting{Time: time.Date(2019, 3, 8, 20, 0, 0, 0, time.UTC), Location: "Home", Duration: 3 * time.Hour}
+	assert.Equal(meetingText(meeting, nil, nil, time.UTC), "Meeting created for Friday 08 Mar 2019 20:00 at Home!\nUntil 23:00")
+	meeting.Duration = 5 * time.Hour
+	assert.Equal(meetingText(meeting, nil, nil, time.UTC), "Meeting created for Friday 08 Mar 2019 20:00 at Home!\nUntil Saturday 09 Mar 2019 01:00")
+}
+
+func TestMeetingTextRSVPDeadline(t *testing.T) {
+	assert := assert.New(t)
+	meeting := &meetings.Meeting{Time: time.Date(2019, 3, 8, 20, 0, 0, 0, time.UTC), Location: "Home", RSVPCutoff: 6 * time.Hour}
+	assert.Equal(meetingText(meeting, nil, nil, time.UTC), "Meeting created for Friday 08 Mar 2019 20:00 at Home!\nRSVP until Friday 08 Mar 2019 14:00")
+}
+
+func TestParseGuestLimit(t *testing.T) {
+	assert := assert.New(t)
+	for input, expected := range map[string]int{"2": 2, "0": meetings.NoGuests, "unlimited": 0, "any": 0} {
+		maxGuests, err := parseGuestLimit(input)
+		assert.NoError(err)
+		assert.Equal(maxGuests, expected, input)
+	}
+	for _, input := range []string{"-1", "two", ""} {
+		_, err := parseGuestLimit(input)
+		assert.Equal(err, ErrInvalidGuestLimit, input)
+	}
+}
+
+func TestMeetingTextMaxGuests(t *testing.T) {
+	assert := assert.New(t)
+	meeting := &meetings.Meeting{Time: time.Date(2019, 3, 8, 20, 0, 0, 0, time.UTC), Location: "Home", MaxGuests: 2}
+	assert.Equal(meetingText(meeting, nil, nil, time.UTC), "Meeting created for Friday 08 Mar 2019 20:00 at Home!\nUp to 2 guest(s) per attendee")
+	meeting.MaxGuests = meetings.NoGuests
+	assert.Equal(meetingText(meeting, nil, nil, time.UTC), "Meeting created for Friday 08 Mar 2019 20:00 at Home!\nNo guests")
+}
+
+func TestParseGuests(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(parseGuests("Bob, Carol Smith @carol\n@dave\n\n"), []*meetings.Guest{
+		&meetings.Guest{Name: "Bob"},
+		&meetings.Guest{Name: "Carol Smith", Handle: "carol"},
+		&meetings.Guest{Name: "@dave", Handle: "dave"},
+	})
+	assert.Equal(parseGuests(" , "), []*meetings.Guest{})
+}
+
+func TestMeetingTextGuestNames(t *testing.T) {
+	assert := assert.New(t)
+	meeting := &meetings.Meeting{Time: time.Date(2019, 3, 8, 20, 0, 0, 0, time.UTC), Location: "Home"}
+	attendees := []*attendeeUser{&attendeeUser{
+		user:   &users.ExternalUser{DisplayName: "alice"},
+		amount: 3,
+		guests: []*meetings.Guest{&meetings.Guest{Name: "Bob"}, &meetings.Guest{Name: "Carol", Handle: "carol"}, &meetings.Guest{Name: "Dave"}},
+	}}
+	assert.Contains(meetingText(meeting, nil, attendees, time.UTC), "* alice (+2: Bob, Carol (@carol))\n")
+}
+
+func TestMeetingTextMaybe(t *testing.T) {
+	assert := assert.New(t)
+	meeting := &meetings.Meeting{Time: time.Date(2019, 3, 8, 20, 0, 0, 0, time.UTC), Location: "Home"}
+	attendees := []*attendeeUser{
+		&attendeeUser{user: &users.ExternalUser{DisplayName: "alice"}, amount: 1},
+		&attendeeUser{user: &users.ExternalUser{DisplayName: "bob"}, amount: 2, maybe: true},
+	}
+	assert.Equal(meetingText(meeting, nil, attendees, time.UTC), "Meeting created for Friday 08 Mar 2019 20:00 at Home!\nAttendees:\n* alice\n\nMaybe:\n* bob (+1)\n")
+}
+
+func TestRSVPChange(t *testing.T) {
+	assert := assert.New(t)
+	change := func(data string, attendee *meetings.Attendee) []interface{} {
+		amount, maybe, refused := rsvpChange(data, attendee)
+		return []interface{}{amount, maybe, refused}
+	}
+	none := &meetings.Attendee{UserID: "1"}
+	maybeWithGuests := &meetings.Attendee{UserID: "1", Amount: 3, Maybe: true}
+	goingWithGuests := &meetings.Attendee{UserID: "1", Amount: 3}
+
+	assert.Equal(change(goingCallbackData, none), []interface{}{1, false, ""})
+	assert.Equal(change(goingCallbackData, maybeWithGuests), []interface{}{3, false, ""})
+	assert.Equal(change(goingCallbackData, goingWithGuests), []interface{}{1, false, ""})
+	assert.Equal(change(maybeCallbackData, none), []interface{}{1, true, ""})
+	assert.Equal(change(maybeCallbackData, goingWithGuests), []interface{}{3, true, ""})
+	assert.Equal(change(addGuestCallbackData, none), []interface{}{2, false, ""})
+	assert.Equal(change(addGuestCallbackData, maybeWithGuests), []interface{}{4, true, ""})
+	assert.Equal(change(removeGuestCallbackData, goingWithGuests), []interface{}{2, false, ""})
+	assert.Equal(change(removeGuestCallbackData, none), []interface{}{0, false, noGuestsResponse})
+	assert.Equal(change(notGoingCallbackData, maybeWithGuests), []interface{}{0, false, ""})
+}
+
+func TestParseQuerySavedLocation(t *testing.T) {
+	assert := assert.New(t)
+	saved := []*locations.Location{&locations.Location{ID: "3", Name: "Home", MaxCapacity: 8}, &locations.Location{ID: "4", Name: "Cafe"}}
+	m, err := parseQuery("home;friday 20:00;", now, time.UTC, saved)
+	assert.NoError(err)
+	assert.Equal(m.Location, "Home")
+	assert.Equal(m.LocationID, "3")
+	assert.Equal(m.Capacity, 8)
+
+	m, err = parseQuery("Home;friday 20:00;4", now, time.UTC, saved)
+	assert.NoError(err)
+	assert.Equal(m.Capacity, 4)
+
+	_, err = parseQuery("Cafe;friday 20:00; ", now, time.UTC, saved)
+	assert.Equal(err, ErrUnknownCapacity)
+	_, err = parseQuery("Park;friday 20:00;", now, time.UTC, saved)
+	assert.Equal(err, ErrInvalidCapacity)
+	_, err = parseQuery("Home;friday 20:00;", now, time.UTC, nil)
+	assert.Equal(err, ErrInvalidCapacity)
+	m, err = parseQuery("Park;friday 20:00;6", now, time.UTC, saved)
+	assert.NoError(err)
+	assert.Equal(m.LocationID, "")
+}
+
+func TestMeetingTextPlace(t *testing.T) {
+	assert := assert.New(t)
+	meeting := &meetings.Meeting{Time: time.Date(2019, 3, 8, 20, 0, 0, 0, time.UTC), Location: "Home", LocationID: "3"}
+	place := &locations.Location{ID: "3", Name: "Home", Address: "Av. Corrientes 1234", AccessNotes: "Ring 3B"}
+	assert.Equal(meetingText(meeting, place, nil, time.UTC), "Meeting created for Friday 08 Mar 2019 20:00 at Home!\nAddress: Av. Corrientes 1234\nMap: https://www.google.com/maps/search/?api=1&query=Av.+Corrientes+1234\nAccess: Ring 3B")
+}
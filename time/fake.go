@@ -1,15 +1,173 @@
 package time
 
-import "time"
+import (
+	"sort"
+	"sync"
+	"time"
+)
 
+// Fake is a clock that only moves when told to. Timers and tickers fire
+// from AdvaceTime in deadline order, each sending its deadline on its
+// channel. AdvaceTime holds the clock until every due waiter fired, so
+// receivers see Now at the target rather than at their deadline. Setting
+// CurrentNow directly moves the clock without firing anything.
 type Fake struct {
 	CurrentNow time.Time
+	mutex      sync.Mutex
+	cond       *sync.Cond
+	lastID     int
+	waiters    []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	fake     *Fake
+	id       int
+	deadline time.Time
+	period   time.Duration
+	c        chan time.Time
+	active   bool
+}
+
+type fakeTicker struct {
+	waiter *fakeWaiter
 }
 
 func (f *Fake) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
 	return f.CurrentNow
 }
 
 func (f *Fake) AdvaceTime(duration time.Duration) {
-	f.CurrentNow = f.CurrentNow.Add(duration)
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	target := f.CurrentNow.Add(duration)
+	for f.fireNext(target) != nil {
+	}
+	f.CurrentNow = target
+}
+
+func (f *Fake) fireNext(target time.Time) *fakeWaiter {
+	next := f.nextWaiter(target)
+	if next == nil {
+		return nil
+	}
+	f.CurrentNow = next.deadline
+	select {
+	case next.c <- next.deadline:
+	default:
+	}
+	if next.period > 0 {
+		next.deadline = next.deadline.Add(next.period)
+	} else {
+		f.remove(next)
+	}
+	return next
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	return f.add(d, 0)
+}
+
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	return &fakeTicker{waiter: f.add(d, d)}
+}
+
+// BlockUntil waits until there are at least n active timers and tickers, so
+// tests can advance the clock once another goroutine started waiting.
+func (f *Fake) BlockUntil(n int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for len(f.waiters) < n {
+		f.getCond().Wait()
+	}
+}
+
+func (f *Fake) getCond() *sync.Cond {
+	if f.cond == nil {
+		f.cond = sync.NewCond(&f.mutex)
+	}
+	return f.cond
+}
+
+func (f *Fake) add(d time.Duration, period time.Duration) *fakeWaiter {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.lastID++
+	w := &fakeWaiter{
+		fake:     f,
+		id:       f.lastID,
+		deadline: f.CurrentNow.Add(d),
+		period:   period,
+		c:        make(chan time.Time, 1),
+	}
+	f.insert(w)
+	return w
+}
+
+func (f *Fake) insert(w *fakeWaiter) {
+	w.active = true
+	f.waiters = append(f.waiters, w)
+	f.getCond().Broadcast()
+}
+
+func (f *Fake) remove(w *fakeWaiter) bool {
+	if !w.active {
+		return false
+	}
+	w.active = false
+	for i, waiter := range f.waiters {
+		if waiter == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (f *Fake) nextWaiter(target time.Time) *fakeWaiter {
+	sort.SliceStable(f.waiters, func(i, j int) bool {
+		if f.waiters[i].deadline.Equal(f.waiters[j].deadline) {
+			return f.waiters[i].id < f.waiters[j].id
+		}
+		return f.waiters[i].deadline.Before(f.waiters[j].deadline)
+	})
+	if len(f.waiters) == 0 || f.waiters[0].deadline.After(target) {
+		return nil
+	}
+	return f.waiters[0]
+}
+
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.c
+}
+
+func (w *fakeWaiter) Stop() bool {
+	w.fake.mutex.Lock()
+	defer w.fake.mutex.Unlock()
+	return w.fake.remove(w)
+}
+
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	w.fake.mutex.Lock()
+	defer w.fake.mutex.Unlock()
+	active := w.fake.remove(w)
+	w.deadline = w.fake.CurrentNow.Add(d)
+	w.fake.insert(w)
+	return active
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.waiter.C()
+}
+
+func (t *fakeTicker) Stop() {
+	t.waiter.Stop()
 }
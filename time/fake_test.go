@@ -0,0 +1,134 @@
+package time
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC)
+
+func fired(c <-chan time.Time) *time.Time {
+	select {
+	case t := <-c:
+		return &t
+	default:
+		return nil
+	}
+}
+
+func TestFakeTimersFireAtDeadline(t *testing.T) {
+	assert := assert.New(t)
+	f := &Fake{CurrentNow: start}
+	late := f.NewTimer(3 * time.Hour)
+	early := f.NewTimer(time.Hour)
+	tie := f.After(time.Hour)
+
+	f.AdvaceTime(59 * time.Minute)
+	assert.Nil(fired(late.C()))
+	assert.Nil(fired(early.C()))
+	assert.Nil(fired(tie))
+
+	f.AdvaceTime(time.Minute)
+	assert.Equal(*fired(early.C()), start.Add(time.Hour))
+	assert.Equal(*fired(tie), start.Add(time.Hour))
+	assert.Nil(fired(late.C()))
+
+	f.AdvaceTime(3 * time.Hour)
+	assert.Equal(*fired(late.C()), start.Add(3*time.Hour))
+	assert.Equal(f.Now(), start.Add(4*time.Hour))
+}
+
+func TestFakeTimerValues(t *testing.T) {
+	assert := assert.New(t)
+	f := &Fake{CurrentNow: start}
+	second := f.NewTimer(2 * time.Hour)
+	first := f.NewTimer(time.Hour)
+	ticker := f.NewTicker(90 * time.Minute)
+	f.AdvaceTime(5 * time.Hour)
+	assert.Equal(*fired(ticker.C()), start.Add(90*time.Minute))
+	assert.Equal(*fired(first.C()), start.Add(time.Hour))
+	assert.Equal(*fired(second.C()), start.Add(2*time.Hour))
+	assert.Nil(fired(first.C()))
+}
+
+func TestFakeTimersFireInOrder(t *testing.T) {
+	assert := assert.New(t)
+	f := &Fake{CurrentNow: start}
+	c := f.NewTimer(3 * time.Hour).(*fakeWaiter)
+	a := f.NewTimer(time.Hour).(*fakeWaiter)
+	b := f.NewTimer(time.Hour).(*fakeWaiter)
+	ticker := f.NewTicker(2 * time.Hour).(*fakeTicker).waiter
+
+	order := []*fakeWaiter{}
+	now := []time.Time{}
+	for next := f.fireNext(start.Add(4 * time.Hour)); next != nil; next = f.fireNext(start.Add(4 * time.Hour)) {
+		order = append(order, next)
+		now = append(now, f.CurrentNow)
+	}
+	assert.Equal(order, []*fakeWaiter{a, b, ticker, c, ticker})
+	assert.Equal(now, []time.Time{start.Add(time.Hour), start.Add(time.Hour), start.Add(2 * time.Hour), start.Add(3 * time.Hour), start.Add(4 * time.Hour)})
+}
+
+func TestFakeTimerStop(t *testing.T) {
+	assert := assert.New(t)
+	f := &Fake{CurrentNow: start}
+	timer := f.NewTimer(time.Hour)
+	assert.True(timer.Stop())
+	assert.False(timer.Stop())
+	f.AdvaceTime(2 * time.Hour)
+	assert.Nil(fired(timer.C()))
+
+	other := f.NewTimer(time.Hour)
+	f.AdvaceTime(time.Hour)
+	assert.False(other.Stop())
+	assert.NotNil(fired(other.C()))
+}
+
+func TestFakeTimerReset(t *testing.T) {
+	assert := assert.New(t)
+	f := &Fake{CurrentNow: start}
+	timer := f.NewTimer(time.Hour)
+	f.AdvaceTime(30 * time.Minute)
+	assert.True(timer.Reset(time.Hour))
+	f.AdvaceTime(45 * time.Minute)
+	assert.Nil(fired(timer.C()))
+	f.AdvaceTime(15 * time.Minute)
+	assert.Equal(*fired(timer.C()), start.Add(90*time.Minute))
+	assert.False(timer.Reset(time.Minute))
+	f.AdvaceTime(time.Minute)
+	assert.NotNil(fired(timer.C()))
+}
+
+func TestFakeTicker(t *testing.T) {
+	assert := assert.New(t)
+	f := &Fake{CurrentNow: start}
+	ticker := f.NewTicker(time.Minute)
+	ticks := []time.Time{}
+	for i := 0; i < 3; i++ {
+		f.AdvaceTime(time.Minute)
+		ticks = append(ticks, *fired(ticker.C()))
+	}
+	assert.Equal(ticks, []time.Time{start.Add(time.Minute), start.Add(2 * time.Minute), start.Add(3 * time.Minute)})
+
+	// like time.Ticker, ticks are dropped for slow receivers
+	f.AdvaceTime(10 * time.Minute)
+	assert.Equal(*fired(ticker.C()), start.Add(4*time.Minute))
+	assert.Nil(fired(ticker.C()))
+
+	ticker.Stop()
+	f.AdvaceTime(10 * time.Minute)
+	assert.Nil(fired(ticker.C()))
+}
+
+func TestFakeBlockUntil(t *testing.T) {
+	assert := assert.New(t)
+	f := &Fake{CurrentNow: start}
+	result := make(chan time.Time)
+	go func() {
+		result <- <-f.After(time.Minute)
+	}()
+	f.BlockUntil(1)
+	f.AdvaceTime(time.Minute)
+	assert.Equal(<-result, start.Add(time.Minute))
+}
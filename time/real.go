@@ -5,6 +5,14 @@ import "time"
 type Real struct {
 }
 
+type realTimer struct {
+	timer *time.Timer
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
 func NewReal() *Real {
 	return &Real{}
 }
@@ -12,3 +20,35 @@ func NewReal() *Real {
 func (r *Real) Now() time.Time {
 	return time.Now()
 }
+
+func (r *Real) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (r *Real) NewTimer(d time.Duration) Timer {
+	return &realTimer{timer: time.NewTimer(d)}
+}
+
+func (r *Real) NewTicker(d time.Duration) Ticker {
+	return &realTicker{ticker: time.NewTicker(d)}
+}
+
+func (t *realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t *realTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+func (t *realTimer) Reset(d time.Duration) bool {
+	return t.timer.Reset(d)
+}
+
+func (t *realTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t *realTicker) Stop() {
+	t.ticker.Stop()
+}
@@ -52,6 +52,15 @@ func (m *Memory) GetExternalGroup(groupID string) (*ExternalGroup, error) {
 	return group, nil
 }
 
+func (m *Memory) SetGroupTimezone(groupID string, timezone string) error {
+	group, found := m.groups[groupID]
+	if !found {
+		return GroupNotFound
+	}
+	m.groups[groupID] = &ExternalGroup{ID: group.ID, Source: group.Source, Timezone: timezone}
+	return nil
+}
+
 func (m *Memory) GetUsers(userIDs []string) (map[string]*ExternalUser, error) {
 	retval := map[string]*ExternalUser{}
 	for _, userID := range userIDs {
@@ -68,3 +68,7 @@ func TestGetUsersMemory(t *testing.T) {
 	t.Parallel()
 	testGetUsers(t, NewMemory())
 }
+
+func TestGroupTimezoneMemory(t *testing.T) {
+	testGroupTimezone(t, NewMemory())
+}
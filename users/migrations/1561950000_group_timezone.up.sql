@@ -0,0 +1 @@
+ALTER TABLE groups ADD COLUMN timezone VARCHAR(255) NOT NULL DEFAULT '';
@@ -100,10 +100,10 @@ func (p *Postgres) GetOrCreateGroup(group *ExternalGroup) (string, error) {
 
 func (p *Postgres) GetExternalGroup(groupID string) (*ExternalGroup, error) {
 	query := `
-	SELECT external_id, source FROM groups WHERE id = $1
+	SELECT external_id, source, timezone FROM groups WHERE id = $1
 	`
 	ext := &ExternalGroup{}
-	err := p.db.QueryRow(query, groupID).Scan(&ext.ID, &ext.Source)
+	err := p.db.QueryRow(query, groupID).Scan(&ext.ID, &ext.Source, &ext.Timezone)
 	if err != nil {
 		if err, ok := err.(*pq.Error); ok && err.Routine == "pg_atoi" {
 			return nil, GroupNotFound
@@ -117,6 +117,29 @@ func (p *Postgres) GetExternalGroup(groupID string) (*ExternalGroup, error) {
 	return ext, nil
 }
 
+func (p *Postgres) SetGroupTimezone(groupID string, timezone string) error {
+	query := `
+	UPDATE groups SET timezone = $1 WHERE id = $2
+	`
+	result, err := p.db.Exec(query, timezone, groupID)
+	if err != nil {
+		if err, ok := err.(*pq.Error); ok && err.Routine == "pg_atoi" {
+			return GroupNotFound
+		}
+		log.Print("failed to set group timezone")
+		return err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Print("failed to set group timezone")
+		return err
+	}
+	if count == 0 {
+		return GroupNotFound
+	}
+	return nil
+}
+
 func (p *Postgres) GetUsers(userIDs []string) (map[string]*ExternalUser, error) {
 	if len(userIDs) == 0 {
 		return map[string]*ExternalUser{}, nil
@@ -82,3 +82,7 @@ func TestGetNoExistingGroupPostgres(t *testing.T) {
 func TestGetUsersPostgres(t *testing.T) {
 	testGetUsers(t, getPostgres(t))
 }
+
+func TestGroupTimezonePostgres(t *testing.T) {
+	testGroupTimezone(t, getPostgres(t))
+}
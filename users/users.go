@@ -1,12 +1,16 @@
 package users
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 type Source int
 
 const (
 	SourceCustom = iota
 	SourceTelegram
+	SourceEmail
 )
 
 var UserNotFound = errors.New("User not found")
@@ -19,8 +23,9 @@ type ExternalUser struct {
 }
 
 type ExternalGroup struct {
-	ID     string
-	Source Source
+	ID       string
+	Source   Source
+	Timezone string
 }
 
 type Factory interface {
@@ -29,4 +34,16 @@ type Factory interface {
 	GetOrCreateGroup(group *ExternalGroup) (string, error)
 	GetExternalGroup(groupID string) (*ExternalGroup, error)
 	GetUsers(userIDs []string) (map[string]*ExternalUser, error)
+	SetGroupTimezone(groupID string, timezone string) error
+}
+
+func (g *ExternalGroup) Location(fallback *time.Location) *time.Location {
+	if g.Timezone == "" {
+		return fallback
+	}
+	location, err := time.LoadLocation(g.Timezone)
+	if err != nil {
+		return fallback
+	}
+	return location
 }
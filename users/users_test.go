@@ -179,3 +179,27 @@ func testGetUsers(t *testing.T, f Factory) {
 	assert.Equal(users[ID], u1)
 	assert.Equal(users[ID2], u2)
 }
+
+func testGroupTimezone(t *testing.T, f Factory) {
+	assert := assert.New(t)
+	ID, err := f.GetOrCreateGroup(&ExternalGroup{ID: "ABC", Source: SourceTelegram})
+	assert.NoError(err)
+
+	group, err := f.GetExternalGroup(ID)
+	assert.NoError(err)
+	assert.Equal(group.Timezone, "")
+
+	assert.NoError(f.SetGroupTimezone(ID, "Europe/Madrid"))
+	group, err = f.GetExternalGroup(ID)
+	assert.NoError(err)
+	assert.Equal(group, &ExternalGroup{ID: "ABC", Source: SourceTelegram, Timezone: "Europe/Madrid"})
+
+	ID2, err := f.GetOrCreateGroup(&ExternalGroup{ID: "ABC", Source: SourceTelegram})
+	assert.NoError(err)
+	assert.Equal(ID, ID2)
+	group, err = f.GetExternalGroup(ID)
+	assert.NoError(err)
+	assert.Equal(group.Timezone, "Europe/Madrid")
+
+	assert.Equal(f.SetGroupTimezone("1234", "Europe/Madrid"), GroupNotFound)
+}
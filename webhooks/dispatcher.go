@@ -0,0 +1,133 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	ftime "github.com/seppo0010/boardgamesorganizer/time"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const SignatureHeader = "X-BGO-Signature"
+const EventHeader = "X-BGO-Event"
+const DeliveryHeader = "X-BGO-Delivery"
+
+type Dispatcher struct {
+	Store       Store
+	Client      *http.Client
+	MaxAttempts int
+	Backoff     time.Duration
+	timeFactory ftime.Factory
+	wg          sync.WaitGroup
+}
+
+func NewDispatcher(store Store) *Dispatcher {
+	return &Dispatcher{
+		Store:       store,
+		Client:      &http.Client{Timeout: 10 * time.Second},
+		MaxAttempts: 5,
+		Backoff:     time.Second,
+		timeFactory: ftime.NewReal(),
+	}
+}
+
+func (d *Dispatcher) SetTimeFactory(tf ftime.Factory) {
+	d.timeFactory = tf
+}
+
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Fire delivers the payload to every subscription of the group interested in
+// its event. Deliveries run in the background; use Wait to block until they
+// are done.
+func (d *Dispatcher) Fire(payload *Payload) {
+	subscriptions, err := d.Store.GetSubscriptions(payload.GroupID)
+	if err != nil {
+		log.Printf("failed to get webhook subscriptions: %#v", err)
+		return
+	}
+	payload.Time = d.timeFactory.Now()
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("failed to encode webhook payload: %#v", err)
+		return
+	}
+	for _, subscription := range subscriptions {
+		if !subscription.Wants(payload.Event) {
+			continue
+		}
+		deliveryID, err := randomHex(16)
+		if err != nil {
+			log.Printf("failed to generate webhook delivery id for %s: %#v", subscription.ID, err)
+			continue
+		}
+		d.wg.Add(1)
+		go func(subscription *Subscription) {
+			defer d.wg.Done()
+			d.deliver(subscription, deliveryID, payload.Event, body)
+		}(subscription)
+	}
+}
+
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) deliver(subscription *Subscription, deliveryID string, event Event, body []byte) {
+	backoff := d.Backoff
+	for attempt := 1; attempt <= d.MaxAttempts; attempt++ {
+		delivery := &Delivery{
+			ID:             deliveryID,
+			SubscriptionID: subscription.ID,
+			Event:          event,
+			Payload:        string(body),
+			Attempt:        attempt,
+			Time:           d.timeFactory.Now(),
+		}
+		statusCode, err := d.post(subscription, deliveryID, event, body)
+		delivery.StatusCode = statusCode
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		if err := d.Store.LogDelivery(delivery); err != nil {
+			log.Printf("failed to log webhook delivery: %#v", err)
+		}
+		if delivery.Error == "" {
+			return
+		}
+		if attempt < d.MaxAttempts {
+			<-d.timeFactory.After(backoff)
+			backoff *= 2
+		}
+	}
+}
+
+func (d *Dispatcher) post(subscription *Subscription, deliveryID string, event Event, body []byte) (int, error) {
+	req, err := http.NewRequest("POST", subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, body))
+	req.Header.Set(EventHeader, string(event))
+	req.Header.Set(DeliveryHeader, deliveryID)
+	res, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
@@ -0,0 +1,194 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	ftime "github.com/seppo0010/boardgamesorganizer/time"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type received struct {
+	event     string
+	delivery  string
+	signature string
+	body      []byte
+}
+
+type receiver struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	requests []*received
+	failures int
+}
+
+func newReceiver(failures int) *receiver {
+	r := &receiver{failures: failures}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.requests = append(r.requests, &received{
+			event:     req.Header.Get(EventHeader),
+			delivery:  req.Header.Get(DeliveryHeader),
+			signature: req.Header.Get(SignatureHeader),
+			body:      body,
+		})
+		if r.failures > 0 {
+			r.failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	return r
+}
+
+func (r *receiver) events() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	events := make([]string, len(r.requests))
+	for i, req := range r.requests {
+		events[i] = req.event
+	}
+	return events
+}
+
+func newTestDispatcher(store Store) (*Dispatcher, *ftime.Fake) {
+	d := NewDispatcher(store)
+	d.MaxAttempts = 3
+	tf := &ftime.Fake{CurrentNow: time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC)}
+	d.SetTimeFactory(tf)
+	return d, tf
+}
+
+func TestDispatcherSignsPayload(t *testing.T) {
+	assert := assert.New(t)
+	r := newReceiver(0)
+	defer r.Close()
+	store := NewMemory()
+	sub := &Subscription{GroupID: "1", URL: r.URL, Secret: "secret", Events: []Event{EventMeetingCreated}}
+	assert.NoError(store.CreateSubscription(sub))
+	d, _ := newTestDispatcher(store)
+
+	d.Fire(&Payload{Event: EventMeetingCreated, GroupID: "1", Meeting: &Meeting{ID: "3", Location: "Home"}})
+	d.Fire(&Payload{Event: EventMeetingClosed, GroupID: "1"})
+	d.Fire(&Payload{Event: EventMeetingCreated, GroupID: "2"})
+	d.Wait()
+
+	assert.Equal(len(r.requests), 1)
+	req := r.requests[0]
+	assert.Equal(req.event, string(EventMeetingCreated))
+	assert.Equal(req.signature, Sign("secret", req.body))
+	assert.NotEqual(req.signature, Sign("other", req.body))
+	payload := &Payload{}
+	assert.NoError(json.Unmarshal(req.body, payload))
+	assert.Equal(payload.GroupID, "1")
+	assert.Equal(payload.Meeting.ID, "3")
+	assert.True(payload.Time.Equal(time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC)))
+
+	deliveries, err := store.GetDeliveries(sub.ID)
+	assert.NoError(err)
+	assert.Equal(len(deliveries), 1)
+	assert.Equal(deliveries[0].ID, req.delivery)
+	assert.Equal(deliveries[0].StatusCode, http.StatusNoContent)
+	assert.Equal(deliveries[0].Error, "")
+}
+
+func TestDispatcherRetries(t *testing.T) {
+	assert := assert.New(t)
+	r := newReceiver(2)
+	defer r.Close()
+	store := NewMemory()
+	sub := &Subscription{GroupID: "1", URL: r.URL, Secret: "secret", Events: []Event{EventMeetingCreated}}
+	assert.NoError(store.CreateSubscription(sub))
+	d, tf := newTestDispatcher(store)
+	retry := func() {
+		tf.BlockUntil(1)
+		tf.AdvaceTime(time.Second)
+		tf.BlockUntil(1)
+		tf.AdvaceTime(2 * time.Second)
+		d.Wait()
+	}
+
+	d.Fire(&Payload{Event: EventMeetingCreated, GroupID: "1"})
+	retry()
+
+	assert.Equal(len(r.requests), 3)
+	assert.Equal(r.requests[0].delivery, r.requests[2].delivery)
+	deliveries, err := store.GetDeliveries(sub.ID)
+	assert.NoError(err)
+	assert.Equal(len(deliveries), 3)
+	for i, delivery := range deliveries {
+		assert.Equal(delivery.Attempt, i+1)
+	}
+	assert.Equal(deliveries[0].StatusCode, http.StatusInternalServerError)
+	assert.Equal(deliveries[0].Error, "unexpected status 500")
+	assert.Equal(deliveries[2].StatusCode, http.StatusNoContent)
+	assert.Equal(deliveries[1].Time.Sub(deliveries[0].Time), time.Second)
+	assert.Equal(deliveries[2].Time.Sub(deliveries[1].Time), 2*time.Second)
+
+	r.failures = 5
+	d.Fire(&Payload{Event: EventMeetingCreated, GroupID: "1"})
+	retry()
+	assert.Equal(len(r.requests), 6)
+	deliveries, err = store.GetDeliveries(sub.ID)
+	assert.NoError(err)
+	assert.Equal(len(deliveries), 6)
+	assert.NotEqual(deliveries[5].Error, "")
+}
+
+func TestMeetingsFiresEvents(t *testing.T) {
+	assert := assert.New(t)
+	r := newReceiver(0)
+	defer r.Close()
+	store := NewMemory()
+	assert.NoError(store.CreateSubscription(&Subscription{GroupID: "1", URL: r.URL, Secret: "secret", Events: Events}))
+	d, _ := newTestDispatcher(store)
+
+	tf := &ftime.Fake{CurrentNow: time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC)}
+	mf := meetings.NewFactory(NewMeetings(meetings.NewMemory().Inner, d))
+	mf.SetTimeFactory(tf)
+
+	seen := 0
+	expectEvents := func(events ...Event) {
+		d.Wait()
+		expected := make([]string, len(events))
+		for i, event := range events {
+			expected[i] = string(event)
+		}
+		all := r.events()
+		assert.ElementsMatch(all[seen:], expected)
+		seen = len(all)
+	}
+
+	assert.NoError(mf.CreateMeeting("1", &meetings.Meeting{Time: time.Date(2019, 5, 2, 20, 3, 7, 0, time.UTC), Location: "Home", Capacity: 2}))
+	expectEvents(EventMeetingCreated)
+	assert.NoError(mf.UserRSVPMeeting("1", &meetings.Attendee{UserID: "1", Amount: 1}))
+	expectEvents(EventRSVPChanged)
+	assert.NoError(mf.UserRSVPMeeting("1", &meetings.Attendee{UserID: "2", Amount: 1}))
+	expectEvents(EventRSVPChanged, EventMeetingFull)
+	assert.Equal(mf.UserRSVPMeeting("1", &meetings.Attendee{UserID: "3", Amount: 1}), meetings.MeetingIsFull)
+	expectEvents()
+	assert.NoError(mf.UserRSVPMeeting("1", &meetings.Attendee{UserID: "2", Amount: 1, Maybe: true}))
+	expectEvents(EventRSVPChanged)
+	assert.NoError(mf.UserRSVPMeeting("1", &meetings.Attendee{UserID: "2", Amount: 1}))
+	expectEvents(EventRSVPChanged, EventMeetingFull)
+	// the factory keeps meetings from overflowing, but an already full
+	// meeting must not be reported again if it does
+	assert.NoError(mf.Inner.UserRSVPMeeting("1", &meetings.Attendee{UserID: "4", Amount: 1}))
+	expectEvents(EventRSVPChanged)
+	tf.CurrentNow = time.Date(2019, 5, 3, 20, 3, 7, 0, time.UTC)
+	_, err := mf.GetMeeting("1")
+	assert.Equal(err, meetings.NoActiveMeeting)
+	expectEvents(EventMeetingClosed)
+	assert.NoError(mf.CreateMeeting("1", &meetings.Meeting{Time: time.Date(2019, 5, 4, 20, 3, 7, 0, time.UTC), Location: "Bar"}))
+	expectEvents(EventMeetingCreated)
+	assert.NoError(mf.CancelMeeting("1"))
+	expectEvents(EventMeetingCancelled)
+}
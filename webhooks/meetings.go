@@ -0,0 +1,107 @@
+package webhooks
+
+import (
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"log"
+)
+
+// Meetings wraps a meetings backend and fires webhooks for every lifecycle
+// change that goes through it.
+type Meetings struct {
+	meetings.Inner
+	Dispatcher *Dispatcher
+}
+
+func NewMeetings(inner meetings.Inner, dispatcher *Dispatcher) *Meetings {
+	return &Meetings{Inner: inner, Dispatcher: dispatcher}
+}
+
+func (m *Meetings) CreateMeeting(groupID string, meeting *meetings.Meeting) error {
+	err := m.Inner.CreateMeeting(groupID, meeting)
+	if err != nil {
+		return err
+	}
+	m.Dispatcher.Fire(&Payload{Event: EventMeetingCreated, GroupID: groupID, Meeting: meetingPayload(meeting)})
+	return nil
+}
+
+// taken counts the seats of everyone going to the active meeting
+func (m *Meetings) taken(groupID string) (int, error) {
+	attendees, err := m.Inner.GetMeetingAttendees(groupID)
+	if err != nil {
+		return 0, err
+	}
+	taken := 0
+	for _, att := range attendees {
+		if att.Going() {
+			taken += att.Amount
+		}
+	}
+	return taken, nil
+}
+
+func (m *Meetings) UserRSVPMeeting(groupID string, attendee *meetings.Attendee) error {
+	// meeting.full only fires when this RSVP fills the meeting
+	takenBefore, err := m.taken(groupID)
+	if err != nil {
+		takenBefore = -1
+	}
+	err = m.Inner.UserRSVPMeeting(groupID, attendee)
+	if err != nil {
+		return err
+	}
+	meeting, err := m.Inner.GetMeeting(groupID)
+	if err != nil {
+		log.Printf("failed to get meeting for webhook: %#v", err)
+		return nil
+	}
+	m.Dispatcher.Fire(&Payload{
+		Event:    EventRSVPChanged,
+		GroupID:  groupID,
+		Meeting:  meetingPayload(meeting),
+		Attendee: &Attendee{UserID: attendee.UserID, Amount: attendee.Amount, Maybe: attendee.Maybe},
+	})
+	if meeting.Capacity <= 0 || !attendee.Going() || takenBefore < 0 || takenBefore >= meeting.Capacity {
+		return nil
+	}
+	taken, err := m.taken(groupID)
+	if err != nil {
+		log.Printf("failed to get meeting attendees for webhook: %#v", err)
+		return nil
+	}
+	if taken >= meeting.Capacity {
+		m.Dispatcher.Fire(&Payload{Event: EventMeetingFull, GroupID: groupID, Meeting: meetingPayload(meeting)})
+	}
+	return nil
+}
+
+func (m *Meetings) CloseMeeting(groupID string) error {
+	meeting, err := m.Inner.GetMeeting(groupID)
+	if err != nil {
+		return err
+	}
+	err = m.Inner.CloseMeeting(groupID)
+	if err != nil {
+		return err
+	}
+	payload := meetingPayload(meeting)
+	payload.Closed = true
+	m.Dispatcher.Fire(&Payload{Event: EventMeetingClosed, GroupID: groupID, Meeting: payload})
+	return nil
+}
+
+func (m *Meetings) CancelMeeting(groupID string) error {
+	meeting, err := m.Inner.GetMeeting(groupID)
+	if err != nil {
+		return err
+	}
+	err = m.Inner.CancelMeeting(groupID)
+	if err != nil {
+		return err
+	}
+	payload := meetingPayload(meeting)
+	payload.Closed = true
+	payload.Cancelled = true
+	m.Dispatcher.Fire(&Payload{Event: EventMeetingCancelled, GroupID: groupID, Meeting: payload})
+	return nil
+}
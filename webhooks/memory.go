@@ -0,0 +1,61 @@
+package webhooks
+
+import (
+	"strconv"
+	"sync"
+)
+
+type Memory struct {
+	mutex              sync.Mutex
+	lastSubscriptionID int
+	subscriptions      map[string][]*Subscription
+	deliveries         map[string][]*Delivery
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		subscriptions: map[string][]*Subscription{},
+		deliveries:    map[string][]*Delivery{},
+	}
+}
+
+func (m *Memory) CreateSubscription(subscription *Subscription) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.lastSubscriptionID++
+	subscription.ID = strconv.Itoa(m.lastSubscriptionID)
+	m.subscriptions[subscription.GroupID] = append(m.subscriptions[subscription.GroupID], subscription)
+	return nil
+}
+
+func (m *Memory) DeleteSubscription(groupID string, subscriptionID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for i, subscription := range m.subscriptions[groupID] {
+		if subscription.ID == subscriptionID {
+			m.subscriptions[groupID] = append(m.subscriptions[groupID][:i], m.subscriptions[groupID][i+1:]...)
+			delete(m.deliveries, subscriptionID)
+			return nil
+		}
+	}
+	return SubscriptionNotFound
+}
+
+func (m *Memory) GetSubscriptions(groupID string) ([]*Subscription, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]*Subscription{}, m.subscriptions[groupID]...), nil
+}
+
+func (m *Memory) LogDelivery(delivery *Delivery) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.deliveries[delivery.SubscriptionID] = append(m.deliveries[delivery.SubscriptionID], delivery)
+	return nil
+}
+
+func (m *Memory) GetDeliveries(subscriptionID string) ([]*Delivery, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]*Delivery{}, m.deliveries[subscriptionID]...), nil
+}
@@ -0,0 +1,11 @@
+package webhooks
+
+import "testing"
+
+func TestSubscriptionsMemory(t *testing.T) {
+	testSubscriptions(t, NewMemory())
+}
+
+func TestDeliveriesMemory(t *testing.T) {
+	testDeliveries(t, NewMemory())
+}
@@ -0,0 +1,19 @@
+CREATE TABLE webhook_subscriptions (
+    id serial PRIMARY KEY,
+    group_id VARCHAR (255) NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR (255) NOT NULL,
+    events TEXT[] NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+    id serial PRIMARY KEY,
+    delivery_id VARCHAR (255) NOT NULL,
+    subscription_id INTEGER NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event VARCHAR (255) NOT NULL,
+    payload TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER NOT NULL,
+    error TEXT NOT NULL,
+    time TIMESTAMPTZ NOT NULL
+);
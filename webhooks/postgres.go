@@ -0,0 +1,161 @@
+package webhooks
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/lib/pq"
+	"log"
+	"strconv"
+)
+
+type PostgresConfig struct {
+	URL            string
+	MigrationsPath string
+}
+
+type Postgres struct {
+	db *sql.DB
+}
+
+func NewPostgres(config *PostgresConfig) (*Postgres, error) {
+	db, err := sql.Open("postgres", config.URL)
+	if err != nil {
+		log.Print("failed to connect to postgres database")
+		return nil, err
+	}
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		log.Print("failed to start driver")
+		return nil, err
+	}
+	m, err := migrate.NewWithDatabaseInstance(fmt.Sprintf("file://%s", config.MigrationsPath), "postgres", driver)
+	if err != nil {
+		log.Print("failed to start migrations")
+		return nil, err
+	}
+	err = m.Up()
+	if err != nil && err != migrate.ErrNoChange {
+		log.Print("failed to run migrations")
+		return nil, err
+	}
+	return &Postgres{db: db}, nil
+}
+
+func (p *Postgres) CreateSubscription(subscription *Subscription) error {
+	query := `
+	INSERT INTO webhook_subscriptions (group_id, url, secret, events)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id;
+	`
+	events := make([]string, len(subscription.Events))
+	for i, event := range subscription.Events {
+		events[i] = string(event)
+	}
+	id := 0
+	err := p.db.QueryRow(query, subscription.GroupID, subscription.URL, subscription.Secret, pq.Array(events)).Scan(&id)
+	if err != nil {
+		log.Print("failed to create webhook subscription")
+		return err
+	}
+	subscription.ID = strconv.Itoa(id)
+	return nil
+}
+
+func (p *Postgres) DeleteSubscription(groupID string, subscriptionID string) error {
+	id, err := strconv.Atoi(subscriptionID)
+	if err != nil {
+		return SubscriptionNotFound
+	}
+	query := `
+	DELETE FROM webhook_subscriptions WHERE group_id = $1 AND id = $2
+	`
+	res, err := p.db.Exec(query, groupID, id)
+	if err != nil {
+		log.Print("failed to delete webhook subscription")
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		log.Print("failed to delete webhook subscription")
+		return err
+	}
+	if count == 0 {
+		return SubscriptionNotFound
+	}
+	return nil
+}
+
+func (p *Postgres) GetSubscriptions(groupID string) ([]*Subscription, error) {
+	query := `
+	SELECT id, url, secret, events FROM webhook_subscriptions WHERE group_id = $1 ORDER BY id
+	`
+	rows, err := p.db.Query(query, groupID)
+	if err != nil {
+		log.Print("failed to get webhook subscriptions")
+		return nil, err
+	}
+	defer rows.Close()
+	subscriptions := []*Subscription{}
+	for rows.Next() {
+		id := 0
+		events := []string{}
+		subscription := &Subscription{GroupID: groupID}
+		err = rows.Scan(&id, &subscription.URL, &subscription.Secret, pq.Array(&events))
+		if err != nil {
+			log.Print("failed to read webhook subscription")
+			return nil, err
+		}
+		subscription.ID = strconv.Itoa(id)
+		for _, event := range events {
+			subscription.Events = append(subscription.Events, Event(event))
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+func (p *Postgres) LogDelivery(delivery *Delivery) error {
+	query := `
+	INSERT INTO webhook_deliveries (delivery_id, subscription_id, event, payload, attempt, status_code, error, time)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := p.db.Exec(query, delivery.ID, delivery.SubscriptionID, string(delivery.Event), delivery.Payload, delivery.Attempt, delivery.StatusCode, delivery.Error, delivery.Time)
+	if err != nil {
+		log.Print("failed to log webhook delivery")
+		return err
+	}
+	return nil
+}
+
+func (p *Postgres) GetDeliveries(subscriptionID string) ([]*Delivery, error) {
+	id, err := strconv.Atoi(subscriptionID)
+	if err != nil {
+		return []*Delivery{}, nil
+	}
+	query := `
+	SELECT delivery_id, event, payload, attempt, status_code, error, time
+	FROM webhook_deliveries WHERE subscription_id = $1 ORDER BY id
+	`
+	rows, err := p.db.Query(query, id)
+	if err != nil {
+		log.Print("failed to get webhook deliveries")
+		return nil, err
+	}
+	defer rows.Close()
+	deliveries := []*Delivery{}
+	for rows.Next() {
+		event := ""
+		delivery := &Delivery{SubscriptionID: subscriptionID}
+		err = rows.Scan(&delivery.ID, &event, &delivery.Payload, &delivery.Attempt, &delivery.StatusCode, &delivery.Error, &delivery.Time)
+		if err != nil {
+			log.Print("failed to read webhook delivery")
+			return nil, err
+		}
+		delivery.Event = Event(event)
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,127 @@
+package webhooks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"github.com/seppo0010/boardgamesorganizer/meetings"
+	"time"
+)
+
+type Event string
+
+const (
+	EventMeetingCreated   Event = "meeting.created"
+	EventRSVPChanged      Event = "rsvp.changed"
+	EventMeetingFull      Event = "meeting.full"
+	EventMeetingClosed    Event = "meeting.closed"
+	EventMeetingCancelled Event = "meeting.cancelled"
+)
+
+var Events = []Event{
+	EventMeetingCreated,
+	EventRSVPChanged,
+	EventMeetingFull,
+	EventMeetingClosed,
+	EventMeetingCancelled,
+}
+
+var SubscriptionNotFound = errors.New("Webhook subscription not found")
+
+type Subscription struct {
+	ID      string
+	GroupID string
+	URL     string
+	Secret  string
+	Events  []Event
+}
+
+func (s *Subscription) Wants(event Event) bool {
+	for _, e := range s.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+type Delivery struct {
+	ID             string
+	SubscriptionID string
+	Event          Event
+	Payload        string
+	Attempt        int
+	StatusCode     int
+	Error          string
+	Time           time.Time
+}
+
+type Meeting struct {
+	ID        string     `json:"id"`
+	Time      time.Time  `json:"time"`
+	Location  string     `json:"location"`
+	Capacity  int        `json:"capacity"`
+	Closed    bool       `json:"closed"`
+	Cancelled bool       `json:"cancelled"`
+	End       *time.Time `json:"end,omitempty"`
+}
+
+type Attendee struct {
+	UserID string `json:"user_id"`
+	Amount int    `json:"amount"`
+	Maybe  bool   `json:"maybe,omitempty"`
+}
+
+type Payload struct {
+	Event    Event     `json:"event"`
+	GroupID  string    `json:"group_id"`
+	Time     time.Time `json:"time"`
+	Meeting  *Meeting  `json:"meeting,omitempty"`
+	Attendee *Attendee `json:"attendee,omitempty"`
+}
+
+type Store interface {
+	CreateSubscription(subscription *Subscription) error
+	DeleteSubscription(groupID string, subscriptionID string) error
+	GetSubscriptions(groupID string) ([]*Subscription, error)
+	LogDelivery(delivery *Delivery) error
+	GetDeliveries(subscriptionID string) ([]*Delivery, error)
+}
+
+func IsEvent(event Event) bool {
+	for _, e := range Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func NewSecret() (string, error) {
+	return randomHex(32)
+}
+
+func randomHex(size int) (string, error) {
+	b := make([]byte, size)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func meetingPayload(meeting *meetings.Meeting) *Meeting {
+	payload := &Meeting{
+		ID:        meeting.ID,
+		Time:      meeting.Time,
+		Location:  meeting.Location,
+		Capacity:  meeting.Capacity,
+		Closed:    meeting.Closed,
+		Cancelled: meeting.Cancelled,
+	}
+	if meeting.Duration > 0 {
+		end := meeting.End()
+		payload.End = &end
+	}
+	return payload
+}
@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func testSubscriptions(t *testing.T, s Store) {
+	assert := assert.New(t)
+
+	subscriptions, err := s.GetSubscriptions("1")
+	assert.NoError(err)
+	assert.Equal(len(subscriptions), 0)
+
+	sub := &Subscription{GroupID: "1", URL: "http://example.com/hook", Secret: "secret", Events: []Event{EventMeetingCreated, EventRSVPChanged}}
+	assert.NoError(s.CreateSubscription(sub))
+	assert.NotEqual(sub.ID, "")
+	other := &Subscription{GroupID: "2", URL: "http://example.com/other", Secret: "secret", Events: []Event{EventMeetingClosed}}
+	assert.NoError(s.CreateSubscription(other))
+	assert.NotEqual(sub.ID, other.ID)
+
+	subscriptions, err = s.GetSubscriptions("1")
+	assert.NoError(err)
+	assert.Equal(subscriptions, []*Subscription{sub})
+
+	assert.Equal(s.DeleteSubscription("2", sub.ID), SubscriptionNotFound)
+	assert.NoError(s.DeleteSubscription("1", sub.ID))
+	assert.Equal(s.DeleteSubscription("1", sub.ID), SubscriptionNotFound)
+	subscriptions, err = s.GetSubscriptions("1")
+	assert.NoError(err)
+	assert.Equal(len(subscriptions), 0)
+}
+
+func testDeliveries(t *testing.T, s Store) {
+	assert := assert.New(t)
+
+	sub := &Subscription{GroupID: "1", URL: "http://example.com/hook", Secret: "secret", Events: []Event{EventMeetingCreated}}
+	assert.NoError(s.CreateSubscription(sub))
+
+	deliveries, err := s.GetDeliveries(sub.ID)
+	assert.NoError(err)
+	assert.Equal(len(deliveries), 0)
+
+	first := &Delivery{ID: "abc", SubscriptionID: sub.ID, Event: EventMeetingCreated, Payload: "{}", Attempt: 1, StatusCode: 500, Error: "unexpected status 500", Time: time.Date(2019, 5, 1, 17, 3, 7, 0, time.UTC)}
+	second := &Delivery{ID: "abc", SubscriptionID: sub.ID, Event: EventMeetingCreated, Payload: "{}", Attempt: 2, StatusCode: 200, Time: time.Date(2019, 5, 1, 17, 3, 8, 0, time.UTC)}
+	assert.NoError(s.LogDelivery(first))
+	assert.NoError(s.LogDelivery(second))
+
+	deliveries, err = s.GetDeliveries(sub.ID)
+	assert.NoError(err)
+	assert.Equal(len(deliveries), 2)
+	for i, expected := range []*Delivery{first, second} {
+		assert.True(deliveries[i].Time.Equal(expected.Time))
+		deliveries[i].Time = expected.Time
+		assert.Equal(deliveries[i], expected)
+	}
+
+	assert.NoError(s.DeleteSubscription("1", sub.ID))
+	deliveries, err = s.GetDeliveries(sub.ID)
+	assert.NoError(err)
+	assert.Equal(len(deliveries), 0)
+}